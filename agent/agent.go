@@ -6,11 +6,15 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/firecracker-microvm/firecracker-go-sdk/vsock"
+	"github.com/hostinger/fireactions/agent/hooks"
 	"github.com/hostinger/fireactions/agent/runner"
+	"github.com/hostinger/fireactions/agent/stats"
+	"github.com/hostinger/fireactions/helper/logger"
 	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
@@ -20,15 +24,39 @@ import (
 
 const (
 	logFilePath = "/var/log/fireactions-agent.log"
+
+	// The log file is rotated once it reaches logFileMaxSize, keeping
+	// logFileMaxBackups rotated files
+	logFileMaxSize    = 10 * 1024 * 1024
+	logFileMaxBackups = 3
 )
 
 type Agent struct {
 	agentv1.UnimplementedAgentServiceServer
 	cfg           Config
 	logFile       string
-	logFileWriter *os.File
+	runnerDir     string
+	logFileWriter *logger.File
 	logger        *zerolog.Logger
 	runner        *runner.Runner
+	hooks         *hooks.Manager
+	host          agentv1.HostServiceClient
+	stats         *stats.Collector
+	secretsDir    string
+	tempDirs      []string // Emptied between jobs in multi-job mode
+	startedAt     time.Time
+	configLoaded  atomic.Bool
+	awaiting      atomic.Bool // Whether the agent waits for a runner JIT config
+	metadata      func(ctx context.Context) (map[string]interface{}, error)
+	setClock      func(t time.Time) error
+	addEntropy    func(seed []byte) error
+	transitions   chan runner.StateTransition
+	configChanged chan struct{} // Signals the reporter to push the config status
+	version       string        // Runner version, only accessed by the reporter
+	stopping      atomic.Bool   // Whether Shutdown was called
+	runnerDone    chan struct{} // Closed once the runner and post-exit hook are done
+	shutdownOnce  sync.Once
+	powerOff      func() error
 }
 
 type Opt func(a *Agent)
@@ -38,9 +66,26 @@ func New(cfg Config, opts ...Opt) (*Agent, error) {
 		return nil, fmt.Errorf("validate config: %w", cfgErr)
 	}
 
+	cfg.Runner = cfg.Runner.withDefaults()
 	a := &Agent{
-		cfg:     cfg,
-		logFile: logFilePath,
+		cfg:           cfg,
+		logFile:       logFilePath,
+		runnerDir:     cfg.Runner.Dir,
+		secretsDir:    secretsDir,
+		tempDirs:      defaultTempDirs,
+		stats:         stats.New(),
+		transitions:   make(chan runner.StateTransition, 16),
+		configChanged: make(chan struct{}, 1),
+		runnerDone:    make(chan struct{}),
+		powerOff:      reboot,
+		metadata:      getMetadata,
+		setClock:      setClock,
+	}
+
+	if cfg.AwaitRunner {
+		// The disk of the VM is thrown away when it is restored, there is
+		// nothing to shut down cleanly
+		a.powerOff = resetVM
 	}
 
 	for _, opt := range opts {
@@ -55,14 +100,9 @@ func New(cfg Config, opts ...Opt) (*Agent, error) {
 }
 
 func (a *Agent) setupLogger() error {
-	logDir := filepath.Dir(a.logFile)
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("create log directory: %w", err)
-	}
-
-	logFileWriter, err := os.OpenFile(a.logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	logFileWriter, err := logger.NewFile(a.logFile, logFileMaxSize, logFileMaxBackups)
 	if err != nil {
-		return fmt.Errorf("open log file: %w", err)
+		return err
 	}
 
 	a.logFileWriter = logFileWriter
@@ -72,12 +112,19 @@ func (a *Agent) setupLogger() error {
 		return fmt.Errorf("parse log level: %w", err)
 	}
 
-	multiWriter := io.MultiWriter(os.Stdout, logFileWriter)
-	logger := zerolog.New(zerolog.ConsoleWriter{Out: multiWriter, TimeFormat: time.RFC3339}).With().
+	var out io.Writer = io.MultiWriter(os.Stdout, logFileWriter)
+	if a.cfg.LogFormat != logger.FormatJSON {
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339}
+	}
+
+	// The level is set globally so that SetLogLevel applies to the loggers
+	// derived from this one as well
+	zerolog.SetGlobalLevel(logLevel)
+	l := zerolog.New(out).With().
 		Timestamp().
-		Logger().Level(logLevel)
+		Logger().Level(zerolog.TraceLevel)
 
-	a.logger = &logger
+	a.logger = &l
 	return nil
 }
 
@@ -91,12 +138,64 @@ func (a *Agent) Close() error {
 }
 
 func (a *Agent) Run(ctx context.Context) error {
+	a.startedAt = time.Now()
+
 	if err := a.setHostname(); err != nil {
 		return fmt.Errorf("setting hostname: %w", err)
 	}
 
+	if a.cfg.MMDSRootOnly {
+		if err := restrictMMDS(); err != nil {
+			return fmt.Errorf("restricting MMDS: %w", err)
+		}
+	}
+
+	secretsEnv, err := a.installSecrets()
+	if err != nil {
+		return fmt.Errorf("installing secrets: %w", err)
+	}
+
+	a.hooks = hooks.New(a.cfg.Hooks, hooks.WithLogger(a.logger))
+	if err := a.hooks.Install(runnerOwnerIDs(a.cfg.Runner.User)); err != nil {
+		return fmt.Errorf("installing hooks: %w", err)
+	}
+
+	// The server scrubs the JIT config and secrets from MMDS once it sees the
+	// config is loaded. Without the JIT config, it is loaded once received.
+	if !a.cfg.AwaitRunner {
+		a.configLoaded.Store(true)
+	}
+
+	runnerOpts := []runner.Opt{
+		runner.WithLogger(a.logger),
+		runner.WithDirectory(a.runnerDir),
+		runner.WithOwner(a.cfg.Runner.User),
+		runner.WithGroup(a.cfg.Runner.Group),
+		runner.WithArgs(a.cfg.Runner.Args...),
+		runner.WithEnv(a.cfg.Runner.Env...),
+		runner.WithEnv(secretsEnv...),
+		runner.WithEnv(a.hooks.Env()...),
+		runner.WithJobEnvFile(a.hooks.JobEnvPath()),
+		runner.WithStateListener(a.queueTransition),
+	}
+
+	if a.cfg.MultiJob != nil {
+		runnerOpts = append(runnerOpts, runner.WithRenewal())
+	}
+
+	a.runner = runner.New(a.cfg.RunnerJITConfig, runnerOpts...)
+
+	conn, host, err := newHostClient()
+	if err != nil {
+		return fmt.Errorf("creating host client: %w", err)
+	}
+	defer conn.Close()
+
+	a.host = host
+	go a.runReporter(ctx)
+
 	// Run GitHub runner in background - it will trigger shutdown on success
-	go a.runGitHubRunner(ctx)
+	go a.runGitHubRunner(ctx, secretsEnv)
 
 	// Run gRPC server in main flow
 	return a.runGRPCServer(ctx)
@@ -133,14 +232,37 @@ func (a *Agent) runGRPCServer(ctx context.Context) error {
 	}
 }
 
-func (a *Agent) runGitHubRunner(ctx context.Context) {
-	a.runner = runner.New(
-		a.cfg.RunnerJITConfig,
-		runner.WithLogger(a.logger),
-	)
+func (a *Agent) runGitHubRunner(ctx context.Context, env []string) {
+	if err := a.hooks.Run(ctx, hooks.PreStart, env...); err != nil {
+		a.logger.Error().Err(err).Msg("Pre-start hook failed, not starting runner")
+	} else if a.cfg.AwaitRunner && !a.awaitRunner(ctx) {
+		a.logger.Info().Msg("Stopped waiting for a runner JIT config, not starting runner")
+	} else {
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		watchDone := make(chan struct{})
+		go func() {
+			defer close(watchDone)
+			a.hooks.Watch(watchCtx, time.Second)
+		}()
+
+		a.runJobs(ctx)
+
+		watchCancel()
+		<-watchDone
+	}
+
+	if err := a.hooks.Run(ctx, hooks.PostExit, env...); err != nil {
+		a.logger.Error().Err(err).Msg("Post-exit hook failed")
+	}
 
-	if err := a.runner.Run(ctx); err != nil {
-		a.logger.Error().Err(err).Msg("Runner encountered an error")
+	close(a.runnerDone)
+	if a.stopping.Load() {
+		// Shutdown powers the VM off
+		return
+	}
+
+	if a.keepFailedVM(ctx) {
+		return
 	}
 
 	if !a.cfg.ShutdownOnExit {
@@ -152,15 +274,29 @@ func (a *Agent) runGitHubRunner(ctx context.Context) {
 	a.shutdown()
 }
 
+// shutdown flushes the log file and powers the VM off. Only the first call
+// has an effect.
 func (a *Agent) shutdown() {
-	// We don't need to wait for it to complete since the VM will shut down anyway
-	cmd := exec.Command("systemctl", "reboot")
-	if err := cmd.Start(); err != nil {
-		a.logger.Error().Err(err).Msg("Failed to initiate VM shutdown")
-		return
-	}
+	a.shutdownOnce.Do(func() {
+		if a.logFileWriter != nil {
+			if err := a.logFileWriter.Sync(); err != nil {
+				a.logger.Warn().Err(err).Msg("Failed to flush log file")
+			}
+		}
 
-	a.logger.Info().Msg("Shutdown command executed")
+		if err := a.powerOff(); err != nil {
+			a.logger.Error().Err(err).Msg("Failed to initiate VM shutdown")
+			return
+		}
+
+		a.logger.Info().Msg("Shutdown command executed")
+	})
+}
+
+// reboot reboots the VM, which makes Firecracker exit.
+func reboot() error {
+	// We don't need to wait for it to complete since the VM will shut down anyway
+	return exec.Command("systemctl", "reboot").Start()
 }
 
 func (a *Agent) setHostname() error {
@@ -1 +1,44 @@
 package agent
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallSecrets_Env(t *testing.T) {
+	logger := zerolog.Nop()
+	a := &Agent{
+		cfg: Config{Secrets: []Secret{
+			{Name: "CACHE_KEY", Value: "s3cret", Target: secretTargetEnv},
+			{Name: "MIRROR_TOKEN", Value: "token", Target: secretTargetEnv},
+		}},
+		logger:     &logger,
+		secretsDir: t.TempDir(),
+	}
+
+	env, err := a.installSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CACHE_KEY=s3cret", "MIRROR_TOKEN=token"}, env)
+}
+
+func TestSecret_String(t *testing.T) {
+	secret := Secret{Name: "CACHE_KEY", Value: "s3cret", Target: secretTargetFile}
+	assert.Equal(t, "CACHE_KEY (file)", secret.String())
+	assert.NotContains(t, fmt.Sprintf("%v %+v", secret, []Secret{secret}), "s3cret")
+}
+
+func TestQueueTransition(t *testing.T) {
+	logger := zerolog.Nop()
+	a := &Agent{logger: &logger, transitions: make(chan runner.StateTransition, 1)}
+
+	a.queueTransition(runner.StateTransition{From: runner.StateIdle, To: runner.StateRunning})
+	a.queueTransition(runner.StateTransition{From: runner.StateRunning, To: runner.StateCompleted})
+
+	require.Len(t, a.transitions, 1, "transitions are dropped when the queue is full")
+	assert.Equal(t, runner.StateRunning, (<-a.transitions).To)
+}
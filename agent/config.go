@@ -1,13 +1,72 @@
 package agent
 
-import "github.com/go-playground/validator/v10"
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hostinger/fireactions/agent/hooks"
+	"github.com/hostinger/fireactions/agent/runner"
+)
 
 type Config struct {
-	Port            uint32 `validate:"required"`
-	RunnerJITConfig string `validate:"required"`
-	Hostname        string `validate:"required"`
-	LogLevel        string `validate:"required,oneof=debug info warn error fatal panic trace"`
-	ShutdownOnExit  bool   `validate:""`
+	Port              uint32   `validate:"required"`
+	RunnerJITConfig   string   `validate:"required_without=AwaitRunner"`
+	Hostname          string   `validate:"required"`
+	LogLevel          string   `validate:"required,oneof=debug info warn error fatal panic trace"`
+	LogFormat         string   `validate:"omitempty,oneof=console json"`
+	ShutdownOnExit    bool     `validate:""`
+	MMDSRootOnly      bool     `validate:""`
+	Secrets           []Secret `validate:"dive"`
+	Hooks             map[hooks.Name]string
+	HeartbeatInterval time.Duration
+	Runner            RunnerConfig
+	MultiJob          *MultiJobConfig // Unset to run a single job
+
+	// AwaitRunner makes the agent wait for the runner JIT config in MMDS
+	// instead of taking it from RunnerJITConfig. The server snapshots the VM
+	// meanwhile and restores it from the snapshot after every job.
+	AwaitRunner bool
+
+	// KeepOnFailure keeps the VM running after a failed job instead of
+	// powering it off, if the server agrees to hold it for debugging.
+	KeepOnFailure bool
+}
+
+// RunnerConfig configures how the GitHub runner is run. Empty fields fall
+// back to the defaults of the runner package.
+type RunnerConfig struct {
+	Dir   string   `validate:"omitempty,startswith=/"`
+	User  string   `validate:""`
+	Group string   `validate:""`
+	Env   []string `validate:"dive,contains=="` // KEY=value
+	Args  []string `validate:""`                // Passed to run.sh after the JIT config
+}
+
+// MultiJobConfig configures the runner to run several jobs one after the
+// other. The runner is registered again with a JIT config from the server
+// and the workspace is cleaned up between jobs.
+type MultiJobConfig struct {
+	MaxJobs      int           `validate:"min=1"`
+	MaxLifetime  time.Duration `validate:"min=0"` // 0 for no limit
+	CleanupPaths []string      `validate:"dive,startswith=/"`
+}
+
+// withDefaults returns the config with the empty fields set to their
+// defaults.
+func (c RunnerConfig) withDefaults() RunnerConfig {
+	if c.Dir == "" {
+		c.Dir = runner.DefaultDir
+	}
+
+	if c.User == "" {
+		c.User = runner.DefaultOwner
+	}
+
+	if c.Group == "" {
+		c.Group = runner.DefaultGroup
+	}
+
+	return c
 }
 
 func (c Config) Validate() error {
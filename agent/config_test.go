@@ -1 +1,37 @@
 package agent
+
+import (
+	"testing"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunnerConfig_WithDefaults(t *testing.T) {
+	assert.Equal(t, RunnerConfig{Dir: runner.DefaultDir, User: runner.DefaultOwner, Group: runner.DefaultGroup}, RunnerConfig{}.withDefaults())
+
+	cfg := RunnerConfig{Dir: "/home/runner/actions-runner", User: "builder", Group: "builder", Args: []string{"--once"}}
+	assert.Equal(t, cfg, cfg.withDefaults())
+}
+
+func TestConfig_ValidateRunner(t *testing.T) {
+	cfg := Config{Port: 9001, RunnerJITConfig: "config", Hostname: "runner", LogLevel: "info"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Runner = RunnerConfig{Dir: "/opt/actions-runner", Env: []string{"RUNNER_TOOL_CACHE=/cache"}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Runner = RunnerConfig{Dir: "actions-runner"}
+	assert.Error(t, cfg.Validate(), "the directory must be absolute")
+
+	cfg.Runner = RunnerConfig{Env: []string{"RUNNER_TOOL_CACHE"}}
+	assert.Error(t, cfg.Validate(), "env must be in the form KEY=value")
+}
+
+func TestConfig_ValidateAwaitRunner(t *testing.T) {
+	cfg := Config{Port: 9001, Hostname: "runner", LogLevel: "info"}
+	assert.Error(t, cfg.Validate(), "the JIT config is required")
+
+	cfg.AwaitRunner = true
+	assert.NoError(t, cfg.Validate())
+}
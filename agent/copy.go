@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/hostinger/fireactions/helper/archive"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	copyChunkSize = 64 * 1024
+)
+
+// CopyFrom implements AgentService.CopyFrom.
+func (a *Agent) CopyFrom(req *agentv1.CopyFromRequest, stream agentv1.AgentService_CopyFromServer) error {
+	if req.GetPath() == "" {
+		return status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	if _, err := os.Lstat(req.GetPath()); errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.NotFound, "%s does not exist", req.GetPath())
+	} else if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	a.logger.Info().Str("path", req.GetPath()).Msg("Copying from VM")
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(archive.Tar(pw, req.GetPath()))
+	}()
+	defer pr.Close()
+
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := pr.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&agentv1.CopyFromResponse{Data: append([]byte(nil), buf[:n]...)}); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "archive %s: %v", req.GetPath(), err)
+		}
+	}
+}
+
+// CopyTo implements AgentService.CopyTo. Extracted files are owned by the
+// agent user (root).
+func (a *Agent) CopyTo(stream agentv1.AgentService_CopyToServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	path := req.GetPath()
+	if path == "" {
+		return status.Error(codes.InvalidArgument, "first request must set the path")
+	}
+
+	a.logger.Info().Str("path", path).Msg("Copying to VM")
+
+	pr, pw := io.Pipe()
+	extracted := make(chan error, 1)
+	go func() {
+		err := archive.Untar(pr, path)
+		pr.CloseWithError(err)
+		extracted <- err
+	}()
+
+	for {
+		if _, err := pw.Write(req.GetData()); err != nil {
+			break
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			pw.CloseWithError(err)
+			<-extracted
+			return err
+		}
+	}
+
+	_ = pw.Close()
+	if err := <-extracted; err != nil {
+		return status.Errorf(codes.FailedPrecondition, "extract to %s: %v", path, err)
+	}
+
+	return stream.SendAndClose(&agentv1.CopyToResponse{})
+}
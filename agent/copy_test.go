@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hostinger/fireactions/helper/archive"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCopyFromTo(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	src := filepath.Join(t.TempDir(), "_diag")
+	require.NoError(t, os.MkdirAll(src, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "Runner_1.log"), bytes.Repeat([]byte("x"), 3*copyChunkSize), 0644))
+
+	stream, err := client.CopyFrom(context.Background(), &agentv1.CopyFromRequest{Path: src})
+	require.NoError(t, err)
+
+	var archived bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		archived.Write(resp.GetData())
+	}
+
+	dst := filepath.Join(t.TempDir(), "diag")
+	upload, err := client.CopyTo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, upload.Send(&agentv1.CopyToRequest{Path: dst}))
+	for data := archived.Bytes(); len(data) > 0; {
+		n := min(len(data), copyChunkSize)
+		require.NoError(t, upload.Send(&agentv1.CopyToRequest{Data: data[:n]}))
+		data = data[n:]
+	}
+	_, err = upload.CloseAndRecv()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dst, "Runner_1.log"))
+	require.NoError(t, err)
+	assert.Len(t, data, 3*copyChunkSize)
+}
+
+func TestCopyFrom_NotFound(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stream, err := client.CopyFrom(context.Background(), &agentv1.CopyFromRequest{Path: "/nonexistent"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCopyTo_MissingParent(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	src := filepath.Join(t.TempDir(), "script.sh")
+	require.NoError(t, os.WriteFile(src, []byte("#!/bin/sh"), 0755))
+
+	var archived bytes.Buffer
+	require.NoError(t, archive.Tar(&archived, src))
+
+	upload, err := client.CopyTo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, upload.Send(&agentv1.CopyToRequest{Path: filepath.Join(t.TempDir(), "missing", "script.sh"), Data: archived.Bytes()}))
+
+	_, err = upload.CloseAndRecv()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
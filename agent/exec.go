@@ -0,0 +1,363 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// execWaitDelay bounds how long Exec waits for the output of processes
+	// that outlive the command, e.g. daemons it started.
+	execWaitDelay = 5 * time.Second
+
+	passwdFile   = "/etc/passwd"
+	defaultShell = "/bin/sh"
+)
+
+// execSession is a command started by Exec.
+type execSession struct {
+	cmd *exec.Cmd
+	pty *os.File // Master of the pseudo-terminal, nil if the command has no tty
+}
+
+// Exec implements AgentService.Exec. Commands run as the agent user (root)
+// unless a user is requested.
+func (a *Agent) Exec(stream agentv1.AgentService_ExecServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := req.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "first request must start a command")
+	}
+
+	cmd, err := newExecCommand(ctx, start)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	sender := &execSender{stream: stream}
+	stdout := sender.writer(func(p []byte) *agentv1.ExecResponse {
+		return &agentv1.ExecResponse{Response: &agentv1.ExecResponse_Stdout{Stdout: p}}
+	})
+	stderr := sender.writer(func(p []byte) *agentv1.ExecResponse {
+		return &agentv1.ExecResponse{Response: &agentv1.ExecResponse_Stderr{Stderr: p}}
+	})
+
+	session := &execSession{cmd: cmd}
+	var stdin io.WriteCloser
+	var slave *os.File
+	if start.GetTty() {
+		session.pty, slave, err = openPTY()
+		if err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+		defer session.pty.Close()
+
+		size := start.GetTerminalSize()
+		if err := setPTYSize(session.pty, size.GetRows(), size.GetCols()); err != nil {
+			a.logger.Debug().Err(err).Msg("Failed to set terminal size")
+		}
+
+		if cred := cmd.SysProcAttr.Credential; cred != nil {
+			if err := slave.Chown(int(cred.Uid), -1); err != nil {
+				a.logger.Debug().Err(err).Msg("Failed to chown terminal")
+			}
+		}
+
+		cmd.Stdin = slave
+		cmd.Stdout = slave
+		cmd.Stderr = slave
+		cmd.SysProcAttr.Setsid = true
+		cmd.SysProcAttr.Setctty = true
+	} else {
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		cmd.SysProcAttr.Setpgid = true
+
+		stdin, err = cmd.StdinPipe()
+		if err != nil {
+			return fmt.Errorf("create stdin pipe: %w", err)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		if slave != nil {
+			_ = slave.Close()
+		}
+		return status.Errorf(codes.FailedPrecondition, "start command: %v", err)
+	}
+
+	a.logger.Info().Strs("command", cmd.Args).Str("user", start.GetUser()).Bool("tty", start.GetTty()).Msg("Executing command")
+
+	copied := make(chan struct{})
+	if session.pty != nil {
+		_ = slave.Close()
+
+		go func() {
+			defer close(copied)
+			_, _ = io.Copy(stdout, session.pty)
+		}()
+	} else {
+		close(copied)
+	}
+
+	go a.forwardExecInput(stream, session, stdin)
+
+	exitCode := execExitCode(cmd.Wait())
+	select {
+	case <-copied:
+	case <-time.After(execWaitDelay):
+	}
+
+	a.logger.Info().Strs("command", cmd.Args).Int("exit_code", exitCode).Msg("Command exited")
+
+	return sender.send(&agentv1.ExecResponse{Response: &agentv1.ExecResponse_ExitCode{ExitCode: int32(exitCode)}})
+}
+
+// forwardExecInput forwards the stdin, terminal resizes and signals received
+// on the stream to the command until the stream is closed. stdin is nil if
+// the command has a tty.
+func (a *Agent) forwardExecInput(stream agentv1.AgentService_ExecServer, session *execSession, stdin io.WriteCloser) {
+	input := stdin
+	if session.pty != nil {
+		input = session.pty
+	} else {
+		defer stdin.Close()
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return
+		}
+
+		switch r := req.Request.(type) {
+		case *agentv1.ExecRequest_Stdin:
+			if _, err := input.Write(r.Stdin); err != nil {
+				a.logger.Debug().Err(err).Msg("Failed to write command stdin")
+			}
+		case *agentv1.ExecRequest_CloseStdin:
+			if session.pty != nil {
+				// A tty has no end of input, send an end-of-transmission instead.
+				_, _ = session.pty.Write([]byte{0x04})
+				continue
+			}
+
+			_ = stdin.Close()
+		case *agentv1.ExecRequest_Resize:
+			if session.pty == nil {
+				continue
+			}
+
+			if err := setPTYSize(session.pty, r.Resize.GetRows(), r.Resize.GetCols()); err != nil {
+				a.logger.Debug().Err(err).Msg("Failed to resize terminal")
+			}
+		case *agentv1.ExecRequest_Signal:
+			if err := session.signal(r.Signal); err != nil {
+				a.logger.Warn().Err(err).Msgf("Failed to send signal %s to command", r.Signal)
+			}
+		}
+	}
+}
+
+// signal sends the signal to the foreground process group of the tty, or to
+// the process group of the command if it has no tty.
+func (s *execSession) signal(name string) error {
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	sig := unix.SignalNum(name)
+	if sig == 0 {
+		return fmt.Errorf("unknown signal %s", name)
+	}
+
+	pgid := s.cmd.Process.Pid
+	if s.pty != nil {
+		if fg, err := ptyForegroundGroup(s.pty); err == nil {
+			pgid = fg
+		}
+	}
+
+	return unix.Kill(-pgid, sig)
+}
+
+// newExecCommand creates the command of an Exec. Commands of another user run
+// with the credentials, home directory and login shell of the user.
+func newExecCommand(ctx context.Context, start *agentv1.ExecStart) (*exec.Cmd, error) {
+	u, err := user.Current()
+	if start.GetUser() != "" {
+		u, err = user.Lookup(start.GetUser())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup user: %w", err)
+	}
+
+	shell := loginShell(u.Username)
+	command := start.GetCommand()
+	if len(command) == 0 {
+		command = []string{shell, "-l"}
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = os.Environ()
+	cmd.Dir = start.GetWorkingDir()
+	cmd.WaitDelay = execWaitDelay
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+
+	if start.GetUser() != "" {
+		cmd.Env = append(cmd.Env,
+			fmt.Sprintf("HOME=%s", u.HomeDir),
+			fmt.Sprintf("USER=%s", u.Username),
+			fmt.Sprintf("LOGNAME=%s", u.Username),
+			fmt.Sprintf("SHELL=%s", shell),
+		)
+
+		if cmd.Dir == "" {
+			cmd.Dir = u.HomeDir
+		}
+
+		if u.Uid != strconv.Itoa(os.Getuid()) {
+			cmd.SysProcAttr.Credential, err = userCredential(u)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cmd.Env = append(cmd.Env, start.GetEnv()...)
+	return cmd, nil
+}
+
+// userCredential returns the credential of the user, including its
+// supplementary groups.
+func userCredential(u *user.User) (*syscall.Credential, error) {
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse uid: %w", err)
+	}
+
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse gid: %w", err)
+	}
+
+	cred := &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return nil, fmt.Errorf("lookup groups: %w", err)
+	}
+
+	for _, groupID := range groupIDs {
+		id, err := strconv.ParseUint(groupID, 10, 32)
+		if err != nil {
+			continue
+		}
+
+		cred.Groups = append(cred.Groups, uint32(id))
+	}
+
+	return cred, nil
+}
+
+// loginShell returns the login shell of the user from /etc/passwd, or
+// /bin/sh if it has none.
+func loginShell(username string) string {
+	f, err := os.Open(passwdFile)
+	if err != nil {
+		return defaultShell
+	}
+	defer f.Close()
+
+	return parseLoginShell(f, username)
+}
+
+func parseLoginShell(passwd io.Reader, username string) string {
+	scanner := bufio.NewScanner(passwd)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) != 7 || fields[0] != username {
+			continue
+		}
+
+		if fields[6] == "" {
+			return defaultShell
+		}
+
+		return fields[6]
+	}
+
+	return defaultShell
+}
+
+// execExitCode returns the exit code of a command. Commands killed by a
+// signal exit with 128 + the signal number, like in a shell.
+func execExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return -1
+	}
+
+	if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+
+	return exitErr.ExitCode()
+}
+
+// execSender serializes the responses of an Exec, as stdout and stderr are
+// written concurrently.
+type execSender struct {
+	mu     sync.Mutex
+	stream agentv1.AgentService_ExecServer
+}
+
+func (s *execSender) send(resp *agentv1.ExecResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stream.Send(resp)
+}
+
+func (s *execSender) writer(response func(p []byte) *agentv1.ExecResponse) io.Writer {
+	return execWriter(func(p []byte) (int, error) {
+		if err := s.send(response(append([]byte(nil), p...))); err != nil {
+			return 0, err
+		}
+
+		return len(p), nil
+	})
+}
+
+// execWriter is an io.Writer calling a function.
+type execWriter func(p []byte) (int, error)
+
+func (w execWriter) Write(p []byte) (int, error) {
+	return w(p)
+}
@@ -0,0 +1,195 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"strings"
+	"testing"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestAgentClient(t *testing.T, a *Agent) agentv1.AgentServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	agentv1.RegisterAgentServiceServer(grpcServer, a)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:bufconn",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return agentv1.NewAgentServiceClient(conn)
+}
+
+// execCommand runs a command through Exec and returns its stdout, stderr and
+// exit code.
+func execCommand(t *testing.T, client agentv1.AgentServiceClient, stdin string, command ...string) (string, string, int32) {
+	t.Helper()
+
+	return execStart(t, client, stdin, &agentv1.ExecStart{Command: command, Env: []string{"GREETING=hello"}})
+}
+
+func execStart(t *testing.T, client agentv1.AgentServiceClient, stdin string, start *agentv1.ExecStart) (string, string, int32) {
+	t.Helper()
+
+	stream, err := client.Exec(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Start{Start: start}}))
+	if stdin != "" {
+		require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Stdin{Stdin: []byte(stdin)}}))
+	}
+	if !start.Tty {
+		require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_CloseStdin{CloseStdin: true}}))
+	}
+
+	var stdout, stderr string
+	for {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+
+		switch r := resp.Response.(type) {
+		case *agentv1.ExecResponse_Stdout:
+			stdout += string(r.Stdout)
+		case *agentv1.ExecResponse_Stderr:
+			stderr += string(r.Stderr)
+		case *agentv1.ExecResponse_ExitCode:
+			_, err := stream.Recv()
+			assert.Equal(t, io.EOF, err)
+			return stdout, stderr, r.ExitCode
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stdout, stderr, code := execCommand(t, client, "world\n", "sh", "-c", `read name; echo "$GREETING $name"; echo oops >&2; exit 3`)
+	assert.Equal(t, "hello world\n", stdout)
+	assert.Equal(t, "oops\n", stderr)
+	assert.Equal(t, int32(3), code)
+
+	_, _, code = execCommand(t, client, "", "sh", "-c", "kill -TERM $$")
+	assert.Equal(t, int32(143), code)
+}
+
+func TestExec_InvalidStart(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stream, err := client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Stdin{Stdin: []byte("ls")}}))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err = client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Start{Start: &agentv1.ExecStart{
+		Command: []string{"/nonexistent"},
+	}}}))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestExec_TTY(t *testing.T) {
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("pseudo-terminals not available")
+	}
+
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stdout, stderr, code := execStart(t, client, "", &agentv1.ExecStart{
+		Command:      []string{"sh", "-c", "test -t 0 && stty size && echo oops >&2"},
+		Tty:          true,
+		TerminalSize: &agentv1.TerminalSize{Rows: 24, Cols: 80},
+	})
+	assert.Equal(t, "24 80\r\noops\r\n", stdout, "stderr is merged into stdout")
+	assert.Empty(t, stderr)
+	assert.Equal(t, int32(0), code)
+}
+
+func TestExec_Signal(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stream, err := client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Start{Start: &agentv1.ExecStart{
+		Command: []string{"sh", "-c", "echo ready; sleep 30"},
+	}}}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "ready\n", string(resp.GetStdout()))
+
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Signal{Signal: "INT"}}))
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int32(130), resp.GetExitCode())
+}
+
+func TestExec_User(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("requires root")
+	}
+
+	if _, err := user.Lookup("nobody"); err != nil {
+		t.Skip("user nobody does not exist")
+	}
+
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	stdout, _, code := execStart(t, client, "", &agentv1.ExecStart{
+		Command:    []string{"sh", "-c", "id -un; echo $USER"},
+		User:       "nobody",
+		WorkingDir: "/",
+	})
+	assert.Equal(t, "nobody\nnobody\n", stdout)
+	assert.Equal(t, int32(0), code)
+
+	stream, err := client.Exec(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Start{Start: &agentv1.ExecStart{
+		Command: []string{"id"},
+		User:    "nonexistent-user",
+	}}}))
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestParseLoginShell(t *testing.T) {
+	passwd := "root:x:0:0:root:/root:/bin/bash\nrunner:x:1001:1001::/home/runner:\n"
+
+	assert.Equal(t, "/bin/bash", parseLoginShell(strings.NewReader(passwd), "root"))
+	assert.Equal(t, "/bin/sh", parseLoginShell(strings.NewReader(passwd), "runner"))
+	assert.Equal(t, "/bin/sh", parseLoginShell(strings.NewReader(passwd), "nobody"))
+}
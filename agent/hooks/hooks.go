@@ -0,0 +1,421 @@
+package hooks
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Name is the name of a hook.
+type Name string
+
+const (
+	PreStart     Name = "pre_start"     // Run by the agent before the runner starts
+	JobStarted   Name = "job_started"   // Run by the runner before a job (ACTIONS_RUNNER_HOOK_JOB_STARTED)
+	JobCompleted Name = "job_completed" // Run by the runner after a job (ACTIONS_RUNNER_HOOK_JOB_COMPLETED)
+	PostExit     Name = "post_exit"     // Run by the agent after the runner exits
+)
+
+const (
+	defaultDir      = "/run/fireactions/hooks"
+	defaultBakedDir = "/etc/fireactions/hooks"
+	defaultTimeout  = 10 * time.Minute
+
+	// defaultWaitDelay bounds how long Run waits for the output of processes
+	// that outlive the hook, e.g. daemons it started in the background.
+	defaultWaitDelay = 5 * time.Second
+
+	statusDirName = "status"
+)
+
+// Names are all hook names.
+var Names = []Name{PreStart, JobStarted, JobCompleted, PostExit}
+
+// runnerHookEnv maps job hooks to the runner environment variables that run them.
+var runnerHookEnv = map[Name]string{
+	JobStarted:   "ACTIONS_RUNNER_HOOK_JOB_STARTED",
+	JobCompleted: "ACTIONS_RUNNER_HOOK_JOB_COMPLETED",
+}
+
+// Result is the result of a hook run.
+type Result struct {
+	Name       Name
+	ExitCode   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Err        string // Set if the hook could not be run or did not finish
+}
+
+// Manager installs and runs hooks. Hooks are scripts delivered via MMDS or
+// baked into the image under /etc/fireactions/hooks/<name>. Scripts delivered
+// via MMDS take precedence.
+type Manager struct {
+	dir       string
+	bakedDir  string
+	scripts   map[Name]string
+	paths     map[Name]string
+	timeout   time.Duration
+	waitDelay time.Duration
+	logger    *zerolog.Logger
+
+	mu      sync.RWMutex
+	results []Result
+}
+
+// Opt is a functional option for Manager.
+type Opt func(m *Manager)
+
+// WithDir sets the directory the hooks are installed to.
+func WithDir(dir string) Opt {
+	f := func(m *Manager) {
+		m.dir = dir
+	}
+
+	return f
+}
+
+// WithBakedDir sets the directory of the hooks baked into the image.
+func WithBakedDir(dir string) Opt {
+	f := func(m *Manager) {
+		m.bakedDir = dir
+	}
+
+	return f
+}
+
+// WithLogger sets the logger.
+func WithLogger(logger *zerolog.Logger) Opt {
+	f := func(m *Manager) {
+		m.logger = logger
+	}
+
+	return f
+}
+
+// New creates a new Manager with the hook scripts delivered via MMDS.
+func New(scripts map[Name]string, opts ...Opt) *Manager {
+	logger := zerolog.Nop()
+	m := &Manager{
+		dir:       defaultDir,
+		bakedDir:  defaultBakedDir,
+		scripts:   scripts,
+		paths:     make(map[Name]string),
+		timeout:   defaultTimeout,
+		waitDelay: defaultWaitDelay,
+		logger:    &logger,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Install writes the hook scripts and the wrappers of the job hooks. The
+// status directory of the job hooks is owned by uid and gid, as the runner
+// runs them as its own user.
+func (m *Manager) Install(uid, gid int) error {
+	for _, name := range Names {
+		path, err := m.install(name)
+		if err != nil {
+			return fmt.Errorf("install hook %s: %w", name, err)
+		}
+
+		if path != "" {
+			m.paths[name] = path
+		}
+	}
+
+	if m.paths[JobStarted] == "" && m.paths[JobCompleted] == "" {
+		return nil
+	}
+
+	statusDir := filepath.Join(m.dir, statusDirName)
+	if err := os.MkdirAll(statusDir, 0755); err != nil {
+		return fmt.Errorf("create hook status directory: %w", err)
+	}
+
+	if err := os.Chown(statusDir, uid, gid); err != nil {
+		return fmt.Errorf("chown hook status directory: %w", err)
+	}
+
+	for name := range runnerHookEnv {
+		if m.paths[name] == "" {
+			continue
+		}
+
+		if err := os.WriteFile(m.wrapperPath(name), []byte(m.wrapper(name)), 0755); err != nil {
+			return fmt.Errorf("write wrapper of hook %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// install returns the path of the hook script, writing it if it was
+// delivered via MMDS. It returns an empty path if the hook is not configured.
+func (m *Manager) install(name Name) (string, error) {
+	if script, ok := m.scripts[name]; ok && script != "" {
+		if err := os.MkdirAll(m.dir, 0755); err != nil {
+			return "", err
+		}
+
+		if !strings.HasPrefix(script, "#!") {
+			script = "#!/bin/sh\n" + script
+		}
+
+		path := filepath.Join(m.dir, string(name))
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			return "", err
+		}
+
+		return path, nil
+	}
+
+	path := filepath.Join(m.bakedDir, string(name))
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		m.logger.Warn().Str("hook", string(name)).Msgf("Ignoring hook %s, not an executable file", path)
+		return "", nil
+	}
+
+	return path, nil
+}
+
+// Env returns the runner environment variables that run the job hooks.
+func (m *Manager) Env() []string {
+	env := make([]string, 0, len(runnerHookEnv))
+	for _, name := range Names {
+		key, ok := runnerHookEnv[name]
+		if !ok || m.paths[name] == "" {
+			continue
+		}
+
+		env = append(env, fmt.Sprintf("%s=%s", key, m.wrapperPath(name)))
+	}
+
+	return env
+}
+
+// Run runs the hook as the agent user and records its result. Output is
+// written to the agent log line by line. Run returns nil if the hook is not
+// configured.
+func (m *Manager) Run(ctx context.Context, name Name, env ...string) error {
+	path := m.paths[name]
+	if path == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	m.logger.Info().Str("hook", string(name)).Msg("Running hook")
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Env = append(os.Environ(), env...)
+	// Background processes started by the hook inherit its output and would
+	// keep Run waiting forever, even after the timeout killed the hook
+	cmd.WaitDelay = m.waitDelay
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.logOutput(name, pr)
+	}()
+
+	result := Result{Name: name, StartedAt: time.Now()}
+	err := cmd.Run()
+	_ = pw.Close()
+	<-done
+	result.FinishedAt = time.Now()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil, errors.Is(err, exec.ErrWaitDelay) && ctx.Err() == nil:
+		// The hook succeeded, but left processes running in the background
+	case errors.As(err, &exitErr) && ctx.Err() == nil:
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+		result.Err = err.Error()
+		if ctx.Err() != nil {
+			result.Err = fmt.Sprintf("hook did not finish within %s", m.timeout)
+		}
+	}
+
+	m.record(result)
+
+	if result.ExitCode != 0 {
+		return fmt.Errorf("hook %s failed with exit code %d", name, result.ExitCode)
+	}
+
+	return nil
+}
+
+// Watch collects the results of the job hooks run by the runner until the
+// context is canceled. The output of the hooks is written to the agent log.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	if m.paths[JobStarted] == "" && m.paths[JobCompleted] == "" {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.Collect()
+			return
+		case <-ticker.C:
+			m.Collect()
+		}
+	}
+}
+
+// Collect collects the results of the job hooks that finished since the last
+// call. Status files are removed once collected, so hooks of later jobs are
+// collected again.
+func (m *Manager) Collect() {
+	for _, name := range Names {
+		if _, ok := runnerHookEnv[name]; !ok {
+			continue
+		}
+
+		exitPath := m.statusPath(name, "exit")
+		data, err := os.ReadFile(exitPath)
+		if err != nil {
+			continue
+		}
+
+		result := Result{Name: name}
+		result.ExitCode, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			result.ExitCode = -1
+			result.Err = fmt.Sprintf("invalid exit code %q", strings.TrimSpace(string(data)))
+		}
+
+		if info, err := os.Stat(exitPath); err == nil {
+			result.FinishedAt = info.ModTime()
+		}
+
+		if data, err := os.ReadFile(m.statusPath(name, "start")); err == nil {
+			if sec, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+				result.StartedAt = time.Unix(sec, 0)
+			}
+		}
+
+		if log, err := os.Open(m.statusPath(name, "log")); err == nil {
+			m.logOutput(name, log)
+			_ = log.Close()
+		}
+
+		for _, suffix := range []string{"exit", "start", "log"} {
+			_ = os.Remove(m.statusPath(name, suffix))
+		}
+
+		m.record(result)
+	}
+}
+
+// Results returns the results of all hook runs.
+func (m *Manager) Results() []Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]Result(nil), m.results...)
+}
+
+func (m *Manager) record(result Result) {
+	m.mu.Lock()
+	m.results = append(m.results, result)
+	m.mu.Unlock()
+
+	event := m.logger.Info()
+	if result.ExitCode != 0 {
+		event = m.logger.Warn()
+	}
+
+	if result.Err != "" {
+		event = event.Str("error", result.Err)
+	}
+
+	event.Str("hook", string(result.Name)).
+		Int("exit_code", result.ExitCode).
+		Dur("duration", result.FinishedAt.Sub(result.StartedAt)).
+		Msg("Hook finished")
+}
+
+func (m *Manager) logOutput(name Name, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m.logger.Info().Str("hook", string(name)).Msg(scanner.Text())
+	}
+}
+
+// JobEnvPath returns the path of the file the job started wrapper records the
+// GITHUB_REPOSITORY, GITHUB_WORKFLOW, GITHUB_RUN_ID and GITHUB_RUN_ATTEMPT
+// variables of every job to, as KEY=value lines. It returns an empty path if
+// no job started hook is configured, as the wrapper is only installed then.
+func (m *Manager) JobEnvPath() string {
+	if m.paths[JobStarted] == "" {
+		return ""
+	}
+
+	return m.statusPath(JobStarted, "env")
+}
+
+func (m *Manager) wrapperPath(name Name) string {
+	return filepath.Join(m.dir, string(name)+".sh")
+}
+
+func (m *Manager) statusPath(name Name, suffix string) string {
+	return filepath.Join(m.dir, statusDirName, fmt.Sprintf("%s.%s", name, suffix))
+}
+
+// wrapper returns the script the runner runs for a job hook. It runs the hook,
+// keeps its output in the job log and records the output and exit code for
+// the agent. The job started wrapper records the job environment first.
+func (m *Manager) wrapper(name Name) string {
+	var script strings.Builder
+	script.WriteString("#!/bin/bash\n# Generated by the fireactions agent.\n")
+
+	if name == JobStarted {
+		fmt.Fprintf(&script, `printf 'GITHUB_REPOSITORY=%%s\nGITHUB_WORKFLOW=%%s\nGITHUB_RUN_ID=%%s\nGITHUB_RUN_ATTEMPT=%%s\n' \
+  "$GITHUB_REPOSITORY" "${GITHUB_WORKFLOW//$'\n'/ }" "$GITHUB_RUN_ID" "$GITHUB_RUN_ATTEMPT" > %[1]q
+mv %[1]q %[2]q
+`, m.statusPath(name, "env.tmp"), m.JobEnvPath())
+	}
+
+	fmt.Fprintf(&script, `date +%%s > %[2]q
+%[1]q 2>&1 | tee %[3]q
+code=${PIPESTATUS[0]}
+echo "$code" > %[4]q
+mv %[4]q %[5]q
+exit "$code"
+`, m.paths[name], m.statusPath(name, "start"), m.statusPath(name, "log"), m.statusPath(name, "exit.tmp"), m.statusPath(name, "exit"))
+
+	return script.String()
+}
@@ -0,0 +1,135 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(t *testing.T, scripts map[Name]string) *Manager {
+	t.Helper()
+
+	dir := t.TempDir()
+	m := New(scripts, WithDir(filepath.Join(dir, "hooks")), WithBakedDir(filepath.Join(dir, "baked")))
+	require.NoError(t, os.MkdirAll(m.bakedDir, 0755))
+
+	return m
+}
+
+func TestManager_Install(t *testing.T) {
+	m := newTestManager(t, map[Name]string{PreStart: "echo pre-start"})
+	require.NoError(t, os.WriteFile(filepath.Join(m.bakedDir, string(PostExit)), []byte("#!/bin/sh\necho baked"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(m.bakedDir, string(JobStarted)), []byte("not executable"), 0644))
+
+	require.NoError(t, m.Install(os.Getuid(), os.Getgid()))
+
+	assert.Equal(t, filepath.Join(m.dir, string(PreStart)), m.paths[PreStart])
+	assert.Equal(t, filepath.Join(m.bakedDir, string(PostExit)), m.paths[PostExit])
+	assert.Empty(t, m.paths[JobStarted])
+	assert.Empty(t, m.Env())
+	assert.Empty(t, m.JobEnvPath(), "the job environment is only recorded by a job started hook")
+
+	script, err := os.ReadFile(m.paths[PreStart])
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho pre-start", string(script))
+}
+
+func TestManager_Run(t *testing.T) {
+	m := newTestManager(t, map[Name]string{PreStart: "echo $GREETING", PostExit: "exit 3"})
+	require.NoError(t, m.Install(os.Getuid(), os.Getgid()))
+
+	require.NoError(t, m.Run(context.Background(), PreStart, "GREETING=hello"))
+	assert.Error(t, m.Run(context.Background(), PostExit))
+	assert.NoError(t, m.Run(context.Background(), JobStarted), "hooks that are not configured are skipped")
+
+	results := m.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, PreStart, results[0].Name)
+	assert.Equal(t, 0, results[0].ExitCode)
+	assert.Equal(t, PostExit, results[1].Name)
+	assert.Equal(t, 3, results[1].ExitCode)
+}
+
+func TestManager_Run_BackgroundProcess(t *testing.T) {
+	m := newTestManager(t, map[Name]string{
+		PreStart: "sleep 30 &\necho started",
+		PostExit: "sleep 30 &\nsleep 30",
+	})
+	m.timeout = 500 * time.Millisecond
+	m.waitDelay = 100 * time.Millisecond
+	require.NoError(t, m.Install(os.Getuid(), os.Getgid()))
+
+	start := time.Now()
+	assert.NoError(t, m.Run(context.Background(), PreStart), "the hook succeeded, the daemon it started is left running")
+	assert.Error(t, m.Run(context.Background(), PostExit))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	results := m.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, 0, results[0].ExitCode)
+	assert.Equal(t, -1, results[1].ExitCode)
+	assert.Contains(t, results[1].Err, "did not finish within")
+}
+
+func TestManager_JobHooks(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+
+	m := newTestManager(t, map[Name]string{JobStarted: "echo started", JobCompleted: "echo failed; exit 1"})
+	require.NoError(t, m.Install(os.Getuid(), os.Getgid()))
+
+	assert.Equal(t, []string{
+		"ACTIONS_RUNNER_HOOK_JOB_STARTED=" + m.wrapperPath(JobStarted),
+		"ACTIONS_RUNNER_HOOK_JOB_COMPLETED=" + m.wrapperPath(JobCompleted),
+	}, m.Env())
+
+	output, err := exec.Command("bash", m.wrapperPath(JobStarted)).CombinedOutput()
+	require.NoError(t, err)
+	assert.Equal(t, "started\n", string(output))
+
+	err = exec.Command("bash", m.wrapperPath(JobCompleted)).Run()
+	assert.Error(t, err)
+
+	m.Collect()
+	results := m.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, JobStarted, results[0].Name)
+	assert.Equal(t, 0, results[0].ExitCode)
+	assert.False(t, results[0].StartedAt.IsZero())
+	assert.Equal(t, JobCompleted, results[1].Name)
+	assert.Equal(t, 1, results[1].ExitCode)
+
+	m.Collect()
+	assert.Len(t, m.Results(), 2, "results are collected once")
+}
+
+func TestManager_JobEnv(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+
+	m := newTestManager(t, map[Name]string{JobStarted: "echo started"})
+	require.NoError(t, m.Install(os.Getuid(), os.Getgid()))
+
+	cmd := exec.Command("bash", m.wrapperPath(JobStarted))
+	cmd.Env = append(os.Environ(),
+		"GITHUB_REPOSITORY=example/app",
+		"GITHUB_WORKFLOW=CI\nnightly",
+		"GITHUB_RUN_ID=42",
+		"GITHUB_RUN_ATTEMPT=2",
+	)
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "started\n", string(output))
+
+	env, err := os.ReadFile(m.JobEnvPath())
+	require.NoError(t, err)
+	assert.Equal(t, "GITHUB_REPOSITORY=example/app\nGITHUB_WORKFLOW=CI nightly\nGITHUB_RUN_ID=42\nGITHUB_RUN_ATTEMPT=2\n", string(env))
+}
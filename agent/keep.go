@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+)
+
+// failedJob returns the last job of the runner if it failed and failed VMs
+// are kept for debugging.
+func (a *Agent) failedJob() (runner.Job, bool) {
+	if !a.cfg.KeepOnFailure {
+		return runner.Job{}, false
+	}
+
+	job, ok := a.runner.Job()
+	if !ok || job.Result != runner.JobFailed {
+		return runner.Job{}, false
+	}
+
+	return job, true
+}
+
+// hasFailedJob reports whether the last job of the runner failed and failed
+// VMs are kept for debugging.
+func (a *Agent) hasFailedJob() bool {
+	_, ok := a.failedJob()
+	return ok
+}
+
+// keepFailedVM reports the failed job of the runner to the server and
+// reports whether the server holds the VM, so it must be kept running. The
+// VM is powered off as usual if the job did not fail or the server does not
+// hold it.
+func (a *Agent) keepFailedVM(ctx context.Context) bool {
+	job, ok := a.failedJob()
+	if !ok {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	resp, err := a.host.ReportFailedJob(ctx, &agentv1.ReportFailedJobRequest{Job: convertJobToProto(job)})
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Failed to report failed job, not keeping VM")
+		return false
+	}
+
+	a.logger.Info().Msgf("Job %s failed, keeping VM for debugging until %s", job.Name, resp.GetHeldUntil().AsTime().Format(time.RFC3339))
+	return true
+}
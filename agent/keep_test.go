@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runTestJob runs the runner of the agent with a fake run.sh that runs a
+// single job with the given result.
+func runTestJob(t *testing.T, a *Agent, result string) {
+	t.Helper()
+
+	if os.Geteuid() != 0 {
+		t.Skip("running the runner as another user requires root")
+	}
+
+	current, err := user.Current()
+	require.NoError(t, err)
+	group, err := user.LookupGroupId(current.Gid)
+	require.NoError(t, err)
+
+	script := "#!/bin/sh\necho \"Running job: build\"\necho \"Job build completed with result: " + result + "\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(a.runnerDir, "run.sh"), []byte(script), 0755))
+
+	a.runner = runner.New("config", runner.WithDirectory(a.runnerDir), runner.WithOwner(current.Username), runner.WithGroup(group.Name))
+	require.NoError(t, a.runner.Run(context.Background()))
+}
+
+func TestKeepFailedVM(t *testing.T) {
+	tests := []struct {
+		name          string
+		keepOnFailure bool
+		result        string
+		failedErr     error
+		kept          bool
+	}{
+		{"failed", true, runner.JobFailed, nil, true},
+		{"succeeded", true, runner.JobSucceeded, nil, false},
+		{"disabled", false, runner.JobFailed, nil, false},
+		{"not held", true, runner.JobFailed, errors.New("keep_on_failure is disabled for the pool"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, host := newTestMultiJobAgent(t, nil)
+			a.cfg.KeepOnFailure = tt.keepOnFailure
+			host.failedErr = tt.failedErr
+			runTestJob(t, a, tt.result)
+
+			assert.Equal(t, tt.kept, a.keepFailedVM(context.Background()))
+			if tt.keepOnFailure && tt.result == runner.JobFailed {
+				require.NotNil(t, host.failedReq)
+				assert.Equal(t, "build", host.failedReq.GetJob().GetName())
+				assert.Equal(t, runner.JobFailed, host.failedReq.GetJob().GetResult())
+			} else {
+				assert.Nil(t, host.failedReq)
+			}
+		})
+	}
+}
+
+func TestNextJob_KeepOnFailure(t *testing.T) {
+	a, host := newTestMultiJobAgent(t, &MultiJobConfig{MaxJobs: 10})
+	a.cfg.KeepOnFailure = true
+	runTestJob(t, a, runner.JobFailed)
+
+	assert.False(t, a.nextJob(context.Background(), true), "no job runs after a failed one")
+	assert.Nil(t, host.renewReq)
+}
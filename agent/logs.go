@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	logSourceAgent  = "agent"
+	logSourceRunner = "runner"
+	logSourceWorker = "worker"
+)
+
+// logSource is a source of logs GetLogs can stream, either a single file or
+// the files matching a pattern, like the rotated runner diagnostic logs. The
+// rotated files of a single file are listed but not streamed.
+type logSource struct {
+	name        string
+	description string
+	file        string
+	backups     int // Rotated files of file, named <file>.1 (newest) up to <file>.<backups>
+	pattern     string
+}
+
+// logSources returns the log sources of the agent.
+func (a *Agent) logSources() []logSource {
+	diagDir := filepath.Join(a.runnerDir, "_diag")
+
+	return []logSource{
+		{name: logSourceAgent, description: "fireactions-agent logs", file: a.logFile, backups: logFileMaxBackups},
+		{name: logSourceRunner, description: "GitHub runner listener diagnostic logs", pattern: filepath.Join(diagDir, "Runner_*.log")},
+		{name: logSourceWorker, description: "GitHub runner job diagnostic logs, one file per job", pattern: filepath.Join(diagDir, "Worker_*.log")},
+	}
+}
+
+// findLogSource returns the log source with the given name, the agent logs
+// if the name is empty.
+func (a *Agent) findLogSource(name string) (logSource, bool) {
+	if name == "" {
+		name = logSourceAgent
+	}
+
+	for _, source := range a.logSources() {
+		if source.name == name {
+			return source, true
+		}
+	}
+
+	return logSource{}, false
+}
+
+// files returns the existing files of the log source, oldest first.
+func (s logSource) files() ([]string, error) {
+	if s.file != "" {
+		var files []string
+		for i := s.backups; i >= 0; i-- {
+			path := s.file
+			if i > 0 {
+				path = fmt.Sprintf("%s.%d", s.file, i)
+			}
+
+			if _, err := os.Stat(path); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+
+				return nil, err
+			}
+
+			files = append(files, path)
+		}
+
+		return files, nil
+	}
+
+	// Names are timestamped, so they sort by age
+	return filepath.Glob(s.pattern)
+}
+
+// ListLogSources implements AgentService.ListLogSources.
+func (a *Agent) ListLogSources(ctx context.Context, req *agentv1.ListLogSourcesRequest) (*agentv1.ListLogSourcesResponse, error) {
+	resp := &agentv1.ListLogSourcesResponse{}
+	for _, source := range a.logSources() {
+		files, err := source.files()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "list %s logs: %v", source.name, err)
+		}
+
+		protoSource := &agentv1.LogSource{Name: source.name, Description: source.description}
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err != nil {
+				// Rotated away since it was listed
+				continue
+			}
+
+			protoSource.Files = append(protoSource.Files, &agentv1.LogFile{
+				Path:       file,
+				Size:       info.Size(),
+				ModifiedAt: timestamppb.New(info.ModTime()),
+			})
+		}
+
+		resp.Sources = append(resp.Sources, protoSource)
+	}
+
+	return resp, nil
+}
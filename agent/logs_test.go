@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestLogsAgent(t *testing.T) *Agent {
+	t.Helper()
+
+	dir := t.TempDir()
+	diagDir := filepath.Join(dir, "runner", "_diag")
+	require.NoError(t, os.MkdirAll(diagDir, 0755))
+
+	files := map[string]string{
+		filepath.Join(dir, "agent.log.1"):                        "agent rotated\n",
+		filepath.Join(dir, "agent.log"):                          "agent\n",
+		filepath.Join(diagDir, "Runner_20240101-100000-utc.log"): "runner\n",
+		filepath.Join(diagDir, "Worker_20240101-100000-utc.log"): "job1 line1\njob1 line2\n",
+		filepath.Join(diagDir, "Worker_20240101-110000-utc.log"): "job2 line1\n",
+	}
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	logger := zerolog.Nop()
+	return &Agent{logger: &logger, logFile: filepath.Join(dir, "agent.log"), runnerDir: filepath.Join(dir, "runner")}
+}
+
+func readLogs(t *testing.T, client agentv1.AgentServiceClient, req *agentv1.GetLogsRequest) (string, error) {
+	t.Helper()
+
+	stream, err := client.GetLogs(context.Background(), req)
+	require.NoError(t, err)
+
+	var out string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+
+		out += resp.GetLine()
+	}
+}
+
+func TestGetLogs_Source(t *testing.T) {
+	client := newTestAgentClient(t, newTestLogsAgent(t))
+
+	out, err := readLogs(t, client, &agentv1.GetLogsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "agent\n", out, "rotated agent logs are only listed")
+
+	out, err = readLogs(t, client, &agentv1.GetLogsRequest{Source: "worker"})
+	require.NoError(t, err)
+	assert.Equal(t, "job1 line1\njob1 line2\njob2 line1\n", out)
+
+	out, err = readLogs(t, client, &agentv1.GetLogsRequest{Source: "worker", TailLines: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "job2 line1\n", out)
+
+	_, err = readLogs(t, client, &agentv1.GetLogsRequest{Source: "kernel"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListLogSources(t *testing.T) {
+	client := newTestAgentClient(t, newTestLogsAgent(t))
+
+	resp, err := client.ListLogSources(context.Background(), &agentv1.ListLogSourcesRequest{})
+	require.NoError(t, err)
+
+	sources := make(map[string][]string)
+	for _, source := range resp.GetSources() {
+		for _, file := range source.GetFiles() {
+			sources[source.GetName()] = append(sources[source.GetName()], filepath.Base(file.GetPath()))
+			assert.Positive(t, file.GetSize())
+		}
+	}
+
+	assert.Equal(t, map[string][]string{
+		"agent":  {"agent.log.1", "agent.log"},
+		"runner": {"Runner_20240101-100000-utc.log"},
+		"worker": {"Worker_20240101-100000-utc.log", "Worker_20240101-110000-utc.log"},
+	}, sources)
+}
@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	mmdsAddress = "169.254.169.254/32"
+)
+
+// mmdsRestrictRules are the iptables rules that block MMDS, with their table.
+// Connections from processes not running as root are rejected. Packets from
+// containers, e.g. of Docker, are forwarded rather than sent by a process of
+// the VM: they are dropped before routing, so that the FORWARD rules Docker
+// adds when it starts cannot accept them.
+var mmdsRestrictRules = []struct {
+	table string
+	rule  []string
+}{
+	{"filter", []string{"OUTPUT", "-d", mmdsAddress, "-m", "owner", "!", "--uid-owner", "0", "-j", "REJECT"}},
+	{"raw", []string{"PREROUTING", "-d", mmdsAddress, "-j", "DROP"}},
+}
+
+// restrictMMDS blocks access to MMDS for non-root users and containers, so
+// unprivileged workflow jobs cannot read the metadata of the VM. Jobs that can
+// become root, e.g. with sudo or through Docker, are not stopped by it. The
+// rules are only added once.
+func restrictMMDS() error {
+	for _, r := range mmdsRestrictRules {
+		check := exec.Command("iptables", append([]string{"-w", "-t", r.table, "-C"}, r.rule...)...)
+		if err := check.Run(); err == nil {
+			continue
+		}
+
+		insert := exec.Command("iptables", append([]string{"-w", "-t", r.table, "-I"}, r.rule...)...)
+		if output, err := insert.CombinedOutput(); err != nil {
+			return fmt.Errorf("iptables: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMMDSRestrictRules(t *testing.T) {
+	require.Len(t, mmdsRestrictRules, 2)
+
+	filter := mmdsRestrictRules[0]
+	assert.Equal(t, "filter", filter.table)
+	assert.Equal(t, "OUTPUT -d 169.254.169.254/32 -m owner ! --uid-owner 0 -j REJECT", strings.Join(filter.rule, " "),
+		"connections of processes not running as root are rejected")
+
+	raw := mmdsRestrictRules[1]
+	assert.Equal(t, "raw", raw.table)
+	assert.Equal(t, "PREROUTING -d 169.254.169.254/32 -j DROP", strings.Join(raw.rule, " "),
+		"packets forwarded from containers are dropped before Docker's FORWARD rules")
+}
+
+// fakeIptables puts an iptables on PATH that logs its arguments and reports
+// the rules in present as existing.
+func fakeIptables(t *testing.T, present ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "iptables.log")
+	script := "#!/bin/sh\necho \"$*\" >> " + log + "\n"
+	for _, rule := range present {
+		script += "[ \"$*\" = \"-w " + rule + "\" ] && exit 0\n"
+	}
+	script += "case \"$*\" in *\" -C \"*) exit 1 ;; esac\nexit 0\n"
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iptables"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return log
+}
+
+func TestRestrictMMDS(t *testing.T) {
+	log := fakeIptables(t, "-t filter -C OUTPUT -d 169.254.169.254/32 -m owner ! --uid-owner 0 -j REJECT")
+
+	require.NoError(t, restrictMMDS())
+
+	data, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"-w -t filter -C OUTPUT -d 169.254.169.254/32 -m owner ! --uid-owner 0 -j REJECT",
+		"-w -t raw -C PREROUTING -d 169.254.169.254/32 -j DROP",
+		"-w -t raw -I PREROUTING -d 169.254.169.254/32 -j DROP",
+	}, strings.Split(strings.TrimSpace(string(data)), "\n"), "only missing rules are inserted")
+}
+
+func TestRestrictMMDS_Error(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iptables"), []byte("#!/bin/sh\necho 'iptables: No chain/target/match by that name.' >&2\nexit 1\n"), 0755))
+	t.Setenv("PATH", dir)
+
+	err := restrictMMDS()
+	assert.ErrorContains(t, err, "No chain/target/match by that name.")
+}
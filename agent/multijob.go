@@ -0,0 +1,179 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+)
+
+const (
+	renewTimeout   = 30 * time.Second
+	cleanupTimeout = 5 * time.Minute
+)
+
+// defaultTempDirs are the temporary directories emptied between jobs.
+var defaultTempDirs = []string{"/tmp", "/var/tmp"}
+
+// runJobs runs the runner until it exits and, in multi-job mode, again for
+// every next job.
+func (a *Agent) runJobs(ctx context.Context) {
+	defer a.runner.Finish()
+
+	for {
+		jobs := a.runner.Jobs()
+		if err := a.runner.Run(ctx); err != nil {
+			a.logger.Error().Err(err).Msg("Runner encountered an error")
+			return
+		}
+
+		if !a.nextJob(ctx, a.runner.Jobs() > jobs) {
+			return
+		}
+	}
+}
+
+// nextJob prepares the runner for its next job in multi-job mode and reports
+// whether it must be run again. ranJob is whether the runner ran a job
+// before it exited.
+func (a *Agent) nextJob(ctx context.Context, ranJob bool) bool {
+	cfg := a.cfg.MultiJob
+	if cfg == nil {
+		return false
+	}
+
+	jobs := a.runner.Jobs()
+	switch {
+	case !ranJob:
+		a.logger.Info().Msg("Runner exited without running a job, not running it again")
+		return false
+	case a.stopping.Load() || ctx.Err() != nil:
+		return false
+	case a.hasFailedJob():
+		a.logger.Info().Msg("Job failed, not running another job so that the VM can be kept")
+		return false
+	case jobs >= cfg.MaxJobs:
+		a.logger.Info().Msgf("Runner ran %d jobs, the maximum", jobs)
+		return false
+	case cfg.MaxLifetime > 0 && time.Since(a.startedAt) >= cfg.MaxLifetime:
+		a.logger.Info().Msgf("Runner ran for %s, the maximum lifetime", cfg.MaxLifetime)
+		return false
+	}
+
+	a.runner.Reset()
+
+	cleanupCtx, cancel := context.WithTimeout(ctx, cleanupTimeout)
+	defer cancel()
+
+	if err := a.cleanupWorkspace(cleanupCtx); err != nil {
+		// Running another job in a dirty workspace would break isolation
+		a.logger.Error().Err(err).Msg("Failed to clean up workspace, not running another job")
+		return false
+	}
+
+	renewCtx, cancel := context.WithTimeout(ctx, renewTimeout)
+	defer cancel()
+
+	resp, err := a.host.RenewRunner(renewCtx, &agentv1.RenewRunnerRequest{Jobs: int32(jobs)})
+	if err != nil {
+		a.logger.Error().Err(err).Msg("Failed to renew runner, not running another job")
+		return false
+	}
+
+	a.logger.Info().Msgf("Runner renewed after %d jobs", jobs)
+	a.runner.Renew(resp.GetRunnerJitConfig())
+	return true
+}
+
+// cleanupWorkspace removes what the previous job left behind: the work
+// directory of the runner except the tool cache, temporary files, the
+// configured cleanup paths and all Docker containers, images, volumes and
+// networks.
+func (a *Agent) cleanupWorkspace(ctx context.Context) error {
+	if err := removeContents(filepath.Join(a.runnerDir, "_work"), "_tool"); err != nil {
+		return fmt.Errorf("clean work directory: %w", err)
+	}
+
+	for _, dir := range a.tempDirs {
+		if err := removeContents(dir); err != nil {
+			return fmt.Errorf("clean %s: %w", dir, err)
+		}
+	}
+
+	for _, pattern := range a.cfg.MultiJob.CleanupPaths {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("clean %s: %w", pattern, err)
+		}
+
+		for _, path := range paths {
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("clean %s: %w", path, err)
+			}
+		}
+	}
+
+	if err := cleanupDocker(ctx); err != nil {
+		return fmt.Errorf("clean docker: %w", err)
+	}
+
+	a.logger.Info().Msg("Workspace cleaned up")
+	return nil
+}
+
+// removeContents removes everything in dir but the entries named keep. A
+// missing dir is not an error.
+func removeContents(dir string, keep ...string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		if slices.Contains(keep, entry.Name()) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanupDocker removes all Docker containers, images, volumes and networks.
+// Nothing is done if Docker is not installed.
+func cleanupDocker(ctx context.Context) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil
+	}
+
+	output, err := exec.CommandContext(ctx, "docker", "ps", "--all", "--quiet").Output()
+	if err != nil {
+		return fmt.Errorf("docker ps: %w", err)
+	}
+
+	if ids := strings.Fields(string(output)); len(ids) > 0 {
+		args := append([]string{"rm", "--force", "--volumes"}, ids...)
+		if output, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("docker rm: %w: %s", err, output)
+		}
+	}
+
+	if output, err := exec.CommandContext(ctx, "docker", "system", "prune", "--all", "--force", "--volumes").CombinedOutput(); err != nil {
+		return fmt.Errorf("docker system prune: %w: %s", err, output)
+	}
+
+	return nil
+}
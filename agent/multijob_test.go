@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeHostClient struct {
+	agentv1.HostServiceClient
+
+	renewReq  *agentv1.RenewRunnerRequest
+	renewErr  error
+	failedReq *agentv1.ReportFailedJobRequest
+	failedErr error
+
+	configMu   sync.Mutex
+	configReqs []*agentv1.ReportConfigStatusRequest
+}
+
+func (c *fakeHostClient) Heartbeat(ctx context.Context, req *agentv1.HeartbeatRequest, _ ...grpc.CallOption) (*agentv1.HeartbeatResponse, error) {
+	return &agentv1.HeartbeatResponse{}, nil
+}
+
+func (c *fakeHostClient) ReportConfigStatus(ctx context.Context, req *agentv1.ReportConfigStatusRequest, _ ...grpc.CallOption) (*agentv1.ReportConfigStatusResponse, error) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+
+	c.configReqs = append(c.configReqs, req)
+	return &agentv1.ReportConfigStatusResponse{}, nil
+}
+
+// configStatus returns the last config status reported, nil if none.
+func (c *fakeHostClient) configStatus() *agentv1.ReportConfigStatusRequest {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+
+	if len(c.configReqs) == 0 {
+		return nil
+	}
+
+	return c.configReqs[len(c.configReqs)-1]
+}
+
+func (c *fakeHostClient) RenewRunner(ctx context.Context, req *agentv1.RenewRunnerRequest, _ ...grpc.CallOption) (*agentv1.RenewRunnerResponse, error) {
+	c.renewReq = req
+	if c.renewErr != nil {
+		return nil, c.renewErr
+	}
+
+	return &agentv1.RenewRunnerResponse{RunnerJitConfig: "config2"}, nil
+}
+
+func (c *fakeHostClient) ReportFailedJob(ctx context.Context, req *agentv1.ReportFailedJobRequest, _ ...grpc.CallOption) (*agentv1.ReportFailedJobResponse, error) {
+	c.failedReq = req
+	if c.failedErr != nil {
+		return nil, c.failedErr
+	}
+
+	return &agentv1.ReportFailedJobResponse{HeldUntil: timestamppb.New(time.Now().Add(time.Hour))}, nil
+}
+
+func newTestMultiJobAgent(t *testing.T, cfg *MultiJobConfig) (*Agent, *fakeHostClient) {
+	t.Helper()
+
+	// Never prune the Docker state of the host running the tests
+	t.Setenv("PATH", "")
+
+	logger := zerolog.Nop()
+	runnerDir := t.TempDir()
+	host := &fakeHostClient{}
+	a := &Agent{
+		cfg:       Config{MultiJob: cfg},
+		logger:    &logger,
+		runnerDir: runnerDir,
+		runner:    runner.New("config", runner.WithDirectory(runnerDir)),
+		host:      host,
+		tempDirs:  []string{t.TempDir()},
+		startedAt: time.Now(),
+	}
+
+	return a, host
+}
+
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("test"), 0644))
+}
+
+func TestNextJob(t *testing.T) {
+	home := t.TempDir()
+	a, host := newTestMultiJobAgent(t, &MultiJobConfig{MaxJobs: 10, CleanupPaths: []string{filepath.Join(home, ".docker")}})
+
+	workDir := filepath.Join(a.runnerDir, "_work")
+	writeTestFile(t, filepath.Join(workDir, "repo", "repo", "main.go"))
+	writeTestFile(t, filepath.Join(workDir, "_tool", "go", "bin", "go"))
+	writeTestFile(t, filepath.Join(a.tempDirs[0], "build", "output"))
+	writeTestFile(t, filepath.Join(home, ".docker", "config.json"))
+	writeTestFile(t, filepath.Join(home, ".bashrc"))
+
+	require.True(t, a.nextJob(context.Background(), true))
+	assert.Equal(t, runner.StateStarting, a.runner.GetState())
+	assert.NotNil(t, host.renewReq)
+
+	assert.NoDirExists(t, filepath.Join(workDir, "repo"))
+	assert.FileExists(t, filepath.Join(workDir, "_tool", "go", "bin", "go"), "the tool cache is kept")
+	assert.NoDirExists(t, filepath.Join(a.tempDirs[0], "build"))
+	assert.DirExists(t, a.tempDirs[0])
+	assert.NoDirExists(t, filepath.Join(home, ".docker"))
+	assert.FileExists(t, filepath.Join(home, ".bashrc"))
+
+	history, _, cancel := a.runner.Watch()
+	defer cancel()
+	require.Len(t, history, 2)
+	assert.Equal(t, runner.StateResetting, history[0].To)
+}
+
+func TestNextJob_Stop(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *MultiJobConfig
+		ranJob bool
+		setup  func(a *Agent, host *fakeHostClient)
+	}{
+		{"single job", nil, true, nil},
+		{"no job", &MultiJobConfig{MaxJobs: 10}, false, nil},
+		{"lifetime", &MultiJobConfig{MaxJobs: 10, MaxLifetime: time.Hour}, true, func(a *Agent, _ *fakeHostClient) {
+			a.startedAt = time.Now().Add(-2 * time.Hour)
+		}},
+		{"shutdown", &MultiJobConfig{MaxJobs: 10}, true, func(a *Agent, _ *fakeHostClient) {
+			a.stopping.Store(true)
+		}},
+		{"renew failed", &MultiJobConfig{MaxJobs: 10}, true, func(_ *Agent, host *fakeHostClient) {
+			host.renewErr = errors.New("github: 500")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, host := newTestMultiJobAgent(t, tt.cfg)
+			if tt.setup != nil {
+				tt.setup(a, host)
+			}
+
+			assert.False(t, a.nextJob(context.Background(), tt.ranJob))
+		})
+	}
+}
+
+func TestRemoveContents(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a", "file"))
+	writeTestFile(t, filepath.Join(dir, "b"))
+	writeTestFile(t, filepath.Join(dir, "keep", "file"))
+
+	require.NoError(t, removeContents(dir, "keep"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "keep", entries[0].Name())
+
+	assert.NoError(t, removeContents(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY opens a new pseudo-terminal and returns its master and slave.
+func openPTY() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open ptmx: %w", err)
+	}
+
+	var n uint32
+	err = ptyControl(master, func(fd int) error {
+		if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+			return fmt.Errorf("unlock pty: %w", err)
+		}
+
+		ptn, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+		if err != nil {
+			return fmt.Errorf("get pty number: %w", err)
+		}
+
+		n = ptn
+		return nil
+	})
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, err
+	}
+
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, fmt.Errorf("open pty slave: %w", err)
+	}
+
+	return master, slave, nil
+}
+
+// setPTYSize sets the size of the pseudo-terminal. Zero sizes are ignored.
+func setPTYSize(pty *os.File, rows, cols uint32) error {
+	if rows == 0 || cols == 0 {
+		return nil
+	}
+
+	return ptyControl(pty, func(fd int) error {
+		return unix.IoctlSetWinsize(fd, unix.TIOCSWINSZ, &unix.Winsize{Row: uint16(rows), Col: uint16(cols)})
+	})
+}
+
+// ptyForegroundGroup returns the foreground process group of the
+// pseudo-terminal, the group signals of the user are delivered to.
+func ptyForegroundGroup(pty *os.File) (int, error) {
+	var pgid int
+	err := ptyControl(pty, func(fd int) (err error) {
+		pgid, err = unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+		return err
+	})
+
+	return pgid, err
+}
+
+// ptyControl runs f on the file descriptor of the pseudo-terminal. Unlike
+// Fd, it keeps the file non-blocking, so closing it interrupts reads.
+func ptyControl(pty *os.File, f func(fd int) error) error {
+	conn, err := pty.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var ferr error
+	if err := conn.Control(func(fd uintptr) { ferr = f(int(fd)) }); err != nil {
+		return err
+	}
+
+	return ferr
+}
@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/mdlayher/vsock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// hostPort is the vsock port the server listens on for each VM.
+	hostPort = 9002
+
+	defaultHeartbeatInterval = 10 * time.Second
+	reportTimeout            = 5 * time.Second
+)
+
+// runReporter pushes heartbeats, runner state transitions and the config
+// status to the server until the context is canceled. Failed heartbeats and
+// transitions are not retried, the next heartbeat carries the current state
+// anyway. The config status is pushed again with every heartbeat until the
+// server received it.
+func (a *Agent) runReporter(ctx context.Context) {
+	client := a.host
+	interval := a.cfg.HeartbeatInterval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	a.sendHeartbeat(ctx, client)
+	configSent := a.sendConfigStatus(ctx, client)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case transition := <-a.transitions:
+			a.sendTransition(ctx, client, transition)
+		case <-a.configChanged:
+			configSent = a.sendConfigStatus(ctx, client)
+		case <-ticker.C:
+			a.sendHeartbeat(ctx, client)
+			if !configSent {
+				configSent = a.sendConfigStatus(ctx, client)
+			}
+		}
+	}
+}
+
+// newHostClient returns a client of the HostService of the server.
+func newHostClient() (*grpc.ClientConn, agentv1.HostServiceClient, error) {
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return vsock.Dial(vsock.Host, hostPort, nil)
+	}
+
+	conn, err := grpc.NewClient(
+		"passthrough:vsock",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, agentv1.NewHostServiceClient(conn), nil
+}
+
+func (a *Agent) sendHeartbeat(ctx context.Context, client agentv1.HostServiceClient) {
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	_, err := client.Heartbeat(ctx, &agentv1.HeartbeatRequest{
+		State:         string(a.runner.GetState()),
+		RunnerVersion: a.runnerVersion(),
+		Time:          timestamppb.Now(),
+		Job:           a.currentJob(),
+		Jobs:          int32(a.runner.Jobs()),
+	})
+	if err != nil {
+		a.logger.Debug().Err(err).Msg("Failed to send heartbeat")
+	}
+}
+
+func (a *Agent) sendTransition(ctx context.Context, client agentv1.HostServiceClient, transition runner.StateTransition) {
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	_, err := client.ReportStateTransition(ctx, &agentv1.ReportStateTransitionRequest{
+		From: string(transition.From),
+		To:   string(transition.To),
+		Time: timestamppb.New(transition.Time),
+		Line: transition.Line,
+		Job:  a.currentJob(),
+		Jobs: int32(a.runner.Jobs()),
+	})
+	if err != nil {
+		a.logger.Debug().Err(err).Msg("Failed to report state transition")
+	}
+}
+
+// sendConfigStatus pushes the config status and reports whether the server
+// received it.
+func (a *Agent) sendConfigStatus(ctx context.Context, client agentv1.HostServiceClient) bool {
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	_, err := client.ReportConfigStatus(ctx, &agentv1.ReportConfigStatusRequest{
+		Loaded:         a.configLoaded.Load(),
+		AwaitingRunner: a.awaiting.Load(),
+	})
+	if err != nil {
+		a.logger.Debug().Err(err).Msg("Failed to report config status")
+		return false
+	}
+
+	return true
+}
+
+// configStatusChanged signals the reporter to push the config status.
+func (a *Agent) configStatusChanged() {
+	select {
+	case a.configChanged <- struct{}{}:
+	default:
+		// A push is pending already, it reads the current status
+	}
+}
+
+// queueTransition queues a state transition for the reporter. Transitions
+// are dropped if the queue is full.
+func (a *Agent) queueTransition(transition runner.StateTransition) {
+	select {
+	case a.transitions <- transition:
+	default:
+		a.logger.Debug().Msgf("Dropping state transition %s -> %s, queue is full", transition.From, transition.To)
+	}
+}
+
+// runnerVersion returns the runner version. It is looked up until it is
+// found once.
+func (a *Agent) runnerVersion() string {
+	if a.version != "" {
+		return a.version
+	}
+
+	version, err := a.runner.GetVersion()
+	if err != nil {
+		a.logger.Debug().Err(err).Msg("Failed to get runner version")
+		return ""
+	}
+
+	a.version = version
+	return version
+}
@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+	"unsafe"
+
+	"github.com/hostinger/fireactions/agent/mmds"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	awaitRunnerInterval = 100 * time.Millisecond
+)
+
+// awaitRunner waits for the server to put a runner JIT config in MMDS, which
+// it does after it restored the VM from the snapshot taken while waiting. It
+// reports whether the runner got its JIT config. The runner is not started
+// unless the random number generator is reseeded with the entropy from MMDS.
+func (a *Agent) awaitRunner(ctx context.Context) bool {
+	a.awaiting.Store(true)
+	a.configStatusChanged()
+	defer func() {
+		a.awaiting.Store(false)
+		a.configStatusChanged()
+	}()
+
+	a.logger.Info().Msg("Waiting for a runner JIT config in MMDS")
+
+	ticker := time.NewTicker(awaitRunnerInterval)
+	defer ticker.Stop()
+
+	for !a.stopping.Load() {
+		metadata, err := a.metadata(ctx)
+		if err != nil {
+			a.logger.Debug().Err(err).Msg("Failed to get metadata")
+		} else if config, _ := metadata["runner_jit_config"].(string); config != "" {
+			if err := a.reseedRandom(metadata["entropy"]); err != nil {
+				a.logger.Error().Err(err).Msg("Failed to reseed random number generator")
+				return false
+			}
+
+			a.syncClock(metadata["restored_at"])
+			a.runner.Renew(config)
+			a.configLoaded.Store(true)
+
+			a.logger.Info().Msg("Runner JIT config received")
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+
+	return false
+}
+
+// syncClock sets the clock to the time the server restored the VM at. The
+// clock of a restored VM is behind by as long as the snapshot was kept.
+func (a *Agent) syncClock(restoredAt interface{}) {
+	value, _ := restoredAt.(string)
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		a.logger.Warn().Msgf("Invalid restored_at %q in metadata, not setting clock", value)
+		return
+	}
+
+	if err := a.setClock(t); err != nil {
+		a.logger.Warn().Err(err).Msg("Failed to set clock")
+	}
+}
+
+// reseedRandom adds the entropy the server put in MMDS to the entropy pool of
+// the kernel. A restored VM starts with the random number generator state of
+// the snapshot, shared by all VMs restored from it.
+func (a *Agent) reseedRandom(entropy interface{}) error {
+	value, _ := entropy.(string)
+	seed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid entropy in metadata: %w", err)
+	}
+
+	if len(seed) == 0 {
+		return fmt.Errorf("no entropy in metadata")
+	}
+
+	return a.addEntropy(seed)
+}
+
+// getMetadata gets the fireactions metadata from MMDS.
+func getMetadata(ctx context.Context) (map[string]interface{}, error) {
+	return mmds.NewClient().GetMetadata(ctx, "fireactions")
+}
+
+// setClock sets the system clock to t.
+func setClock(t time.Time) error {
+	ts := unix.NsecToTimespec(t.UnixNano())
+	return unix.ClockSettime(unix.CLOCK_REALTIME, &ts)
+}
+
+// addEntropy adds seed to the entropy pool of the kernel with the
+// RNDADDENTROPY ioctl and credits all of its bits, which reseeds the random
+// number generator.
+func addEntropy(seed []byte) error {
+	random, err := os.OpenFile("/dev/random", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer random.Close()
+
+	// struct rand_pool_info { int entropy_count; int buf_size; __u32 buf[]; }
+	info := make([]byte, 8+len(seed))
+	binary.NativeEndian.PutUint32(info[0:], uint32(len(seed)*8))
+	binary.NativeEndian.PutUint32(info[4:], uint32(len(seed)))
+	copy(info[8:], seed)
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, random.Fd(), unix.RNDADDENTROPY, uintptr(unsafe.Pointer(&info[0])))
+	if errno != 0 {
+		return fmt.Errorf("RNDADDENTROPY: %w", errno)
+	}
+
+	return nil
+}
+
+// resetVM reboots the VM right away, which makes Firecracker exit.
+func resetVM() error {
+	unix.Sync()
+	return unix.Reboot(unix.LINUX_REBOOT_CMD_RESTART)
+}
@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRestoreAgent returns an agent awaiting a runner whose reporter
+// pushes to the returned host client until the test ends.
+func newTestRestoreAgent(t *testing.T, metadata func(ctx context.Context) (map[string]interface{}, error)) (*Agent, *time.Time, *fakeHostClient) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	clock := &time.Time{}
+	host := &fakeHostClient{}
+	a := &Agent{
+		cfg:           Config{AwaitRunner: true},
+		logger:        &logger,
+		runner:        runner.New("", runner.WithDirectory(t.TempDir())),
+		host:          host,
+		configChanged: make(chan struct{}, 1),
+		metadata:      metadata,
+		setClock: func(t time.Time) error {
+			*clock = t
+			return nil
+		},
+		addEntropy: func(seed []byte) error { return nil },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go a.runReporter(ctx)
+
+	return a, clock, host
+}
+
+func TestAwaitRunner(t *testing.T) {
+	restoredAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	var calls atomic.Int32
+	a, clock, host := newTestRestoreAgent(t, func(ctx context.Context) (map[string]interface{}, error) {
+		switch calls.Add(1) {
+		case 1:
+			return nil, errors.New("connection reset")
+		case 2:
+			return map[string]interface{}{"hostname": "runner"}, nil
+		default:
+			return map[string]interface{}{
+				"runner_jit_config": "config",
+				"entropy":           "cmFuZG9t",
+				"restored_at":       restoredAt.Format(time.RFC3339Nano),
+			}, nil
+		}
+	})
+
+	var seed []byte
+	a.addEntropy = func(s []byte) error {
+		seed = s
+		return nil
+	}
+
+	done := make(chan bool)
+	go func() {
+		done <- a.awaitRunner(context.Background())
+	}()
+
+	select {
+	case ok := <-done:
+		require.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner JIT config not received")
+	}
+
+	assert.Equal(t, []byte("random"), seed)
+	assert.Equal(t, restoredAt, *clock)
+	assert.True(t, a.configLoaded.Load())
+
+	require.Eventually(t, func() bool {
+		status := host.configStatus()
+		return status != nil && status.GetLoaded() && !status.GetAwaitingRunner()
+	}, 5*time.Second, 10*time.Millisecond, "the config status is pushed once the JIT config is received")
+}
+
+func TestAwaitRunner_Stop(t *testing.T) {
+	a, _, host := newTestRestoreAgent(t, func(ctx context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	})
+
+	done := make(chan bool)
+	go func() {
+		done <- a.awaitRunner(context.Background())
+	}()
+
+	require.Eventually(t, func() bool {
+		status := host.configStatus()
+		return status != nil && status.GetAwaitingRunner() && !status.GetLoaded()
+	}, 5*time.Second, 10*time.Millisecond, "waiting for the JIT config is pushed")
+
+	a.stopping.Store(true)
+
+	select {
+	case ok := <-done:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("still waiting after stop")
+	}
+}
+
+func TestAwaitRunner_Reseed(t *testing.T) {
+	tests := []struct {
+		name       string
+		entropy    interface{}
+		entropyErr error
+	}{
+		{"missing entropy", nil, nil},
+		{"invalid entropy", "not base64!", nil},
+		{"ioctl failure", "cmFuZG9t", errors.New("operation not permitted")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _, _ := newTestRestoreAgent(t, func(ctx context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{"runner_jit_config": "config", "entropy": tt.entropy}, nil
+			})
+			a.addEntropy = func([]byte) error { return tt.entropyErr }
+
+			assert.False(t, a.awaitRunner(context.Background()), "the runner is not started without reseeding")
+			assert.False(t, a.configLoaded.Load())
+		})
+	}
+}
@@ -6,8 +6,13 @@ import (
 	"io"
 	"os"
 
+	"github.com/hostinger/fireactions/agent/runner"
 	"github.com/hostinger/fireactions/agent/tail"
+	"github.com/hostinger/fireactions/helper/logger"
 	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (a *Agent) GetRunnerState(ctx context.Context, req *agentv1.GetRunnerStateRequest) (*agentv1.GetRunnerStateResponse, error) {
@@ -17,6 +22,18 @@ func (a *Agent) GetRunnerState(ctx context.Context, req *agentv1.GetRunnerStateR
 
 	resp := &agentv1.GetRunnerStateResponse{
 		State: string(a.runner.GetState()),
+		Job:   a.currentJob(),
+		Jobs:  int32(a.runner.Jobs()),
+	}
+
+	for _, result := range a.hooks.Results() {
+		resp.Hooks = append(resp.Hooks, &agentv1.HookResult{
+			Name:       string(result.Name),
+			ExitCode:   int32(result.ExitCode),
+			StartedAt:  timestamppb.New(result.StartedAt),
+			FinishedAt: timestamppb.New(result.FinishedAt),
+			Error:      result.Err,
+		})
 	}
 
 	return resp, nil
@@ -35,13 +52,98 @@ func (a *Agent) GetRunnerVersion(ctx context.Context, req *agentv1.GetRunnerVers
 	return resp, nil
 }
 
-func (a *Agent) GetLogs(req *agentv1.GetLogsRequest, stream agentv1.AgentService_GetLogsServer) error {
+// SetLogLevel implements AgentService.SetLogLevel.
+func (a *Agent) SetLogLevel(ctx context.Context, req *agentv1.SetLogLevelRequest) (*agentv1.SetLogLevelResponse, error) {
+	previous, err := logger.SetLevel(req.GetLevel())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid log level: %v", err)
+	}
+
+	a.logger.Info().Msgf("Log level changed from %s to %s", previous, req.GetLevel())
+	return &agentv1.SetLogLevelResponse{PreviousLevel: previous}, nil
+}
+
+func (a *Agent) WatchRunnerState(req *agentv1.WatchRunnerStateRequest, stream agentv1.AgentService_WatchRunnerStateServer) error {
+	if a.runner == nil {
+		return fmt.Errorf("runner not initialized")
+	}
+
+	history, transitions, stop := a.runner.Watch()
+	defer stop()
+
+	for _, transition := range history {
+		if err := stream.Send(convertStateTransitionToProto(transition)); err != nil {
+			return err
+		}
+	}
+
 	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case transition, ok := <-transitions:
+			if !ok {
+				if err := stop(); err != nil {
+					return status.Error(codes.ResourceExhausted, err.Error())
+				}
+				return nil
+			}
+			if err := stream.Send(convertStateTransitionToProto(transition)); err != nil {
+				return err
+			}
+		}
+	}
+}
 
-	if _, err := os.Stat(a.logFile); os.IsNotExist(err) && !req.Follow {
+// currentJob returns the job the runner is running or last ran, nil if none.
+func (a *Agent) currentJob() *agentv1.Job {
+	job, ok := a.runner.Job()
+	if !ok {
 		return nil
 	}
 
+	return convertJobToProto(job)
+}
+
+func convertJobToProto(job runner.Job) *agentv1.Job {
+	j := &agentv1.Job{
+		Name:       job.Name,
+		Repository: job.Repository,
+		Workflow:   job.Workflow,
+		RunId:      job.RunID,
+		RunAttempt: job.RunAttempt,
+		Result:     job.Result,
+	}
+
+	if !job.StartedAt.IsZero() {
+		j.StartedAt = timestamppb.New(job.StartedAt)
+	}
+
+	if !job.CompletedAt.IsZero() {
+		j.CompletedAt = timestamppb.New(job.CompletedAt)
+	}
+
+	return j
+}
+
+func convertStateTransitionToProto(transition runner.StateTransition) *agentv1.WatchRunnerStateResponse {
+	return &agentv1.WatchRunnerStateResponse{
+		From: string(transition.From),
+		To:   string(transition.To),
+		Time: timestamppb.New(transition.Time),
+		Line: transition.Line,
+	}
+}
+
+func (a *Agent) GetLogs(req *agentv1.GetLogsRequest, stream agentv1.AgentService_GetLogsServer) error {
+	ctx := stream.Context()
+
+	source, ok := a.findLogSource(req.GetSource())
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown log source %q", req.GetSource())
+	}
+
 	config := tail.Config{
 		Follow: req.Follow,
 		ReOpen: req.Follow,
@@ -51,7 +153,17 @@ func (a *Agent) GetLogs(req *agentv1.GetLogsRequest, stream agentv1.AgentService
 		config.Location = &tail.SeekInfo{Offset: int64(-req.TailLines), Whence: io.SeekEnd}
 	}
 
-	t, err := tail.TailFile(a.logFile, config)
+	var t *tail.Tail
+	var err error
+	if source.file != "" {
+		if _, err := os.Stat(source.file); os.IsNotExist(err) && !req.Follow {
+			return nil
+		}
+
+		t, err = tail.TailFile(source.file, config)
+	} else {
+		t, err = tail.TailPattern(source.pattern, config)
+	}
 	if err != nil {
 		return fmt.Errorf("tail file: %w", err)
 	}
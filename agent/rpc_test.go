@@ -1 +1,30 @@
 package agent
+
+import (
+	"context"
+	"testing"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger})
+
+	resp, err := client.SetLogLevel(context.Background(), &agentv1.SetLogLevelRequest{Level: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, "info", resp.GetPreviousLevel())
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	_, err = client.SetLogLevel(context.Background(), &agentv1.SetLogLevelRequest{Level: "verbose"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+}
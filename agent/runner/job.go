@@ -0,0 +1,270 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Results of a job as printed by the runner.
+const (
+	JobSucceeded = "Succeeded"
+	JobFailed    = "Failed"
+	JobCanceled  = "Canceled"
+)
+
+const (
+	jobDetailsTimeout      = 30 * time.Second
+	jobDetailsPollInterval = 500 * time.Millisecond
+)
+
+// Job is the job the runner is running or last ran. The name, start and
+// completion come from the runner output, the other details from the job
+// environment recorded by the job started hook or, failing that, the job
+// message in the Worker log of the job, once it was written.
+type Job struct {
+	Name        string
+	Repository  string
+	Workflow    string
+	RunID       int64
+	RunAttempt  int64
+	StartedAt   time.Time
+	CompletedAt time.Time
+	Result      string // Empty while the job is running
+}
+
+// Job returns the job the runner is running or last ran, or false if it did
+// not run a job yet.
+func (r *Runner) Job() (Job, bool) {
+	r.stateMu.RLock()
+	defer r.stateMu.RUnlock()
+
+	if r.job == nil {
+		return Job{}, false
+	}
+
+	return *r.job, true
+}
+
+// startJob records the start of a job and looks up its details in the
+// background.
+func (r *Runner) startJob(name string) {
+	job := &Job{Name: name, StartedAt: time.Now()}
+
+	r.stateMu.Lock()
+	r.job = job
+	r.jobs++
+	r.stateMu.Unlock()
+
+	go r.loadJobDetails(job)
+}
+
+// Jobs returns the number of jobs the runner started, including the current
+// one.
+func (r *Runner) Jobs() int {
+	r.stateMu.RLock()
+	defer r.stateMu.RUnlock()
+
+	return r.jobs
+}
+
+// completeJob records the result of the current job.
+func (r *Runner) completeJob(result string) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	if r.job == nil {
+		r.job = &Job{}
+	}
+
+	r.job.CompletedAt = time.Now()
+	r.job.Result = result
+
+	// The job environment of a job whose details were never loaded must not
+	// be taken for the next one
+	if r.jobEnvFile != "" {
+		_ = os.Remove(r.jobEnvFile)
+	}
+}
+
+// loadJobDetails waits for the job environment recorded by the job started
+// hook and fills in the details from it. The job message in the Worker log of
+// the job is the fallback, used until the job environment is recorded or if it
+// never is. Every job gets its own Worker log, so the log of a previous job is
+// never used again.
+func (r *Runner) loadJobDetails(job *Job) {
+	pattern := filepath.Join(r.directory, "_diag", "Worker_*.log")
+	deadline := time.Now().Add(jobDetailsTimeout)
+	found := false
+
+	for time.Now().Before(deadline) {
+		if r.jobEnvFile != "" {
+			if details, ok := readJobEnv(r.jobEnvFile); ok {
+				_ = os.Remove(r.jobEnvFile)
+				r.setJobDetails(job, details)
+				return
+			}
+		}
+
+		files, _ := filepath.Glob(pattern)
+
+		r.stateMu.RLock()
+		lastWorkerLog := r.lastWorkerLog
+		r.stateMu.RUnlock()
+
+		if !found && len(files) > 0 && files[len(files)-1] > lastWorkerLog {
+			path := files[len(files)-1]
+			if details, ok := readJobMessage(path); ok {
+				r.stateMu.Lock()
+				r.lastWorkerLog = path
+				r.stateMu.Unlock()
+
+				r.setJobDetails(job, details)
+				found = true
+				if r.jobEnvFile == "" {
+					return
+				}
+			}
+		}
+
+		time.Sleep(jobDetailsPollInterval)
+	}
+
+	if !found {
+		r.logger.Debug().Msgf("No job details found for job %s", job.Name)
+	}
+}
+
+// setJobDetails fills in the details of the job that do not come from the
+// runner output.
+func (r *Runner) setJobDetails(job *Job, details Job) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	job.Repository = details.Repository
+	job.Workflow = details.Workflow
+	job.RunID = details.RunID
+	job.RunAttempt = details.RunAttempt
+	if job.Name == "" {
+		job.Name = details.Name
+	}
+}
+
+// readJobEnv reads the job details from the job environment recorded by the
+// job started hook.
+func readJobEnv(path string) (Job, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Job{}, false
+	}
+	defer f.Close()
+
+	return parseJobEnv(f)
+}
+
+// parseJobEnv parses the job details from KEY=value lines of the GITHUB_*
+// variables of the job.
+func parseJobEnv(r io.Reader) (Job, bool) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			env[key] = value
+		}
+	}
+
+	if env["GITHUB_REPOSITORY"] == "" {
+		return Job{}, false
+	}
+
+	job := Job{
+		Repository: env["GITHUB_REPOSITORY"],
+		Workflow:   env["GITHUB_WORKFLOW"],
+	}
+	job.RunID, _ = strconv.ParseInt(env["GITHUB_RUN_ID"], 10, 64)
+	job.RunAttempt, _ = strconv.ParseInt(env["GITHUB_RUN_ATTEMPT"], 10, 64)
+
+	return job, true
+}
+
+// readJobMessage reads the job details from the job message in a Worker log.
+func readJobMessage(path string) (Job, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Job{}, false
+	}
+	defer f.Close()
+
+	return parseJobMessage(f)
+}
+
+// jobMessage is the part of the job message the Worker logs that holds the
+// job details. Context data is serialized as template tokens, dictionaries
+// have type 2 and their entries in d.
+type jobMessage struct {
+	JobDisplayName string `json:"jobDisplayName"`
+	ContextData    map[string]struct {
+		Type    int `json:"t"`
+		Entries []struct {
+			Key   string          `json:"k"`
+			Value json.RawMessage `json:"v"`
+		} `json:"d"`
+	} `json:"contextData"`
+}
+
+// parseJobMessage parses the job message from a Worker log. The message is
+// logged as indented JSON on the lines following "Job message:", up to the
+// next log entry.
+func parseJobMessage(r io.Reader) (Job, bool) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var message strings.Builder
+	inMessage := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !inMessage {
+			inMessage = strings.HasSuffix(strings.TrimSpace(line), "Job message:")
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			break
+		}
+
+		message.WriteString(line)
+		message.WriteByte('\n')
+	}
+
+	if message.Len() == 0 {
+		return Job{}, false
+	}
+
+	var msg jobMessage
+	if err := json.Unmarshal([]byte(message.String()), &msg); err != nil {
+		return Job{}, false
+	}
+
+	github := make(map[string]string)
+	for _, entry := range msg.ContextData["github"].Entries {
+		var value string
+		if err := json.Unmarshal(entry.Value, &value); err == nil {
+			github[entry.Key] = value
+		}
+	}
+
+	job := Job{
+		Name:       msg.JobDisplayName,
+		Repository: github["repository"],
+		Workflow:   github["workflow"],
+	}
+	job.RunID, _ = strconv.ParseInt(github["run_id"], 10, 64)
+	job.RunAttempt, _ = strconv.ParseInt(github["run_attempt"], 10, 64)
+
+	return job, true
+}
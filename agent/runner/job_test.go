@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobMessage(t *testing.T) {
+	job, ok := readJobMessage("testdata/Worker_20240101-100000-utc.log")
+	require.True(t, ok)
+
+	assert.Equal(t, Job{
+		Name:       "build (ubuntu)",
+		Repository: "hostinger/fireactions",
+		Workflow:   "CI",
+		RunID:      1234567890,
+		RunAttempt: 2,
+	}, job)
+
+	_, ok = parseJobMessage(strings.NewReader("[2024-01-01 10:00:00Z INFO Worker] Version: 2.331.0\n"))
+	assert.False(t, ok, "no job message")
+
+	_, ok = parseJobMessage(strings.NewReader("[2024-01-01 10:00:00Z INFO Worker] Job message:\n {\n  \"jobDisplayName\": \"build\",\n"))
+	assert.False(t, ok, "job message not fully written")
+}
+
+func TestParseJobEnv(t *testing.T) {
+	job, ok := parseJobEnv(strings.NewReader("GITHUB_REPOSITORY=hostinger/fireactions\nGITHUB_WORKFLOW=CI\nGITHUB_RUN_ID=1234567890\nGITHUB_RUN_ATTEMPT=2\n"))
+	require.True(t, ok)
+	assert.Equal(t, Job{Repository: "hostinger/fireactions", Workflow: "CI", RunID: 1234567890, RunAttempt: 2}, job)
+
+	_, ok = parseJobEnv(strings.NewReader("GITHUB_REPOSITORY=\nGITHUB_RUN_ID=1\n"))
+	assert.False(t, ok, "no repository")
+}
+
+func TestRunner_JobEnv(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "_diag"), 0755))
+	envFile := filepath.Join(dir, "job_started.env")
+
+	r := New("config", WithDirectory(dir), WithJobEnvFile(envFile))
+	r.pipeToLogger(strings.NewReader("2024-01-01 10:00:00Z: Running job: build (ubuntu)\n"), zerolog.Nop(), zerolog.InfoLevel)
+
+	// The Worker log is used until the job environment is recorded
+	data, err := os.ReadFile("testdata/Worker_20240101-100000-utc.log")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_diag", "Worker_20240101-100000-utc.log"), data, 0644))
+
+	assert.Eventually(t, func() bool {
+		job, _ := r.Job()
+		return job.Repository == "hostinger/fireactions"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(envFile, []byte("GITHUB_REPOSITORY=hostinger/other\nGITHUB_WORKFLOW=Release\nGITHUB_RUN_ID=42\nGITHUB_RUN_ATTEMPT=1\n"), 0644))
+
+	assert.Eventually(t, func() bool {
+		job, _ := r.Job()
+		return job.Repository == "hostinger/other"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	job, _ := r.Job()
+	assert.Equal(t, "Release", job.Workflow)
+	assert.Equal(t, int64(42), job.RunID)
+	assert.Equal(t, "build (ubuntu)", job.Name)
+	assert.NoFileExists(t, envFile, "the job environment is consumed")
+}
+
+func TestRunner_Job(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "_diag"), 0755))
+
+	r := New("config", WithDirectory(dir))
+	_, ok := r.Job()
+	assert.False(t, ok)
+
+	r.pipeToLogger(strings.NewReader("2024-01-01 10:00:00Z: Running job: build (ubuntu)\n"), zerolog.Nop(), zerolog.InfoLevel)
+
+	job, ok := r.Job()
+	require.True(t, ok)
+	assert.Equal(t, "build (ubuntu)", job.Name)
+	assert.Empty(t, job.Result)
+	assert.False(t, job.StartedAt.IsZero())
+
+	// Details are filled in once the Worker log of the job is written
+	data, err := os.ReadFile("testdata/Worker_20240101-100000-utc.log")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_diag", "Worker_20240101-100000-utc.log"), data, 0644))
+
+	assert.Eventually(t, func() bool {
+		job, _ := r.Job()
+		return job.Repository == "hostinger/fireactions"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	r.pipeToLogger(strings.NewReader("2024-01-01 10:05:00Z: Job build (ubuntu) completed with result: Failed\n"), zerolog.Nop(), zerolog.InfoLevel)
+
+	job, _ = r.Job()
+	assert.Equal(t, JobFailed, job.Result)
+	assert.Equal(t, int64(1234567890), job.RunID)
+	assert.False(t, job.CompletedAt.IsZero())
+	assert.Equal(t, StateCompleted, r.GetState())
+}
@@ -3,6 +3,7 @@ package runner
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,12 +14,24 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/rs/zerolog"
 )
 
 const (
-	defaultDir = "/opt/runner"
+	// DefaultDir is the default directory the runner is installed in.
+	DefaultDir = "/opt/runner"
+
+	// DefaultOwner is the default user the runner process runs as.
+	DefaultOwner = "runner"
+
+	// DefaultGroup is the default group the runner process runs as.
+	DefaultGroup = "docker"
+
+	// defaultWaitDelay bounds how long Run waits for the output of processes
+	// that outlive run.sh, e.g. daemons started by a job.
+	defaultWaitDelay = 5 * time.Second
 )
 
 // RunnerState represents the current state of the runner.
@@ -31,20 +44,64 @@ const (
 	StateCompleted RunnerState = "Completed" // Runner completed a job
 	StateExited    RunnerState = "Exited"    // Runner process exited
 	StateError     RunnerState = "Error"     // Runner process exited with error
+	StateResetting RunnerState = "Resetting" // Workspace is cleaned up for the next job
 )
 
+const (
+	maxHistory    = 100
+	watcherBuffer = 16
+)
+
+// ErrWatchLagged is returned by the stop function of a watch that ended
+// because the watcher fell behind the state transitions.
+var ErrWatchLagged = errors.New("watcher fell behind the runner state transitions")
+
+// StateTransition is a change of the runner state.
+type StateTransition struct {
+	From RunnerState
+	To   RunnerState
+	Time time.Time
+	Line string // Runner output line that triggered the transition, if any
+}
+
+// IsFinal reports whether the runner process exited in the new state.
+func (t StateTransition) IsFinal() bool {
+	return t.To == StateExited || t.To == StateError
+}
+
 // Runner manages the GitHub Actions runner process.
 type Runner struct {
 	config    string
 	directory string
 	owner     string
 	group     string
+	env       []string
+	args      []string
 	stdout    io.Writer
 	stderr    io.Writer
 	logger    *zerolog.Logger
+	waitDelay time.Duration
+
+	stateMu   sync.RWMutex
+	state     RunnerState
+	history   []StateTransition
+	job       *Job
+	jobs      int // Number of jobs started, across renewals
+	process   *os.Process
+	stopping  bool // Whether the runner must not take new jobs
+	renewable bool // Whether the runner may run again after its process exited
+	done      bool // Whether the runner will not run again
+	listeners []func(StateTransition)
+	watchers  map[*watcher]struct{}
+
+	jobEnvFile    string // File the job started hook records the job environment to
+	lastWorkerLog string // Worker log of the last job with details
+}
 
-	stateMu sync.RWMutex
-	state   RunnerState
+// watcher is a watch of the state transitions, see Runner.Watch.
+type watcher struct {
+	ch     chan StateTransition
+	lagged bool // Whether ch was closed because the watcher fell behind
 }
 
 // Opt is a functional option for Runner.
@@ -104,18 +161,70 @@ func WithGroup(group string) Opt {
 	return f
 }
 
+// WithEnv adds environment variables in the form KEY=value to the runner
+// process.
+func WithEnv(env ...string) Opt {
+	f := func(r *Runner) {
+		r.env = append(r.env, env...)
+	}
+
+	return f
+}
+
+// WithArgs adds arguments passed to run.sh after the JIT config.
+func WithArgs(args ...string) Opt {
+	f := func(r *Runner) {
+		r.args = append(r.args, args...)
+	}
+
+	return f
+}
+
+// WithJobEnvFile sets the file the job started hook records the environment
+// of every job to, the primary source of the job details. Without it, or with
+// an empty path, the details only come from the Worker log.
+func WithJobEnvFile(path string) Opt {
+	f := func(r *Runner) {
+		r.jobEnvFile = path
+	}
+
+	return f
+}
+
+// WithStateListener registers a function that is called on every state
+// transition. Listeners are called synchronously and must not block.
+func WithStateListener(listener func(StateTransition)) Opt {
+	f := func(r *Runner) {
+		r.listeners = append(r.listeners, listener)
+	}
+
+	return f
+}
+
+// WithRenewal makes the runner renewable: it may be run again for another job
+// after its process exited, so watches only end once Finish is called.
+func WithRenewal() Opt {
+	f := func(r *Runner) {
+		r.renewable = true
+	}
+
+	return f
+}
+
 // New creates a new Runner.
 func New(config string, opts ...Opt) *Runner {
 	logger := zerolog.Nop()
 	r := &Runner{
 		config:    config,
-		directory: defaultDir,
-		owner:     "runner",
-		group:     "docker",
+		directory: DefaultDir,
+		owner:     DefaultOwner,
+		group:     DefaultGroup,
 		stdout:    os.Stdout,
 		stderr:    os.Stderr,
 		logger:    &logger,
+		waitDelay: defaultWaitDelay,
 		state:     StateStarting,
+		watchers:  make(map[*watcher]struct{}),
 	}
 
 	for _, opt := range opts {
@@ -132,15 +241,155 @@ func (r *Runner) GetState() RunnerState {
 	return r.state
 }
 
-// setState updates the runner state and notifies listeners.
-func (r *Runner) setState(state RunnerState) {
+// Watch returns the transitions that already happened and a channel of the
+// transitions that follow. The channel is closed once the runner is done,
+// after its process exited or, for renewable runners, once Finish is called,
+// or once stop is called. Transitions are never dropped: the channel of a
+// watcher that falls behind is closed early instead, and stop then returns
+// ErrWatchLagged.
+func (r *Runner) Watch() ([]StateTransition, <-chan StateTransition, func() error) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	history := append([]StateTransition(nil), r.history...)
+	w := &watcher{ch: make(chan StateTransition, watcherBuffer)}
+	if r.done {
+		close(w.ch)
+		return history, w.ch, func() error { return nil }
+	}
+
+	r.watchers[w] = struct{}{}
+	stop := func() error {
+		r.stateMu.Lock()
+		defer r.stateMu.Unlock()
+
+		if _, ok := r.watchers[w]; ok {
+			delete(r.watchers, w)
+			close(w.ch)
+		}
+
+		if w.lagged {
+			return ErrWatchLagged
+		}
+
+		return nil
+	}
+
+	return history, w.ch, stop
+}
+
+// setState updates the runner state and notifies listeners and watchers.
+// line is the runner output line that triggered the transition, if any.
+func (r *Runner) setState(state RunnerState, line string) {
 	r.stateMu.Lock()
 	oldState := r.state
+	if oldState == state {
+		r.stateMu.Unlock()
+		return
+	}
+
 	r.state = state
+	transition := StateTransition{From: oldState, To: state, Time: time.Now(), Line: line}
+	r.history = append(r.history, transition)
+	if len(r.history) > maxHistory {
+		r.history = r.history[len(r.history)-maxHistory:]
+	}
+
+	for w := range r.watchers {
+		select {
+		case w.ch <- transition:
+		default:
+			r.logger.Warn().Msgf("Ending the watch of a slow watcher at state transition %s -> %s", oldState, state)
+			w.lagged = true
+			delete(r.watchers, w)
+			close(w.ch)
+		}
+	}
+
+	if transition.IsFinal() && !r.renewable {
+		r.finish()
+	}
+	stopping := r.stopping
 	r.stateMu.Unlock()
 
-	if oldState != state {
-		r.logger.Info().Msgf("Runner state changed: %s -> %s", oldState, state)
+	r.logger.Info().Msgf("Runner state changed: %s -> %s", oldState, state)
+
+	for _, listener := range r.listeners {
+		listener(transition)
+	}
+
+	if stopping && (state == StateIdle || state == StateCompleted) {
+		r.interrupt()
+	}
+}
+
+// Finish marks the runner as done once it will not be run again, which ends
+// the watches. Runners that are not renewable are done once their process
+// exited already.
+func (r *Runner) Finish() {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	r.finish()
+}
+
+// finish marks the runner as done and closes the channels of the watchers.
+// The caller must hold stateMu.
+func (r *Runner) finish() {
+	r.done = true
+	for w := range r.watchers {
+		delete(r.watchers, w)
+		close(w.ch)
+	}
+}
+
+// Reset marks the runner as being reset for its next job, after its process
+// exited.
+func (r *Runner) Reset() {
+	r.setState(StateResetting, "")
+}
+
+// Renew sets the JIT config the runner is registered with for its next job.
+// Run can then be called again. The job history is kept.
+func (r *Runner) Renew(config string) {
+	r.stateMu.Lock()
+	r.config = config
+	r.stateMu.Unlock()
+
+	r.setState(StateStarting, "")
+}
+
+// Stop stops the runner from taking new jobs. An idle runner is interrupted
+// right away, a busy one as soon as its current job completes. A runner that
+// did not start yet does not start at all.
+func (r *Runner) Stop() {
+	r.stateMu.Lock()
+	r.stopping = true
+	state := r.state
+	r.stateMu.Unlock()
+
+	if state == StateRunning {
+		r.logger.Info().Msg("Runner is stopping after the current job")
+		return
+	}
+
+	r.interrupt()
+}
+
+// interrupt sends SIGINT to the process group of the runner, so that it
+// reaches the listener started by run.sh.
+func (r *Runner) interrupt() {
+	r.stateMu.RLock()
+	process := r.process
+	r.stateMu.RUnlock()
+
+	if process == nil {
+		return
+	}
+
+	r.logger.Info().Msg("Interrupting GitHub Actions runner")
+	if err := syscall.Kill(-process.Pid, syscall.SIGINT); err != nil && err != syscall.ESRCH {
+		r.logger.Warn().Err(err).Msg("Failed to interrupt runner")
 	}
 }
 
@@ -177,25 +426,42 @@ func (r *Runner) GetVersion() (string, error) {
 
 // Run starts the GitHub Actions runner and blocks until it exits.
 func (r *Runner) Run(ctx context.Context) error {
+	r.stateMu.RLock()
+	stopping := r.stopping
+	config := r.config
+	r.stateMu.RUnlock()
+
+	if stopping {
+		r.logger.Info().Msg("Runner was stopped before it started")
+		r.setState(StateExited, "")
+		return nil
+	}
+
 	r.logger.Info().Msg("Starting GitHub Actions runner")
 
-	sanitizedConfig := strings.TrimSpace(r.config)
+	sanitizedConfig := strings.TrimSpace(config)
 	if strings.ContainsAny(sanitizedConfig, ";|&$`(){}[]*?~<>^!\n\r") {
 		return fmt.Errorf("invalid characters in config: %q", sanitizedConfig)
 	}
 
-	runCmd := exec.CommandContext(ctx, filepath.Join(r.directory, "run.sh"), "--jitconfig", sanitizedConfig)
+	args := append([]string{"--jitconfig", sanitizedConfig}, r.args...)
+	runCmd := exec.CommandContext(ctx, filepath.Join(r.directory, "run.sh"), args...)
 	runCmd.Dir = r.directory
-
-	stdoutPipe, err := runCmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("create stdout pipe: %w", err)
+	// Kill Runner.Listener and the job processes along with run.sh, they
+	// hold its output open
+	runCmd.Cancel = func() error {
+		return syscall.Kill(-runCmd.Process.Pid, syscall.SIGKILL)
 	}
+	// Processes that left the process group, e.g. daemons started by a job,
+	// may still hold the output open and would keep Run waiting forever
+	runCmd.WaitDelay = r.waitDelay
 
-	stderrPipe, err := runCmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("create stderr pipe: %w", err)
-	}
+	// Wait returns once the output was read, so the last lines are logged
+	// and change the state before the exit does
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	runCmd.Stdout = stdoutWriter
+	runCmd.Stderr = stderrWriter
 
 	owner, err := user.Lookup(r.owner)
 	if err != nil {
@@ -219,6 +485,7 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	runCmd.SysProcAttr = &syscall.SysProcAttr{
 		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+		Setpgid:    true, // See interrupt
 	}
 
 	runCmd.Env = append(
@@ -230,22 +497,53 @@ func (r *Runner) Run(ctx context.Context) error {
 		fmt.Sprintf("UID=%d", uid),
 		fmt.Sprintf("GID=%d", gid),
 	)
+	runCmd.Env = append(runCmd.Env, r.env...)
 
 	if err := runCmd.Start(); err != nil {
-		r.setState(StateError)
+		r.setState(StateError, "")
 		return fmt.Errorf("start runner: %w", err)
 	}
 
-	go r.pipeToLogger(stdoutPipe, *r.logger, zerolog.InfoLevel)
-	go r.pipeToLogger(stderrPipe, *r.logger, zerolog.ErrorLevel)
+	r.stateMu.Lock()
+	r.process = runCmd.Process
+	stopping = r.stopping
+	r.stateMu.Unlock()
+
+	// Stop may have been called while the process was starting
+	if stopping {
+		r.interrupt()
+	}
+
+	var pipes sync.WaitGroup
+	pipes.Add(2)
+	go func() {
+		defer pipes.Done()
+		r.pipeToLogger(stdoutReader, *r.logger, zerolog.InfoLevel)
+	}()
+	go func() {
+		defer pipes.Done()
+		r.pipeToLogger(stderrReader, *r.logger, zerolog.ErrorLevel)
+	}()
 
 	err = runCmd.Wait()
+	_ = stdoutWriter.Close()
+	_ = stderrWriter.Close()
+	pipes.Wait()
+
+	r.stateMu.Lock()
+	r.process = nil
+	r.stateMu.Unlock()
+
+	if errors.Is(err, exec.ErrWaitDelay) {
+		r.logger.Warn().Msg("GitHub Actions runner exited, but processes it started still hold its output open")
+		err = nil
+	}
 	if err != nil {
-		r.setState(StateError)
+		r.setState(StateError, "")
 		return err
 	}
 
-	r.setState(StateExited)
+	r.setState(StateExited, "")
 	return nil
 }
 
@@ -263,11 +561,13 @@ func (r *Runner) pipeToLogger(reader io.Reader, logger zerolog.Logger, level zer
 
 		// Detect state changes based on log messages
 		if strings.Contains(line, "Listening for Jobs") {
-			r.setState(StateIdle)
-		} else if strings.Contains(line, "Running job:") {
-			r.setState(StateRunning)
-		} else if strings.Contains(line, "Job") && strings.Contains(line, "completed with result:") {
-			r.setState(StateCompleted)
+			r.setState(StateIdle, line)
+		} else if _, name, ok := strings.Cut(line, "Running job:"); ok {
+			r.startJob(strings.TrimSpace(name))
+			r.setState(StateRunning, line)
+		} else if _, result, ok := strings.Cut(line, "completed with result:"); ok && strings.Contains(line, "Job") {
+			r.completeJob(strings.TrimSpace(result))
+			r.setState(StateCompleted, line)
 		}
 	}
 }
@@ -0,0 +1,298 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_Watch(t *testing.T) {
+	r := New("config")
+	r.pipeToLogger(strings.NewReader("√ Connected to GitHub\nListening for Jobs\n"), zerolog.Nop(), zerolog.InfoLevel)
+
+	history, transitions, cancel := r.Watch()
+	defer cancel()
+
+	require.Len(t, history, 1)
+	assert.Equal(t, StateStarting, history[0].From)
+	assert.Equal(t, StateIdle, history[0].To)
+	assert.Equal(t, "Listening for Jobs", history[0].Line)
+
+	r.pipeToLogger(strings.NewReader("Running job: build\n"), zerolog.Nop(), zerolog.InfoLevel)
+	transition := <-transitions
+	assert.Equal(t, StateRunning, transition.To)
+	assert.Equal(t, "Running job: build", transition.Line)
+	assert.False(t, transition.Time.IsZero())
+
+	r.setState(StateExited, "")
+	transition = <-transitions
+	assert.True(t, transition.IsFinal())
+
+	_, ok := <-transitions
+	assert.False(t, ok, "the channel is closed once the runner exits")
+}
+
+func TestRunner_WatchAfterExit(t *testing.T) {
+	r := New("config")
+	r.setState(StateError, "")
+
+	history, transitions, cancel := r.Watch()
+	defer cancel()
+
+	require.Len(t, history, 1)
+	_, ok := <-transitions
+	assert.False(t, ok)
+}
+
+func TestRunner_WatchLagged(t *testing.T) {
+	r := New("config")
+
+	_, transitions, stop := r.Watch()
+
+	states := []RunnerState{StateIdle, StateRunning, StateCompleted}
+	for i := 0; i <= watcherBuffer; i++ {
+		r.setState(states[i%len(states)], "")
+	}
+
+	var received []StateTransition
+	for transition := range transitions {
+		received = append(received, transition)
+	}
+
+	assert.Len(t, received, watcherBuffer, "the buffered transitions are delivered")
+	assert.Equal(t, StateStarting, received[0].From)
+	assert.ErrorIs(t, stop(), ErrWatchLagged, "the watch ends with an error instead of skipping a transition")
+	assert.Empty(t, r.watchers, "lagged watchers are removed")
+}
+
+func TestRunner_WatchCancel(t *testing.T) {
+	r := New("config")
+
+	_, transitions, cancel := r.Watch()
+	assert.NoError(t, cancel())
+	assert.NoError(t, cancel())
+
+	_, ok := <-transitions
+	assert.False(t, ok)
+
+	r.setState(StateIdle, "Listening for Jobs")
+	assert.Empty(t, r.watchers)
+}
+
+func TestRunner_Run(t *testing.T) {
+	var stdout lockedBuffer
+	logger := zerolog.New(&stdout)
+	r := newTestRunner(t, "echo \"args: $*\"\necho \"env: $RUNNER_LABEL\"",
+		WithLogger(&logger),
+		WithEnv("RUNNER_LABEL=gpu"),
+		WithArgs("--once", "--disableupdate"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, r.Run(ctx))
+	assert.Equal(t, StateExited, r.GetState())
+
+	// The output is piped to the logger asynchronously
+	assert.Eventually(t, func() bool {
+		return strings.Contains(stdout.String(), "args: --jitconfig config --once --disableupdate") &&
+			strings.Contains(stdout.String(), "env: gpu")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRunner_RunOutputHeldOpen(t *testing.T) {
+	r := newTestRunner(t, "echo \"Listening for Jobs\"\nsleep 2 &")
+	r.waitDelay = 100 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	require.NoError(t, r.Run(ctx), "processes left running do not fail the runner")
+	assert.Less(t, time.Since(start), time.Second, "Run does not wait for processes holding the output open")
+	assert.Equal(t, StateExited, r.GetState())
+}
+
+func TestRunner_RunCancel(t *testing.T) {
+	r := newTestRunner(t, "echo \"Listening for Jobs\"\nsleep 30 &\nwhile true; do sleep 0.05; done")
+	r.waitDelay = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.Equal(t, StateError, r.GetState())
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+func TestRunner_StopIdle(t *testing.T) {
+	var r *Runner
+	r = newTestRunner(t, "echo \"Listening for Jobs\"\nwhile true; do sleep 0.05; done",
+		WithStateListener(func(transition StateTransition) {
+			if transition.To == StateIdle {
+				r.Stop()
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, r.Run(ctx), "the runner exits once interrupted")
+	assert.Equal(t, StateExited, r.GetState())
+}
+
+func TestRunner_StopRunning(t *testing.T) {
+	var r *Runner
+	r = newTestRunner(t, "echo \"Listening for Jobs\"\necho \"Running job: build\"\nsleep 0.2\necho \"Job build completed with result: Succeeded\"\nwhile true; do sleep 0.05; done",
+		WithStateListener(func(transition StateTransition) {
+			if transition.To == StateRunning {
+				r.Stop()
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, r.Run(ctx))
+
+	history, _, cancelWatch := r.Watch()
+	defer cancelWatch()
+
+	states := make([]RunnerState, 0, len(history))
+	for _, transition := range history {
+		states = append(states, transition.To)
+	}
+	assert.Equal(t, []RunnerState{StateIdle, StateRunning, StateCompleted, StateExited}, states, "the job completes before the runner is interrupted")
+}
+
+func TestRunner_Renew(t *testing.T) {
+	var stdout lockedBuffer
+	logger := zerolog.New(&stdout)
+	r := newTestRunner(t, "echo \"Running job: build\"\necho \"Job build completed with result: Succeeded\"\necho \"args: $*\"", WithLogger(&logger))
+
+	require.NoError(t, r.Run(context.Background()))
+	assert.Equal(t, 1, r.Jobs())
+
+	r.Reset()
+	assert.Equal(t, StateResetting, r.GetState())
+
+	r.Renew("config2")
+	assert.Equal(t, StateStarting, r.GetState())
+
+	require.NoError(t, r.Run(context.Background()))
+	assert.Equal(t, 2, r.Jobs())
+	assert.Contains(t, stdout.String(), "args: --jitconfig config2")
+
+	history, _, cancel := r.Watch()
+	defer cancel()
+
+	states := make([]RunnerState, 0, len(history))
+	for _, transition := range history {
+		states = append(states, transition.To)
+	}
+	assert.Equal(t, []RunnerState{StateRunning, StateCompleted, StateExited, StateResetting, StateStarting, StateRunning, StateCompleted, StateExited}, states)
+}
+
+func TestRunner_WatchRenewal(t *testing.T) {
+	r := newTestRunner(t, "echo \"Running job: build\"\necho \"Job build completed with result: Succeeded\"", WithRenewal())
+
+	_, transitions, cancel := r.Watch()
+	defer cancel()
+
+	var states []RunnerState
+	receive := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			transition, ok := <-transitions
+			require.True(t, ok, "the channel is open until the runner is finished")
+			states = append(states, transition.To)
+		}
+	}
+
+	require.NoError(t, r.Run(context.Background()))
+	receive(3)
+
+	r.Reset()
+	r.Renew("config2")
+	require.NoError(t, r.Run(context.Background()))
+	receive(5)
+
+	assert.Equal(t, []RunnerState{StateRunning, StateCompleted, StateExited, StateResetting, StateStarting, StateRunning, StateCompleted, StateExited}, states)
+
+	r.Finish()
+	_, ok := <-transitions
+	assert.False(t, ok, "the channel is closed once the runner is finished")
+
+	_, transitions, _ = r.Watch()
+	_, ok = <-transitions
+	assert.False(t, ok)
+}
+
+func TestRunner_StopBeforeRun(t *testing.T) {
+	r := New("config", WithDirectory(t.TempDir()))
+	r.Stop()
+
+	require.NoError(t, r.Run(context.Background()))
+	assert.Equal(t, StateExited, r.GetState())
+}
+
+// newTestRunner returns a runner of a fake run.sh running script as the
+// current user. The script exits on SIGINT.
+func newTestRunner(t *testing.T, script string, opts ...Opt) *Runner {
+	t.Helper()
+
+	if os.Geteuid() != 0 {
+		t.Skip("running the runner as another user requires root")
+	}
+
+	current, err := user.Current()
+	require.NoError(t, err)
+	group, err := user.LookupGroupId(current.Gid)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	script = "#!/bin/sh\ntrap 'exit 0' INT\n" + script + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run.sh"), []byte(script), 0755))
+
+	opts = append([]Opt{WithDirectory(dir), WithOwner(current.Username), WithGroup(group.Name)}, opts...)
+	return New("config", opts...)
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use, as the runner
+// output is logged from one goroutine per pipe.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
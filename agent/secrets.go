@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	secretsDir = "/run/fireactions/secrets"
+
+	secretTargetEnv  = "env"
+	secretTargetFile = "file"
+)
+
+// Secret is a secret delivered to the agent via MMDS.
+type Secret struct {
+	Name   string `validate:"required,excludesall=/= "`
+	Value  string `validate:"required"`
+	Target string `validate:"required,oneof=env file"`
+}
+
+// String implements fmt.Stringer and never includes the value.
+func (s Secret) String() string {
+	return fmt.Sprintf("%s (%s)", s.Name, s.Target)
+}
+
+// installSecrets writes the file secrets to a tmpfs readable by the runner
+// user and returns the env secrets in the form KEY=value.
+func (a *Agent) installSecrets() ([]string, error) {
+	if len(a.cfg.Secrets) == 0 {
+		return nil, nil
+	}
+
+	uid, gid := runnerOwnerIDs(a.cfg.Runner.User)
+	env := make([]string, 0, len(a.cfg.Secrets))
+	names := make([]string, 0, len(a.cfg.Secrets))
+	for _, secret := range a.cfg.Secrets {
+		names = append(names, secret.Name)
+
+		if secret.Target == secretTargetEnv {
+			env = append(env, fmt.Sprintf("%s=%s", secret.Name, secret.Value))
+			continue
+		}
+
+		if err := a.ensureSecretsDir(uid, gid); err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(a.secretsDir, secret.Name)
+		if err := os.WriteFile(path, []byte(secret.Value), 0400); err != nil {
+			return nil, fmt.Errorf("write secret %s: %w", secret.Name, err)
+		}
+
+		if err := os.Chown(path, uid, gid); err != nil {
+			return nil, fmt.Errorf("chown secret %s: %w", secret.Name, err)
+		}
+	}
+
+	a.logger.Info().Strs("secrets", names).Msg("Secrets installed")
+	return env, nil
+}
+
+// runnerOwnerIDs returns the uid and gid of the runner user, or -1 if the
+// user does not exist.
+func runnerOwnerIDs(name string) (int, int) {
+	owner, err := user.Lookup(name)
+	if err != nil {
+		return -1, -1
+	}
+
+	uid, err := strconv.Atoi(owner.Uid)
+	if err != nil {
+		return -1, -1
+	}
+
+	gid, err := strconv.Atoi(owner.Gid)
+	if err != nil {
+		return -1, -1
+	}
+
+	return uid, gid
+}
+
+// ensureSecretsDir creates the secrets directory on a dedicated tmpfs, so
+// secrets never touch the root filesystem.
+func (a *Agent) ensureSecretsDir(uid, gid int) error {
+	if err := os.MkdirAll(a.secretsDir, 0750); err != nil {
+		return fmt.Errorf("create secrets directory: %w", err)
+	}
+
+	var stat unix.Statfs_t
+	if err := unix.Statfs(a.secretsDir, &stat); err != nil {
+		return fmt.Errorf("statfs secrets directory: %w", err)
+	}
+
+	if stat.Type != unix.TMPFS_MAGIC {
+		if err := unix.Mount("tmpfs", a.secretsDir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "mode=0750"); err != nil {
+			return fmt.Errorf("mount tmpfs on secrets directory: %w", err)
+		}
+	}
+
+	if err := os.Chown(a.secretsDir, uid, gid); err != nil {
+		return fmt.Errorf("chown secrets directory: %w", err)
+	}
+
+	return nil
+}
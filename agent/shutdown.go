@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultShutdownGracePeriod = 5 * time.Minute
+)
+
+// Shutdown implements AgentService.Shutdown.
+func (a *Agent) Shutdown(ctx context.Context, req *agentv1.ShutdownRequest) (*agentv1.ShutdownResponse, error) {
+	if a.runner == nil {
+		return nil, status.Error(codes.FailedPrecondition, "runner not initialized")
+	}
+
+	gracePeriod := req.GetGracePeriod().AsDuration()
+	if gracePeriod <= 0 {
+		gracePeriod = defaultShutdownGracePeriod
+	}
+
+	state := a.runner.GetState()
+	if !a.stopping.CompareAndSwap(false, true) {
+		a.logger.Info().Str("reason", req.GetReason()).Msg("Shutdown requested again, already shutting down")
+		return &agentv1.ShutdownResponse{RunnerState: string(state)}, nil
+	}
+
+	a.logger.Info().Str("reason", req.GetReason()).Dur("grace_period", gracePeriod).Msgf("Shutdown requested, stopping runner in state %s", state)
+	a.runner.Stop()
+
+	go a.shutdownAfterRunner(gracePeriod)
+
+	return &agentv1.ShutdownResponse{RunnerState: string(state)}, nil
+}
+
+// shutdownAfterRunner powers the VM off once the runner is done, or after the
+// grace period if the runner is still busy.
+func (a *Agent) shutdownAfterRunner(gracePeriod time.Duration) {
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-a.runnerDone:
+		a.logger.Info().Msg("Runner stopped, shutting down VM")
+	case <-timer.C:
+		if a.runner.GetState() == runner.StateRunning {
+			a.logger.Warn().Msgf("Runner did not complete its job within %s, shutting down VM", gracePeriod)
+		} else {
+			a.logger.Warn().Msgf("Runner did not stop within %s, shutting down VM", gracePeriod)
+		}
+	}
+
+	a.shutdown()
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newTestShutdownAgent(t *testing.T) (*Agent, chan struct{}) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	poweredOff := make(chan struct{}, 2)
+	a := &Agent{
+		logger:     &logger,
+		runner:     runner.New("config", runner.WithDirectory(t.TempDir())),
+		runnerDone: make(chan struct{}),
+		powerOff: func() error {
+			poweredOff <- struct{}{}
+			return nil
+		},
+	}
+
+	return a, poweredOff
+}
+
+func TestShutdown_RunnerDone(t *testing.T) {
+	a, poweredOff := newTestShutdownAgent(t)
+	client := newTestAgentClient(t, a)
+
+	resp, err := client.Shutdown(context.Background(), &agentv1.ShutdownRequest{Reason: "scale down", GracePeriod: durationpb.New(time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, string(runner.StateStarting), resp.GetRunnerState())
+
+	select {
+	case <-poweredOff:
+		t.Fatal("powered off before the runner is done")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The runner was stopped before it started, so it exits right away
+	require.NoError(t, a.runner.Run(context.Background()))
+	close(a.runnerDone)
+
+	select {
+	case <-poweredOff:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not powered off after the runner is done")
+	}
+
+	_, err = client.Shutdown(context.Background(), &agentv1.ShutdownRequest{Reason: "scale down"})
+	require.NoError(t, err, "shutting down again is a no-op")
+	assert.Len(t, poweredOff, 0)
+}
+
+func TestShutdown_GracePeriod(t *testing.T) {
+	a, poweredOff := newTestShutdownAgent(t)
+	client := newTestAgentClient(t, a)
+
+	_, err := client.Shutdown(context.Background(), &agentv1.ShutdownRequest{Reason: "pool stopped", GracePeriod: durationpb.New(50 * time.Millisecond)})
+	require.NoError(t, err)
+
+	select {
+	case <-poweredOff:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not powered off after the grace period")
+	}
+
+	// The runner is done after the grace period, but the VM is powered off once
+	close(a.runnerDone)
+	a.shutdown()
+	assert.Len(t, poweredOff, 0)
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/hostinger/fireactions/agent/stats"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultTopProcesses = 10
+	maxTopProcesses     = 100
+)
+
+// GetStats implements AgentService.GetStats.
+func (a *Agent) GetStats(ctx context.Context, req *agentv1.GetStatsRequest) (*agentv1.GetStatsResponse, error) {
+	topProcesses := int(req.GetTopProcesses())
+	switch {
+	case topProcesses < 0:
+		return nil, status.Error(codes.InvalidArgument, "top_processes must not be negative")
+	case topProcesses == 0:
+		topProcesses = defaultTopProcesses
+	case topProcesses > maxTopProcesses:
+		topProcesses = maxTopProcesses
+	}
+
+	s, err := a.stats.Collect(ctx, topProcesses)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "collect stats: %v", err)
+	}
+
+	return convertStatsToProto(s), nil
+}
+
+func convertStatsToProto(s *stats.Stats) *agentv1.GetStatsResponse {
+	resp := &agentv1.GetStatsResponse{
+		Time: timestamppb.New(s.Time),
+		Cpu:  &agentv1.CPUStats{Count: int32(s.CPU.Count), UsagePercent: s.CPU.UsagePercent, BusySeconds: s.CPU.BusySeconds},
+		Load: &agentv1.LoadStats{Load1: s.Load.Load1, Load5: s.Load.Load5, Load15: s.Load.Load15},
+		Memory: &agentv1.MemoryStats{
+			Total:     s.Memory.Total,
+			Available: s.Memory.Available,
+			Used:      s.Memory.Used,
+			Buffers:   s.Memory.Buffers,
+			Cached:    s.Memory.Cached,
+			SwapTotal: s.Memory.SwapTotal,
+			SwapUsed:  s.Memory.SwapUsed,
+		},
+	}
+
+	for _, disk := range s.Disks {
+		resp.Disks = append(resp.Disks, &agentv1.DiskStats{
+			Mountpoint: disk.Mountpoint,
+			Device:     disk.Device,
+			Fstype:     disk.Fstype,
+			Total:      disk.Total,
+			Used:       disk.Used,
+			Available:  disk.Available,
+		})
+	}
+
+	for _, network := range s.Networks {
+		resp.Networks = append(resp.Networks, &agentv1.NetworkStats{
+			Interface: network.Interface,
+			RxBytes:   network.RxBytes,
+			TxBytes:   network.TxBytes,
+			RxPackets: network.RxPackets,
+			TxPackets: network.TxPackets,
+			RxErrors:  network.RxErrors,
+			TxErrors:  network.TxErrors,
+			RxDropped: network.RxDropped,
+			TxDropped: network.TxDropped,
+		})
+	}
+
+	for _, process := range s.Processes {
+		resp.Processes = append(resp.Processes, &agentv1.ProcessStats{
+			Pid:        int32(process.PID),
+			Name:       process.Name,
+			Command:    process.Command,
+			CpuPercent: process.CPUPercent,
+			Rss:        process.RSS,
+		})
+	}
+
+	return resp
+}
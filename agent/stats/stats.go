@@ -0,0 +1,463 @@
+package stats
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	defaultProcDir        = "/proc"
+	defaultSampleInterval = 250 * time.Millisecond
+	maxCommandLength      = 256
+
+	// clockTicks is the number of clock ticks per second, the unit of the
+	// times in /proc/stat (USER_HZ, 100 on all architectures).
+	clockTicks = 100
+)
+
+// Stats is a snapshot of the resource usage of the VM.
+type Stats struct {
+	Time      time.Time
+	CPU       CPU
+	Load      Load
+	Memory    Memory
+	Disks     []Disk
+	Networks  []Network
+	Processes []Process // Sorted by CPU usage, highest first
+}
+
+// CPU is the CPU usage over the sample interval and since boot.
+type CPU struct {
+	Count        int
+	UsagePercent float64 // Of all CPUs, 0-100
+	BusySeconds  float64 // CPU time spent not idle since boot, summed over all CPUs
+}
+
+// Load is the system load average.
+type Load struct {
+	Load1  float64
+	Load5  float64
+	Load15 float64
+}
+
+// Memory is the memory usage in bytes.
+type Memory struct {
+	Total     uint64
+	Available uint64
+	Used      uint64 // Total - Available
+	Buffers   uint64
+	Cached    uint64
+	SwapTotal uint64
+	SwapUsed  uint64
+}
+
+// Disk is the usage of a mounted block device in bytes.
+type Disk struct {
+	Mountpoint string
+	Device     string
+	Fstype     string
+	Total      uint64
+	Used       uint64
+	Available  uint64
+}
+
+// Network are the counters of a network interface.
+type Network struct {
+	Interface string
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+	RxErrors  uint64
+	TxErrors  uint64
+	RxDropped uint64
+	TxDropped uint64
+}
+
+// Process is the resource usage of a process.
+type Process struct {
+	PID        int
+	Name       string
+	Command    string
+	CPUPercent float64 // Of a single CPU, like top
+	RSS        uint64  // Resident set size in bytes
+}
+
+// Collector collects Stats from /proc.
+type Collector struct {
+	procDir        string
+	sampleInterval time.Duration
+}
+
+// Opt is a functional option for Collector.
+type Opt func(c *Collector)
+
+// WithProcDir sets the directory procfs is mounted on.
+func WithProcDir(dir string) Opt {
+	f := func(c *Collector) {
+		c.procDir = dir
+	}
+
+	return f
+}
+
+// WithSampleInterval sets the interval CPU usage is sampled over.
+func WithSampleInterval(interval time.Duration) Opt {
+	f := func(c *Collector) {
+		c.sampleInterval = interval
+	}
+
+	return f
+}
+
+// New creates a new Collector.
+func New(opts ...Opt) *Collector {
+	c := &Collector{
+		procDir:        defaultProcDir,
+		sampleInterval: defaultSampleInterval,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Collect collects a snapshot of the resource usage, including the top
+// processes by CPU usage. CPU usage is sampled over the sample interval.
+func (c *Collector) Collect(ctx context.Context, topProcesses int) (*Stats, error) {
+	cpu1, err := c.readCPU()
+	if err != nil {
+		return nil, fmt.Errorf("read cpu: %w", err)
+	}
+	procs1 := c.readProcesses()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.sampleInterval):
+	}
+
+	cpu2, err := c.readCPU()
+	if err != nil {
+		return nil, fmt.Errorf("read cpu: %w", err)
+	}
+	procs2 := c.readProcesses()
+
+	stats := &Stats{Time: time.Now(), CPU: CPU{Count: cpu2.count, BusySeconds: float64(cpu2.total-cpu2.idle) / clockTicks}}
+	totalDelta := float64(cpu2.total - cpu1.total)
+	if totalDelta > 0 {
+		stats.CPU.UsagePercent = 100 * (totalDelta - float64(cpu2.idle-cpu1.idle)) / totalDelta
+	}
+
+	if stats.Load, err = c.readLoad(); err != nil {
+		return nil, fmt.Errorf("read loadavg: %w", err)
+	}
+
+	if stats.Memory, err = c.readMemory(); err != nil {
+		return nil, fmt.Errorf("read meminfo: %w", err)
+	}
+
+	if stats.Disks, err = c.readDisks(); err != nil {
+		return nil, fmt.Errorf("read mounts: %w", err)
+	}
+
+	if stats.Networks, err = c.readNetworks(); err != nil {
+		return nil, fmt.Errorf("read net/dev: %w", err)
+	}
+
+	for pid, p2 := range procs2 {
+		process := p2.Process
+		if p1, ok := procs1[pid]; ok && totalDelta > 0 && cpu2.count > 0 {
+			// Total ticks are summed over all CPUs, scale to a single CPU
+			process.CPUPercent = 100 * float64(p2.ticks-p1.ticks) / (totalDelta / float64(cpu2.count))
+		}
+
+		stats.Processes = append(stats.Processes, process)
+	}
+
+	sort.Slice(stats.Processes, func(i, j int) bool {
+		if stats.Processes[i].CPUPercent != stats.Processes[j].CPUPercent {
+			return stats.Processes[i].CPUPercent > stats.Processes[j].CPUPercent
+		}
+
+		return stats.Processes[i].RSS > stats.Processes[j].RSS
+	})
+
+	if len(stats.Processes) > topProcesses {
+		stats.Processes = stats.Processes[:topProcesses]
+	}
+
+	return stats, nil
+}
+
+type cpuSample struct {
+	count int
+	total uint64
+	idle  uint64
+}
+
+func (c *Collector) readCPU() (cpuSample, error) {
+	f, err := os.Open(filepath.Join(c.procDir, "stat"))
+	if err != nil {
+		return cpuSample{}, err
+	}
+	defer f.Close()
+
+	var sample cpuSample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+
+		if fields[0] != "cpu" {
+			sample.count++
+			continue
+		}
+
+		// user nice system idle iowait irq softirq steal, guest time is
+		// already included in user and nice
+		for i, field := range fields[1:min(len(fields), 9)] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return cpuSample{}, fmt.Errorf("parse %s: %w", fields[0], err)
+			}
+
+			sample.total += v
+			if i == 3 || i == 4 {
+				sample.idle += v
+			}
+		}
+	}
+
+	return sample, scanner.Err()
+}
+
+func (c *Collector) readLoad() (Load, error) {
+	data, err := os.ReadFile(filepath.Join(c.procDir, "loadavg"))
+	if err != nil {
+		return Load{}, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return Load{}, fmt.Errorf("invalid loadavg %q", strings.TrimSpace(string(data)))
+	}
+
+	var load Load
+	values := []*float64{&load.Load1, &load.Load5, &load.Load15}
+	for i, v := range values {
+		if *v, err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return Load{}, err
+		}
+	}
+
+	return load, nil
+}
+
+func (c *Collector) readMemory() (Memory, error) {
+	f, err := os.Open(filepath.Join(c.procDir, "meminfo"))
+	if err != nil {
+		return Memory{}, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+
+		v, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		values[key] = v * 1024
+	}
+	if err := scanner.Err(); err != nil {
+		return Memory{}, err
+	}
+
+	memory := Memory{
+		Total:     values["MemTotal"],
+		Available: values["MemAvailable"],
+		Buffers:   values["Buffers"],
+		Cached:    values["Cached"],
+		SwapTotal: values["SwapTotal"],
+		SwapUsed:  values["SwapTotal"] - min(values["SwapFree"], values["SwapTotal"]),
+	}
+	memory.Used = memory.Total - min(memory.Available, memory.Total)
+
+	return memory, nil
+}
+
+var mountEscapes = strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+// readDisks returns the usage of the mounted block devices, i.e. the root
+// drive and any volumes.
+func (c *Collector) readDisks() ([]Disk, error) {
+	f, err := os.Open(filepath.Join(c.procDir, "self", "mounts"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var disks []Disk
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+
+		mountpoint := mountEscapes.Replace(fields[1])
+		if seen[mountpoint] {
+			continue
+		}
+		seen[mountpoint] = true
+
+		var stat unix.Statfs_t
+		if err := unix.Statfs(mountpoint, &stat); err != nil {
+			continue
+		}
+
+		bsize := uint64(stat.Bsize)
+		disks = append(disks, Disk{
+			Mountpoint: mountpoint,
+			Device:     fields[0],
+			Fstype:     fields[2],
+			Total:      stat.Blocks * bsize,
+			Used:       (stat.Blocks - stat.Bfree) * bsize,
+			Available:  stat.Bavail * bsize,
+		})
+	}
+
+	return disks, scanner.Err()
+}
+
+// readNetworks returns the counters of the network interfaces, except
+// loopback.
+func (c *Collector) readNetworks() ([]Network, error) {
+	f, err := os.Open(filepath.Join(c.procDir, "net", "dev"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var networks []Network
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		fields := strings.Fields(counters)
+		if name == "lo" || len(fields) < 16 {
+			continue
+		}
+
+		v := make([]uint64, 16)
+		for i := range v {
+			v[i], _ = strconv.ParseUint(fields[i], 10, 64)
+		}
+
+		networks = append(networks, Network{
+			Interface: name,
+			RxBytes:   v[0],
+			RxPackets: v[1],
+			RxErrors:  v[2],
+			RxDropped: v[3],
+			TxBytes:   v[8],
+			TxPackets: v[9],
+			TxErrors:  v[10],
+			TxDropped: v[11],
+		})
+	}
+
+	return networks, scanner.Err()
+}
+
+type processSample struct {
+	Process
+	ticks uint64
+}
+
+// readProcesses returns the processes by PID. Processes that exit while they
+// are read are skipped.
+func (c *Collector) readProcesses() map[int]processSample {
+	entries, err := os.ReadDir(c.procDir)
+	if err != nil {
+		return nil
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	processes := make(map[int]processSample)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(c.procDir, entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+
+		// pid (comm) state ppid ..., comm may contain spaces and parentheses
+		stat := string(data)
+		nameStart, nameEnd := strings.IndexByte(stat, '('), strings.LastIndexByte(stat, ')')
+		if nameStart < 0 || nameEnd < nameStart {
+			continue
+		}
+
+		fields := strings.Fields(stat[nameEnd+1:])
+		if len(fields) < 22 {
+			continue
+		}
+
+		utime, _ := strconv.ParseUint(fields[11], 10, 64)
+		stime, _ := strconv.ParseUint(fields[12], 10, 64)
+		rss, _ := strconv.ParseUint(fields[21], 10, 64)
+
+		sample := processSample{
+			Process: Process{PID: pid, Name: stat[nameStart+1 : nameEnd], RSS: rss * pageSize},
+			ticks:   utime + stime,
+		}
+
+		if cmdline, err := os.ReadFile(filepath.Join(c.procDir, entry.Name(), "cmdline")); err == nil {
+			command := strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " "))
+			if len(command) > maxCommandLength {
+				command = command[:maxCommandLength]
+			}
+
+			sample.Command = command
+		}
+
+		processes[pid] = sample
+	}
+
+	return processes
+}
@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProcDir creates a fake procfs with a single mount at mountpoint.
+func newTestProcDir(t *testing.T, mountpoint string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"stat":    "cpu  100 0 50 800 50 0 0 0 0 0\ncpu0 50 0 25 400 25 0 0 0 0 0\ncpu1 50 0 25 400 25 0 0 0 0 0\nintr 1\n",
+		"loadavg": "0.50 0.25 0.10 1/100 1234\n",
+		"meminfo": "MemTotal:        2048000 kB\nMemFree:          512000 kB\nMemAvailable:    1024000 kB\n" +
+			"Buffers:           10000 kB\nCached:           200000 kB\nSwapTotal:        100000 kB\nSwapFree:          40000 kB\n",
+		"self/mounts": fmt.Sprintf("/dev/vda %s ext4 rw,relatime 0 0\nproc /proc proc rw 0 0\n/dev/vda %s ext4 rw 0 0\n", mountpoint, mountpoint),
+		"net/dev": "Inter-|   Receive                                                |  Transmit\n" +
+			" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n" +
+			"    lo:    100       1    0    0    0     0          0         0      100       1    0    0    0     0       0          0\n" +
+			"  eth0: 123456     100    1    2    0     0          0         0    65432      50    3    4    0     0       0          0\n",
+		"42/stat":    "42 (Runner.Worker (x)) S 1 42 42 0 -1 4194560 100 0 0 0 30 10 0 0 20 0 10 0 100 1000000 256 18446744073709551615\n",
+		"42/cmdline": "/opt/runner/bin/Runner.Worker\x00spawnclient\x00",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestCollector_Collect(t *testing.T) {
+	mountpoint := t.TempDir()
+	c := New(WithProcDir(newTestProcDir(t, mountpoint)), WithSampleInterval(time.Millisecond))
+
+	stats, err := c.Collect(context.Background(), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, stats.CPU.Count)
+	assert.Equal(t, 1.5, stats.CPU.BusySeconds)
+	assert.Equal(t, Load{Load1: 0.5, Load5: 0.25, Load15: 0.1}, stats.Load)
+
+	assert.Equal(t, uint64(2048000*1024), stats.Memory.Total)
+	assert.Equal(t, uint64(1024000*1024), stats.Memory.Available)
+	assert.Equal(t, uint64(1024000*1024), stats.Memory.Used)
+	assert.Equal(t, uint64(60000*1024), stats.Memory.SwapUsed)
+
+	require.Len(t, stats.Disks, 1, "only block devices are reported, once")
+	assert.Equal(t, mountpoint, stats.Disks[0].Mountpoint)
+	assert.Equal(t, "ext4", stats.Disks[0].Fstype)
+	assert.NotZero(t, stats.Disks[0].Total)
+
+	require.Len(t, stats.Networks, 1, "loopback is skipped")
+	assert.Equal(t, Network{
+		Interface: "eth0", RxBytes: 123456, RxPackets: 100, RxErrors: 1, RxDropped: 2,
+		TxBytes: 65432, TxPackets: 50, TxErrors: 3, TxDropped: 4,
+	}, stats.Networks[0])
+
+	require.Len(t, stats.Processes, 1)
+	assert.Equal(t, 42, stats.Processes[0].PID)
+	assert.Equal(t, "Runner.Worker (x)", stats.Processes[0].Name)
+	assert.Equal(t, "/opt/runner/bin/Runner.Worker spawnclient", stats.Processes[0].Command)
+	assert.Equal(t, uint64(256*os.Getpagesize()), stats.Processes[0].RSS)
+
+	stats, err = c.Collect(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, stats.Processes)
+}
+
+func TestCollector_ReadCPU(t *testing.T) {
+	dir := newTestProcDir(t, t.TempDir())
+	c := New(WithProcDir(dir))
+
+	sample, err := c.readCPU()
+	require.NoError(t, err)
+	assert.Equal(t, cpuSample{count: 2, total: 1000, idle: 850}, sample)
+}
+
+func TestCollector_Live(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("procfs not available")
+	}
+
+	stats, err := New(WithSampleInterval(10*time.Millisecond)).Collect(context.Background(), 5)
+	require.NoError(t, err)
+
+	assert.Positive(t, stats.CPU.Count)
+	assert.Positive(t, stats.Memory.Total)
+	assert.NotEmpty(t, stats.Processes)
+	assert.LessOrEqual(t, len(stats.Processes), 5)
+}
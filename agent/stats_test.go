@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/stats"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetStats(t *testing.T) {
+	logger := zerolog.Nop()
+	client := newTestAgentClient(t, &Agent{logger: &logger, stats: stats.New(stats.WithSampleInterval(10 * time.Millisecond))})
+
+	resp, err := client.GetStats(context.Background(), &agentv1.GetStatsRequest{TopProcesses: 3})
+	require.NoError(t, err)
+
+	assert.Positive(t, resp.GetCpu().GetCount())
+	assert.Positive(t, resp.GetMemory().GetTotal())
+	assert.NotEmpty(t, resp.GetProcesses())
+	assert.LessOrEqual(t, len(resp.GetProcesses()), 3)
+	assert.WithinDuration(t, time.Now(), resp.GetTime().AsTime(), time.Minute)
+
+	_, err = client.GetStats(context.Background(), &agentv1.GetStatsRequest{TopProcesses: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,216 @@
+package tail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TailPattern starts tailing the files matching a glob pattern, in the order
+// of their names. This suits logs that rotate to timestamped names, like
+// Runner_20240101-120000-utc.log.
+//
+// The Location is applied to the last file only, earlier files are skipped.
+// Without a Location, all files are read from the beginning. In follow mode,
+// files created after the last one are read from the beginning once the
+// current one has been read to the end, and the tail waits for a first file
+// if none matches yet.
+func TailPattern(pattern string, config Config) (*Tail, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	if config.MaxLineSize == 0 {
+		config.MaxLineSize = DefaultMaxLineSize
+	}
+
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	t := &Tail{
+		Lines:   make(chan *Line),
+		pattern: pattern,
+		config:  config,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	t.wg.Add(1)
+	go t.runPattern()
+
+	return t, nil
+}
+
+// runPattern is the main loop of TailPattern.
+func (t *Tail) runPattern() {
+	defer t.wg.Done()
+	defer close(t.Lines)
+	defer t.cleanup()
+
+	files, err := filepath.Glob(t.pattern)
+	if err != nil {
+		t.setErr(err)
+		return
+	}
+
+	name := ""
+	if len(files) > 0 {
+		name = files[0]
+		if t.config.Location != nil {
+			name = files[len(files)-1]
+		}
+	}
+
+	seek := t.config.Location != nil
+	for {
+		if name == "" {
+			if !t.config.Follow || !t.sleep() {
+				return
+			}
+
+			if name, err = t.nextFile(""); err != nil {
+				t.setErr(err)
+				return
+			}
+
+			continue
+		}
+
+		t.filename = name
+		if err := t.openFile(); err != nil {
+			// Removed since it was matched, move on to the next one
+			if errors.Is(err, fs.ErrNotExist) {
+				if name, err = t.nextFile(name); err != nil {
+					t.setErr(err)
+					return
+				}
+
+				continue
+			}
+
+			t.setErr(err)
+			return
+		}
+
+		if seek {
+			seek = false
+			if err := t.seekToPosition(); err != nil {
+				t.setErr(err)
+				return
+			}
+		}
+
+		next, ok := t.readFile(name)
+		t.cleanup()
+		t.file = nil
+		if !ok {
+			return
+		}
+
+		name = next
+	}
+}
+
+// readFile sends the lines of the current file until it is read to the end
+// and a newer file exists, and returns the newer file. It returns false when
+// the tail is done.
+func (t *Tail) readFile(name string) (string, bool) {
+	reader := bufio.NewReader(t.file)
+
+	var partial strings.Builder
+	next := ""
+	for {
+		chunk, err := reader.ReadString('\n')
+		partial.WriteString(chunk)
+
+		if err == nil || partial.Len() >= t.config.MaxLineSize {
+			if !t.send(&Line{Text: strings.TrimSuffix(partial.String(), "\n")}) {
+				return "", false
+			}
+
+			partial.Reset()
+			continue
+		}
+
+		if err != io.EOF {
+			t.send(&Line{Err: err})
+			return "", false
+		}
+
+		// The newer file was found at the previous end of the file, which
+		// has now been read to the end again, including anything written in
+		// between.
+		if next != "" {
+			break
+		}
+
+		if next, err = t.nextFile(name); err != nil {
+			t.setErr(err)
+			return "", false
+		}
+
+		if next != "" {
+			continue
+		}
+
+		if !t.config.Follow {
+			break
+		}
+
+		if !t.sleep() {
+			return "", false
+		}
+	}
+
+	if partial.Len() > 0 && !t.send(&Line{Text: partial.String()}) {
+		return "", false
+	}
+
+	return next, next != ""
+}
+
+// nextFile returns the first file matching the pattern whose name sorts after
+// name, or an empty string if there is none.
+func (t *Tail) nextFile(name string) (string, error) {
+	files, err := filepath.Glob(t.pattern)
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		if file > name {
+			return file, nil
+		}
+	}
+
+	return "", nil
+}
+
+// send sends a line, returning false if the tail was stopped.
+func (t *Tail) send(line *Line) bool {
+	select {
+	case <-t.ctx.Done():
+		return false
+	case t.Lines <- line:
+		return true
+	}
+}
+
+// sleep waits for the poll interval, returning false if the tail was stopped.
+func (t *Tail) sleep() bool {
+	select {
+	case <-t.ctx.Done():
+		return false
+	case <-time.After(t.config.PollInterval):
+		return true
+	}
+}
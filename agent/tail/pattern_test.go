@@ -0,0 +1,163 @@
+package tail
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readLines reads n lines from the tail, failing after a timeout.
+func readLines(t *testing.T, tail *Tail, n int) []string {
+	t.Helper()
+
+	var lines []string
+	for len(lines) < n {
+		select {
+		case line, ok := <-tail.Lines:
+			if !ok {
+				t.Fatalf("Channel closed after %d lines, expected %d", len(lines), n)
+			}
+			if line.Err != nil {
+				t.Fatalf("Line error: %v", line.Err)
+			}
+			lines = append(lines, line.Text)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timeout after %d lines, expected %d", len(lines), n)
+		}
+	}
+
+	return lines
+}
+
+func assertLines(t *testing.T, got []string, expected ...string) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("Line %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestTailPattern_NonFollow(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Runner_20240101-100000-utc.log": "old1\nold2\n",
+		"Runner_20240101-110000-utc.log": "new1\nnew2\nnew3",
+		"Worker_20240101-100000-utc.log": "worker\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tail, err := TailPattern(filepath.Join(tmpDir, "Runner_*.log"), Config{})
+	if err != nil {
+		t.Fatalf("TailPattern failed: %v", err)
+	}
+	defer tail.Cleanup()
+
+	var lines []string
+	for line := range tail.Lines {
+		if line.Err != nil {
+			t.Fatalf("Line error: %v", line.Err)
+		}
+		lines = append(lines, line.Text)
+	}
+	assertLines(t, lines, "old1", "old2", "new1", "new2", "new3")
+
+	// The location only applies to the newest file
+	tail, err = TailPattern(filepath.Join(tmpDir, "Runner_*.log"), Config{
+		Location: &SeekInfo{Offset: -2, Whence: io.SeekEnd},
+	})
+	if err != nil {
+		t.Fatalf("TailPattern failed: %v", err)
+	}
+	defer tail.Cleanup()
+
+	lines = nil
+	for line := range tail.Lines {
+		lines = append(lines, line.Text)
+	}
+	assertLines(t, lines, "new2", "new3")
+}
+
+func TestTailPattern_NoMatch(t *testing.T) {
+	tail, err := TailPattern(filepath.Join(t.TempDir(), "Runner_*.log"), Config{})
+	if err != nil {
+		t.Fatalf("TailPattern failed: %v", err)
+	}
+	defer tail.Cleanup()
+
+	if _, ok := <-tail.Lines; ok {
+		t.Error("Expected channel to be closed")
+	}
+
+	if _, err := TailPattern("[", Config{}); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
+
+func TestTailPattern_FollowNewFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	pattern := filepath.Join(tmpDir, "Worker_*.log")
+
+	tail, err := TailPattern(pattern, Config{Follow: true, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("TailPattern failed: %v", err)
+	}
+	defer tail.Cleanup()
+
+	// Waits for the first file
+	first, err := os.Create(filepath.Join(tmpDir, "Worker_20240101-100000-utc.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	if _, err := first.WriteString("job1 start\njob1 par"); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, readLines(t, tail, 1), "job1 start")
+
+	// Partial lines are completed before they are sent
+	if _, err := first.WriteString("tial\n"); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, readLines(t, tail, 1), "job1 partial")
+
+	// Lines written to the old file before the switch are not lost
+	if _, err := first.WriteString("job1 end\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "Worker_20240101-110000-utc.log"), []byte("job2 start\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, readLines(t, tail, 2), "job1 end", "job2 start")
+
+	// Unrelated files are ignored
+	if err := os.WriteFile(filepath.Join(tmpDir, "Runner_20240101-120000-utc.log"), []byte("runner\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "Worker_20240101-120000-utc.log"), []byte("job3 start\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, readLines(t, tail, 1), "job3 start")
+
+	tail.Stop()
+	select {
+	case _, ok := <-tail.Lines:
+		if ok {
+			t.Error("Expected channel to be closed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timeout waiting for channel to close")
+	}
+}
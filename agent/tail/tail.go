@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
@@ -15,6 +16,8 @@ import (
 const (
 	// DefaultMaxLineSize is the default maximum size for a single line.
 	DefaultMaxLineSize = 1024 * 1024 // 1MB
+	// DefaultPollInterval is the default interval TailPattern polls at.
+	DefaultPollInterval = 250 * time.Millisecond
 )
 
 // Config specifies how a file should be tailed.
@@ -29,6 +32,9 @@ type Config struct {
 	MaxLineSize int
 	// ReOpen reopens the file if it's rotated or recreated.
 	ReOpen bool
+	// PollInterval is how often TailPattern checks for new data and new
+	// files. If 0, uses DefaultPollInterval.
+	PollInterval time.Duration
 }
 
 // SeekInfo specifies where to seek in the file.
@@ -47,6 +53,7 @@ type Line struct {
 type Tail struct {
 	Lines    chan *Line
 	filename string
+	pattern  string // Set by TailPattern
 	config   Config
 	ctx      context.Context
 	cancel   context.CancelFunc
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
+	"time"
 
 	"github.com/hostinger/fireactions/agent"
+	"github.com/hostinger/fireactions/agent/hooks"
 	"github.com/hostinger/fireactions/agent/mmds"
 	"github.com/spf13/cobra"
 )
@@ -22,11 +25,13 @@ func newAgentCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringP("log-level", "l", "info", "Log level (debug, info, warn, error, fatal, panic, trace)")
+	cmd.Flags().String("log-format", "console", "Log format (console, json), overrides the log_format of the pool")
 	return cmd
 }
 
 func runAgentCmd(cmd *cobra.Command, _ []string) error {
 	logLevel, _ := cmd.Flags().GetString("log-level")
+	logFormat, _ := cmd.Flags().GetString("log-format")
 
 	mmdsClient := mmds.NewClient()
 	metadata, err := mmdsClient.GetMetadata(context.Background(), "fireactions")
@@ -34,8 +39,10 @@ func runAgentCmd(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("getting metadata: %w", err)
 	}
 
+	// VMs restored between jobs get their JIT config after the snapshot
+	awaitRunner, _ := metadata["restore"].(bool)
 	runnerJITConfig, ok := metadata["runner_jit_config"].(string)
-	if !ok {
+	if !ok && !awaitRunner {
 		return fmt.Errorf("runner_jit_config not found in metadata")
 	}
 
@@ -45,16 +52,57 @@ func runAgentCmd(cmd *cobra.Command, _ []string) error {
 	}
 
 	shutdownOnExit, _ := metadata["shutdown_on_exit"].(bool)
+	mmdsRootOnly, _ := metadata["mmds_root_only"].(bool)
+	keepOnFailure, _ := metadata["keep_on_failure"].(bool)
+	heartbeatInterval, _ := metadata["heartbeat_interval"].(float64)
+
+	secrets, err := parseSecrets(metadata["secrets"])
+	if err != nil {
+		return fmt.Errorf("parsing secrets: %w", err)
+	}
+
+	hookScripts, err := parseHooks(metadata["hooks"])
+	if err != nil {
+		return fmt.Errorf("parsing hooks: %w", err)
+	}
+
+	runnerConfig, err := parseRunnerConfig(metadata["runner"])
+	if err != nil {
+		return fmt.Errorf("parsing runner: %w", err)
+	}
+
+	multiJob, err := parseMultiJob(metadata["multi_job"])
+	if err != nil {
+		return fmt.Errorf("parsing multi_job: %w", err)
+	}
+
+	poolLogFormat, err := parseLogFormat(metadata["agent"])
+	if err != nil {
+		return fmt.Errorf("parsing agent: %w", err)
+	}
+
+	if poolLogFormat != "" && !cmd.Flags().Changed("log-format") {
+		logFormat = poolLogFormat
+	}
 
 	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	agentServer, err := agent.New(agent.Config{
-		Port:            9001,
-		RunnerJITConfig: runnerJITConfig,
-		Hostname:        hostname,
-		LogLevel:        logLevel,
-		ShutdownOnExit:  shutdownOnExit,
+		Port:              9001,
+		RunnerJITConfig:   runnerJITConfig,
+		Hostname:          hostname,
+		LogLevel:          logLevel,
+		LogFormat:         logFormat,
+		ShutdownOnExit:    shutdownOnExit,
+		MMDSRootOnly:      mmdsRootOnly,
+		Secrets:           secrets,
+		Hooks:             hookScripts,
+		HeartbeatInterval: time.Duration(heartbeatInterval * float64(time.Second)),
+		Runner:            runnerConfig,
+		MultiJob:          multiJob,
+		AwaitRunner:       awaitRunner,
+		KeepOnFailure:     keepOnFailure,
 	})
 	if err != nil {
 		return fmt.Errorf("create agent: %w", err)
@@ -62,3 +110,170 @@ func runAgentCmd(cmd *cobra.Command, _ []string) error {
 
 	return agentServer.Run(ctx)
 }
+
+// parseSecrets parses the secrets from the MMDS metadata.
+func parseSecrets(v interface{}) ([]agent.Secret, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("secrets is not a list")
+	}
+
+	secrets := make([]agent.Secret, 0, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("secret %d is not an object", i)
+		}
+
+		name, _ := m["name"].(string)
+		value, _ := m["value"].(string)
+		target, _ := m["target"].(string)
+		secrets = append(secrets, agent.Secret{Name: name, Value: value, Target: target})
+	}
+
+	return secrets, nil
+}
+
+// parseHooks parses the hook scripts from the MMDS metadata.
+func parseHooks(v interface{}) (map[hooks.Name]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("hooks is not an object")
+	}
+
+	scripts := make(map[hooks.Name]string, len(m))
+	for name, script := range m {
+		s, ok := script.(string)
+		if !ok {
+			return nil, fmt.Errorf("hook %s is not a string", name)
+		}
+
+		scripts[hooks.Name(name)] = s
+	}
+
+	return scripts, nil
+}
+
+// parseRunnerConfig parses the runner configuration from the MMDS metadata.
+func parseRunnerConfig(v interface{}) (agent.RunnerConfig, error) {
+	var cfg agent.RunnerConfig
+	if v == nil {
+		return cfg, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return cfg, fmt.Errorf("runner is not an object")
+	}
+
+	cfg.Dir, _ = m["dir"].(string)
+	cfg.User, _ = m["user"].(string)
+	cfg.Group, _ = m["group"].(string)
+
+	if env, ok := m["env"]; ok {
+		envMap, ok := env.(map[string]interface{})
+		if !ok {
+			return cfg, fmt.Errorf("env is not an object")
+		}
+
+		for key, value := range envMap {
+			s, ok := value.(string)
+			if !ok {
+				return cfg, fmt.Errorf("env %s is not a string", key)
+			}
+
+			cfg.Env = append(cfg.Env, fmt.Sprintf("%s=%s", key, s))
+		}
+
+		sort.Strings(cfg.Env)
+	}
+
+	if args, ok := m["args"]; ok {
+		list, ok := args.([]interface{})
+		if !ok {
+			return cfg, fmt.Errorf("args is not a list")
+		}
+
+		for i, arg := range list {
+			s, ok := arg.(string)
+			if !ok {
+				return cfg, fmt.Errorf("arg %d is not a string", i)
+			}
+
+			cfg.Args = append(cfg.Args, s)
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseLogFormat parses the log format of the agent from the MMDS metadata.
+// It returns an empty string if the pool does not set one.
+func parseLogFormat(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("agent is not an object")
+	}
+
+	format, ok := m["log_format"]
+	if !ok {
+		return "", nil
+	}
+
+	s, ok := format.(string)
+	if !ok {
+		return "", fmt.Errorf("log_format is not a string")
+	}
+
+	return s, nil
+}
+
+// parseMultiJob parses the multi-job mode settings from the MMDS metadata. It
+// returns nil if the mode is disabled.
+func parseMultiJob(v interface{}) (*agent.MultiJobConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("multi_job is not an object")
+	}
+
+	maxJobs, _ := m["max_jobs"].(float64)
+	maxLifetime, _ := m["max_lifetime"].(float64)
+	cfg := &agent.MultiJobConfig{
+		MaxJobs:     int(maxJobs),
+		MaxLifetime: time.Duration(maxLifetime * float64(time.Second)),
+	}
+
+	if paths, ok := m["cleanup_paths"]; ok {
+		list, ok := paths.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cleanup_paths is not a list")
+		}
+
+		for i, path := range list {
+			s, ok := path.(string)
+			if !ok {
+				return nil, fmt.Errorf("cleanup path %d is not a string", i)
+			}
+
+			cfg.CleanupPaths = append(cfg.CleanupPaths, s)
+		}
+	}
+
+	return cfg, nil
+}
@@ -1 +1,117 @@
 package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/agent"
+	"github.com/hostinger/fireactions/agent/hooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecrets(t *testing.T) {
+	secrets, err := parseSecrets([]interface{}{
+		map[string]interface{}{"name": "CACHE_KEY", "value": "s3cret", "target": "env"},
+		map[string]interface{}{"name": "mirror-token", "value": "token", "target": "file"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []agent.Secret{
+		{Name: "CACHE_KEY", Value: "s3cret", Target: "env"},
+		{Name: "mirror-token", Value: "token", Target: "file"},
+	}, secrets)
+
+	secrets, err = parseSecrets(nil)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+
+	_, err = parseSecrets("invalid")
+	assert.Error(t, err)
+
+	_, err = parseSecrets([]interface{}{"invalid"})
+	assert.Error(t, err)
+}
+
+func TestParseHooks(t *testing.T) {
+	scripts, err := parseHooks(map[string]interface{}{"pre_start": "echo hello", "post_exit": "echo bye"})
+	require.NoError(t, err)
+	assert.Equal(t, map[hooks.Name]string{hooks.PreStart: "echo hello", hooks.PostExit: "echo bye"}, scripts)
+
+	scripts, err = parseHooks(nil)
+	require.NoError(t, err)
+	assert.Empty(t, scripts)
+
+	_, err = parseHooks(map[string]interface{}{"pre_start": 1})
+	assert.Error(t, err)
+}
+
+func TestParseRunnerConfig(t *testing.T) {
+	cfg, err := parseRunnerConfig(map[string]interface{}{
+		"dir":   "/home/runner/actions-runner",
+		"user":  "builder",
+		"group": "builder",
+		"env":   map[string]interface{}{"RUNNER_TOOL_CACHE": "/cache", "DOCKER_HOST": "unix:///run/docker.sock"},
+		"args":  []interface{}{"--once"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, agent.RunnerConfig{
+		Dir:   "/home/runner/actions-runner",
+		User:  "builder",
+		Group: "builder",
+		Env:   []string{"DOCKER_HOST=unix:///run/docker.sock", "RUNNER_TOOL_CACHE=/cache"},
+		Args:  []string{"--once"},
+	}, cfg)
+
+	cfg, err = parseRunnerConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, agent.RunnerConfig{}, cfg)
+
+	_, err = parseRunnerConfig("invalid")
+	assert.Error(t, err)
+
+	_, err = parseRunnerConfig(map[string]interface{}{"env": map[string]interface{}{"RUNNER_TOOL_CACHE": 1}})
+	assert.Error(t, err)
+
+	_, err = parseRunnerConfig(map[string]interface{}{"args": "--once"})
+	assert.Error(t, err)
+}
+
+func TestParseMultiJob(t *testing.T) {
+	cfg, err := parseMultiJob(map[string]interface{}{
+		"max_jobs":      float64(10),
+		"max_lifetime":  float64(3600),
+		"cleanup_paths": []interface{}{"/home/runner/.docker"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &agent.MultiJobConfig{MaxJobs: 10, MaxLifetime: time.Hour, CleanupPaths: []string{"/home/runner/.docker"}}, cfg)
+
+	cfg, err = parseMultiJob(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	_, err = parseMultiJob("invalid")
+	assert.Error(t, err)
+
+	_, err = parseMultiJob(map[string]interface{}{"max_jobs": float64(10), "cleanup_paths": "/tmp"})
+	assert.Error(t, err)
+}
+
+func TestParseLogFormat(t *testing.T) {
+	format, err := parseLogFormat(map[string]interface{}{"log_format": "json"})
+	require.NoError(t, err)
+	assert.Equal(t, "json", format)
+
+	format, err = parseLogFormat(nil)
+	require.NoError(t, err)
+	assert.Empty(t, format)
+
+	format, err = parseLogFormat(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, format)
+
+	_, err = parseLogFormat("invalid")
+	assert.Error(t, err)
+
+	_, err = parseLogFormat(map[string]interface{}{"log_format": 1})
+	assert.Error(t, err)
+}
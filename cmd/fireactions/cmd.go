@@ -54,10 +54,14 @@ func NewRootCommand() *cobra.Command {
 	cmd.AddCommand(newPsCmd())
 	cmd.AddCommand(newLoginCmd())
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newExecCmd())
+	cmd.AddCommand(newCpCmd())
+	cmd.AddCommand(newStatsCmd())
 
 	cmd.AddGroup(&cobra.Group{ID: "image", Title: "Image management commands:"})
 	cmd.AddCommand(newImageCmd())
 
+	cmd.AddCommand(newLogLevelCmd())
 	cmd.AddCommand(newVersionCmd())
 
 	return cmd
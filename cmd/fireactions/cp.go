@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hostinger/fireactions/helper/archive"
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	copyChunkSize = 64 * 1024
+)
+
+func newCpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cp SRC DST",
+		Short: "Copy files and directories between a machine and the local filesystem",
+		Long: `Copy files and directories between a machine and the local filesystem.
+
+One of SRC and DST must be a path inside a machine in the form
+MACHINE_ID:/path, which must be absolute. Directories are copied
+recursively. If DST is an existing directory, SRC is copied into it,
+otherwise SRC is copied as DST. Use - as the local path to write a tar
+archive to stdout or read one from stdin.
+
+Files copied into a machine are owned by root.`,
+		Example: `  fireactions cp runner-abc123:/opt/runner/_diag ./diag
+  fireactions cp runner-abc123:/var/crash/core.1234 .
+  fireactions cp ./patched.sh runner-abc123:/usr/local/bin/script.sh`,
+		Args:    cobra.ExactArgs(2),
+		GroupID: "machine",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCpCmd(cmd, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
+
+	return cmd
+}
+
+// parseCopyPath splits a cp argument into a machine ID and a path. The
+// machine ID is empty for local paths.
+func parseCopyPath(arg string) (string, string) {
+	if strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+
+	machine, p, ok := strings.Cut(arg, ":")
+	if !ok || machine == "" || strings.Contains(machine, "/") {
+		return "", arg
+	}
+
+	return machine, p
+}
+
+func runCpCmd(cmd *cobra.Command, src, dst string) error {
+	srcMachine, srcPath := parseCopyPath(src)
+	dstMachine, dstPath := parseCopyPath(dst)
+
+	if (srcMachine == "") == (dstMachine == "") {
+		return fmt.Errorf("exactly one of SRC and DST must be a path inside a machine (MACHINE_ID:/path)")
+	}
+
+	remotePath := srcPath
+	if dstMachine != "" {
+		remotePath = dstPath
+	}
+
+	if !path.IsAbs(remotePath) {
+		return fmt.Errorf("path inside the machine must be absolute: %q", remotePath)
+	}
+
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	if srcMachine != "" {
+		return copyFromMachine(cmd, client, srcMachine, srcPath, dstPath)
+	}
+
+	return copyToMachine(cmd, client, dstMachine, srcPath, dstPath)
+}
+
+func copyFromMachine(cmd *cobra.Command, client serverv1.ServerServiceClient, vmid, src, dst string) error {
+	stream, err := client.CopyFromMachine(cmd.Context(), &serverv1.CopyFromMachineRequest{ID: vmid, Path: src})
+	if err != nil {
+		return fmt.Errorf("failed to start copy: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				_ = pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+
+			if _, err := pw.Write(resp.GetData()); err != nil {
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+
+	if dst == "-" {
+		_, err = io.Copy(cmd.OutOrStdout(), pr)
+	} else {
+		err = archive.Untar(pr, dst)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy %s:%s: %w", vmid, src, err)
+	}
+
+	return nil
+}
+
+func copyToMachine(cmd *cobra.Command, client serverv1.ServerServiceClient, vmid, src, dst string) error {
+	var archiveReader io.Reader
+	if src == "-" {
+		archiveReader = cmd.InOrStdin()
+	} else {
+		if _, err := os.Lstat(src); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, err)
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(archive.Tar(pw, src))
+		}()
+		defer pr.Close()
+
+		archiveReader = pr
+	}
+
+	stream, err := client.CopyToMachine(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to start copy: %w", err)
+	}
+
+	req := &serverv1.CopyToMachineRequest{ID: vmid, Path: dst}
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, readErr := archiveReader.Read(buf)
+		if n > 0 || req.ID != "" {
+			req.Data = append([]byte(nil), buf[:n]...)
+
+			// A failed send means the server closed the stream, its error
+			// is returned by CloseAndRecv.
+			if err := stream.Send(req); err != nil {
+				break
+			}
+
+			req = &serverv1.CopyToMachineRequest{}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, readErr)
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("failed to copy to %s:%s: %w", vmid, dst, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCopyPath(t *testing.T) {
+	tests := []struct {
+		arg         string
+		wantMachine string
+		wantPath    string
+	}{
+		{"runner-abc123:/opt/runner/_diag", "runner-abc123", "/opt/runner/_diag"},
+		{"runner-abc123:relative", "runner-abc123", "relative"},
+		{"./local", "", "./local"},
+		{"/tmp/a:b", "", "/tmp/a:b"},
+		{"dir/file:name", "", "dir/file:name"},
+		{"local", "", "local"},
+		{"-", "", "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			machine, path := parseCopyPath(tt.arg)
+			assert.Equal(t, tt.wantMachine, machine)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestCpCmd_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"both local", []string{"./a", "./b"}, "exactly one of SRC and DST"},
+		{"both remote", []string{"vm1:/a", "vm2:/b"}, "exactly one of SRC and DST"},
+		{"relative remote", []string{"vm1:a", "./b"}, "must be absolute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCpCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
+)
+
+// exitCodeError is returned by commands that exit with the exit code of a
+// remote command.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.code)
+}
+
+// execOptions are the options of a command run inside a machine.
+type execOptions struct {
+	ID          string
+	Command     []string // Empty runs the login shell of the user
+	Env         []string
+	WorkingDir  string
+	User        string
+	RunnerUser  bool // Run as the runner user of the pool instead of User
+	Interactive bool
+	TTY         bool
+}
+
+func newExecCmd() *cobra.Command {
+	var opts execOptions
+
+	cmd := &cobra.Command{
+		Use:   "exec MACHINE_ID -- COMMAND [ARG...]",
+		Short: "Run a command inside a machine",
+		Long: `Run a command inside a machine through the fireactions-agent, without SSH.
+
+The command runs as root unless --user is set. Its stdout and stderr are
+streamed back and the command exits with the exit code of the remote command.
+Signals received by the command are forwarded to the remote command.`,
+		Example: `  fireactions exec runner-abc123 -- ps aux
+  cat script.sh | fireactions exec -i runner-abc123 -- sh
+  fireactions exec -it -u runner runner-abc123 -- bash`,
+		Args:    cobra.MinimumNArgs(2),
+		GroupID: "machine",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.ID = args[0]
+			opts.Command = args[1:]
+			return runExecCmd(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "Forward stdin to the command")
+	cmd.Flags().BoolVarP(&opts.TTY, "tty", "t", false, "Run the command in a pseudo-terminal")
+	cmd.Flags().StringVarP(&opts.User, "user", "u", "", "User to run the command as (default: root)")
+	cmd.Flags().StringArrayVar(&opts.Env, "env", nil, "Set environment variables in the form KEY=value")
+	cmd.Flags().StringVarP(&opts.WorkingDir, "workdir", "w", "", "Working directory of the command")
+	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
+
+	return cmd
+}
+
+func runExecCmd(cmd *cobra.Command, opts execOptions) error {
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	stdin := cmd.InOrStdin()
+	terminalFd := -1
+	if f, ok := stdin.(*os.File); ok && opts.TTY && isTerminal(int(f.Fd())) {
+		terminalFd = int(f.Fd())
+	}
+
+	start := &serverv1.ExecMachineStart{
+		ID:         opts.ID,
+		Command:    opts.Command,
+		Env:        opts.Env,
+		WorkingDir: opts.WorkingDir,
+		User:       opts.User,
+		RunnerUser: opts.RunnerUser,
+		Tty:        opts.TTY,
+	}
+
+	if opts.TTY {
+		if term := os.Getenv("TERM"); term != "" {
+			start.Env = append([]string{"TERM=" + term}, start.Env...)
+		}
+	}
+
+	if terminalFd >= 0 {
+		if rows, cols, err := terminalSize(terminalFd); err == nil {
+			start.TerminalSize = &serverv1.TerminalSize{Rows: rows, Cols: cols}
+		}
+	}
+
+	grpcStream, err := client.ExecMachine(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to start exec stream: %w", err)
+	}
+
+	stream := &execStream{stream: grpcStream}
+	if err := stream.send(&serverv1.ExecMachineRequest{Request: &serverv1.ExecMachineRequest_Start{Start: start}}); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	if terminalFd >= 0 {
+		restore, err := makeRaw(terminalFd)
+		if err != nil {
+			return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer restore()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go stream.forwardSignals(done, terminalFd)
+
+	if opts.Interactive || opts.TTY {
+		go stream.forwardStdin(stdin)
+	} else if err := stream.send(&serverv1.ExecMachineRequest{Request: &serverv1.ExecMachineRequest_CloseStdin{CloseStdin: true}}); err != nil {
+		return fmt.Errorf("failed to close stdin: %w", err)
+	}
+
+	for {
+		resp, err := grpcStream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("stream closed before the command exited")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive output: %w", err)
+		}
+
+		switch r := resp.Response.(type) {
+		case *serverv1.ExecMachineResponse_Stdout:
+			if _, err := cmd.OutOrStdout().Write(r.Stdout); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		case *serverv1.ExecMachineResponse_Stderr:
+			if _, err := cmd.ErrOrStderr().Write(r.Stderr); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		case *serverv1.ExecMachineResponse_ExitCode:
+			if r.ExitCode != 0 {
+				return &exitCodeError{code: int(r.ExitCode)}
+			}
+			return nil
+		}
+	}
+}
+
+// execStream serializes the requests of an exec stream, as stdin, signals
+// and terminal resizes are sent concurrently.
+type execStream struct {
+	mu     sync.Mutex
+	stream serverv1.ServerService_ExecMachineClient
+}
+
+func (s *execStream) send(req *serverv1.ExecMachineRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stream.Send(req)
+}
+
+// forwardStdin sends stdin to the command and closes its stdin on EOF.
+func (s *execStream) forwardStdin(stdin io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			sendErr := s.send(&serverv1.ExecMachineRequest{Request: &serverv1.ExecMachineRequest_Stdin{Stdin: append([]byte(nil), buf[:n]...)}})
+			if sendErr != nil {
+				return
+			}
+		}
+
+		if err != nil {
+			_ = s.send(&serverv1.ExecMachineRequest{Request: &serverv1.ExecMachineRequest_CloseStdin{CloseStdin: true}})
+			return
+		}
+	}
+}
+
+// forwardSignals forwards the signals received by the process to the command
+// and resizes its terminal along with the local one, until done is closed.
+func (s *execStream) forwardSignals(done <-chan struct{}, terminalFd int) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT, syscall.SIGWINCH)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-done:
+			return
+		case sig := <-signals:
+			req := &serverv1.ExecMachineRequest{}
+			if sig == syscall.SIGWINCH {
+				if terminalFd < 0 {
+					continue
+				}
+
+				rows, cols, err := terminalSize(terminalFd)
+				if err != nil {
+					continue
+				}
+
+				req.Request = &serverv1.ExecMachineRequest_Resize{Resize: &serverv1.TerminalSize{Rows: rows, Cols: cols}}
+			} else {
+				req.Request = &serverv1.ExecMachineRequest_Signal{Signal: unix.SignalName(sig.(syscall.Signal))}
+			}
+
+			if err := s.send(req); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// exitCode returns the exit code of the process for an error returned by a
+// command.
+func exitCode(err error) (int, bool) {
+	var exitErr *exitCodeError
+	if !errors.As(err, &exitErr) {
+		return 0, false
+	}
+
+	if exitErr.code < 0 || exitErr.code > 255 {
+		return 1, true
+	}
+
+	return exitErr.code, true
+}
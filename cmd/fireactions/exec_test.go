@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecCmd_Structure(t *testing.T) {
+	cmd := newExecCmd()
+
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "exec MACHINE_ID -- COMMAND [ARG...]", cmd.Use)
+	assert.NotNil(t, cmd.RunE)
+	assert.NotNil(t, cmd.Flags().Lookup("interactive"))
+	assert.NotNil(t, cmd.Flags().Lookup("env"))
+	assert.NotNil(t, cmd.Flags().Lookup("workdir"))
+}
+
+func TestExecCmd_RequiresCommand(t *testing.T) {
+	cmd := newExecCmd()
+	cmd.SetArgs([]string{"runner-abc123"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "requires at least 2 arg(s)")
+}
+
+func TestExitCode(t *testing.T) {
+	code, ok := exitCode(fmt.Errorf("exec: %w", &exitCodeError{code: 3}))
+	assert.True(t, ok)
+	assert.Equal(t, 3, code)
+
+	code, ok = exitCode(&exitCodeError{code: -1})
+	assert.True(t, ok)
+	assert.Equal(t, 1, code)
+
+	_, ok = exitCode(fmt.Errorf("connection refused"))
+	assert.False(t, ok)
+}
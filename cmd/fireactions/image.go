@@ -2,6 +2,10 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/go-units"
 
 	"github.com/hostinger/fireactions/helper/printer"
 	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
@@ -13,7 +17,7 @@ func newImageCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "image",
 		Short:   "Manage images",
-		Long:    "Manage container images - list and remove images.",
+		Long:    "Manage container images - list, pull, inspect and remove rootfs and kernel images.",
 		GroupID: "image",
 	}
 
@@ -21,6 +25,8 @@ func newImageCmd() *cobra.Command {
 
 	cmd.AddGroup(&cobra.Group{ID: "image", Title: "Image management commands:"})
 	cmd.AddCommand(newImageListCmd())
+	cmd.AddCommand(newImagePullCmd())
+	cmd.AddCommand(newImageInspectCmd())
 	cmd.AddCommand(newImageRemoveCmd())
 
 	return cmd
@@ -55,6 +61,146 @@ func runImageListCmd(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+func newImagePullCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull NAME",
+		Short: "Pull an image",
+		Long:  "Pull an image into the server's containerd namespace, showing per-layer progress. Useful for pre-warming new hosts.",
+		RunE:  runImagePullCmd,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().Bool("kernel", false, "Pull the image as a kernel image and extract vmlinux from it")
+	cmd.Flags().String("pool", "", "Pull with the registry credentials and mirrors of the pool instead of the global ones")
+	return cmd
+}
+
+func runImagePullCmd(cmd *cobra.Command, args []string) error {
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	imageType := "rootfs"
+	if kernel, _ := cmd.Flags().GetBool("kernel"); kernel {
+		imageType = "kernel"
+	}
+
+	pool, _ := cmd.Flags().GetString("pool")
+	stream, err := client.PullImage(cmd.Context(), &serverv1.PullImageRequest{Name: args[0], Type: imageType, Pool: pool})
+	if err != nil {
+		return fmt.Errorf("pull image \"%s\": %w", args[0], err)
+	}
+
+	out := cmd.OutOrStdout()
+	lastStatus := serverv1.PullImageStatus(-1)
+	lastLayerStatus := make(map[string]serverv1.LayerStatus)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("pull image \"%s\": %w", args[0], err)
+		}
+
+		if resp.Status == serverv1.PullImageStatus_PULL_IMAGE_STATUS_DONE {
+			_, _ = fmt.Fprintf(out, "Image \"%s\" pulled (%s)\n", args[0], resp.Digest)
+			continue
+		}
+
+		for _, layer := range resp.Layers {
+			if status, ok := lastLayerStatus[layer.Digest]; ok && status == layer.Status {
+				continue
+			}
+			lastLayerStatus[layer.Digest] = layer.Status
+
+			_, _ = fmt.Fprintf(out, "%s: %s %s/%s\n", shortDigest(layer.Digest), formatLayerStatus(layer.Status),
+				units.HumanSize(float64(layer.Offset)), units.HumanSize(float64(layer.Total)))
+		}
+
+		if resp.Status != lastStatus {
+			lastStatus = resp.Status
+			if resp.Status == serverv1.PullImageStatus_PULL_IMAGE_STATUS_UNPACKING {
+				_, _ = fmt.Fprintln(out, "Unpacking...")
+			}
+		}
+	}
+}
+
+func formatLayerStatus(status serverv1.LayerStatus) string {
+	switch status {
+	case serverv1.LayerStatus_LAYER_STATUS_DOWNLOADING:
+		return "Downloading"
+	case serverv1.LayerStatus_LAYER_STATUS_DONE:
+		return "Done"
+	default:
+		return "Waiting"
+	}
+}
+
+// shortDigest returns the first 12 characters of the digest's hex part.
+func shortDigest(digest string) string {
+	if i := strings.Index(digest, ":"); i >= 0 {
+		digest = digest[i+1:]
+	}
+
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+
+	return digest
+}
+
+func newImageInspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect NAME",
+		Short: "Show details of an image",
+		RunE:  runImageInspectCmd,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	return cmd
+}
+
+func runImageInspectCmd(cmd *cobra.Command, args []string) error {
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	resp, err := client.InspectImage(cmd.Context(), &serverv1.InspectImageRequest{Name: args[0]})
+	if err != nil {
+		return fmt.Errorf("inspect image \"%s\": %w", args[0], err)
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Name:       %s\n", resp.Image.GetName())
+	_, _ = fmt.Fprintf(out, "Type:       %s\n", resp.Image.GetType())
+	_, _ = fmt.Fprintf(out, "Digest:     %s\n", resp.Digest)
+	_, _ = fmt.Fprintf(out, "Platforms:  %s\n", joinOrNone(resp.Platforms))
+	_, _ = fmt.Fprintf(out, "Size:       %s\n", units.HumanSize(float64(resp.Image.GetSize())))
+	_, _ = fmt.Fprintf(out, "Unpacked:   %t\n", resp.Unpacked)
+	_, _ = fmt.Fprintf(out, "Pools:      %s\n", joinOrNone(resp.Pools))
+	_, _ = fmt.Fprintf(out, "Machines:   %s\n", joinOrNone(resp.Machines))
+	_, _ = fmt.Fprintf(out, "Layers:\n")
+	printer.PrintText(&printableImageLayer{resp.Layers}, out, nil)
+
+	return nil
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+
+	return strings.Join(values, ", ")
+}
+
 func newImageRemoveCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "remove NAME",
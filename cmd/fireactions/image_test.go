@@ -21,6 +21,8 @@ func TestImageCommand_Structure(t *testing.T) {
 	}
 
 	assert.Contains(t, subcommandNames, "list")
+	assert.Contains(t, subcommandNames, "pull")
+	assert.Contains(t, subcommandNames, "inspect")
 	assert.Contains(t, subcommandNames, "remove")
 }
 
@@ -39,3 +41,23 @@ func TestImageRemoveCommand_Structure(t *testing.T) {
 	assert.NotNil(t, cmd.RunE)
 	assert.Contains(t, cmd.Aliases, "rm")
 }
+
+func TestImagePullCommand_Structure(t *testing.T) {
+	cmd := newImagePullCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "pull NAME", cmd.Use)
+	assert.NotNil(t, cmd.RunE)
+	assert.NotNil(t, cmd.Flags().Lookup("kernel"))
+}
+
+func TestImageInspectCommand_Structure(t *testing.T) {
+	cmd := newImageInspectCmd()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "inspect NAME", cmd.Use)
+	assert.NotNil(t, cmd.RunE)
+}
+
+func TestShortDigest(t *testing.T) {
+	assert.Equal(t, "0123456789ab", shortDigest("sha256:0123456789abcdef"))
+	assert.Equal(t, "abc", shortDigest("sha256:abc"))
+}
@@ -11,25 +11,49 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// newLoginCmd returns a command to SSH into a selected VM
+// newLoginCmd returns a command to open a shell in a selected VM
 func newLoginCmd() *cobra.Command {
+	var username string
+	var useSSH bool
+
 	cmd := &cobra.Command{
 		Use:   "login <vmid>",
-		Short: "SSH into a running VM as root user",
-		Long:  `SSH into a running VM as root user by providing the VM ID.`,
-		Args:  cobra.ExactArgs(1),
+		Short: "Open an interactive shell in a running VM",
+		Long: `Open an interactive shell in a running VM by providing the VM ID.
+
+The shell runs in a pseudo-terminal through the fireactions-agent over vsock,
+so the VM needs neither sshd, keys nor a reachable IP address. It runs as the
+runner user of the pool unless --user is set. Use --ssh to connect over SSH as
+root instead.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLoginCmd(cmd, args[0])
+			if useSSH {
+				return runLoginSSHCmd(cmd, args[0])
+			}
+
+			return runLoginCmd(cmd, args[0], username)
 		},
 		GroupID: "machine",
 	}
 
+	cmd.Flags().StringVarP(&username, "user", "u", "", "User to log in as (default: the runner user of the pool)")
+	cmd.Flags().BoolVar(&useSSH, "ssh", false, "Connect over SSH as root instead of through the agent")
+	cmd.MarkFlagsMutuallyExclusive("user", "ssh")
 	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
 
 	return cmd
 }
 
-func runLoginCmd(cmd *cobra.Command, vmID string) error {
+func runLoginCmd(cmd *cobra.Command, vmID string, username string) error {
+	stdin, ok := cmd.InOrStdin().(*os.File)
+	if !ok || !isTerminal(int(stdin.Fd())) {
+		return fmt.Errorf("login requires a terminal, use exec to run commands without one")
+	}
+
+	return runExecCmd(cmd, execOptions{ID: vmID, User: username, RunnerUser: username == "", TTY: true})
+}
+
+func runLoginSSHCmd(cmd *cobra.Command, vmID string) error {
 	endpoint, _ := cmd.Flags().GetString("endpoint")
 	client, cleanup, err := newClient(endpoint)
 	if err != nil {
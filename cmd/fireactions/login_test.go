@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,8 +12,20 @@ func TestLoginCmd_Structure(t *testing.T) {
 
 	assert.NotNil(t, cmd)
 	assert.Equal(t, "login <vmid>", cmd.Use)
-	assert.Equal(t, "SSH into a running VM as root user", cmd.Short)
+	assert.Equal(t, "Open an interactive shell in a running VM", cmd.Short)
 	assert.NotNil(t, cmd.RunE)
+	assert.Empty(t, cmd.Flags().Lookup("user").DefValue, "the server picks the runner user of the pool")
+	assert.NotNil(t, cmd.Flags().Lookup("ssh"))
+}
+
+func TestLoginCmd_RequiresTerminal(t *testing.T) {
+	cmd := newLoginCmd()
+	cmd.SetArgs([]string{"runner-abc123"})
+	cmd.SetIn(strings.NewReader(""))
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "login requires a terminal")
 }
 
 func TestLoginCmd_RequiresVMID(t *testing.T) {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/spf13/cobra"
+)
+
+func newLogLevelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log-level LEVEL [MACHINE_ID]",
+		Short: "Change the log level of the server or a machine",
+		Long: `Change the log level of the Fireactions server, or of the fireactions-agent of
+a machine when MACHINE_ID is given, without restarting it.
+
+The change is not persisted: the server goes back to the log_level of its
+configuration file on restart.`,
+		Example: `  fireactions log-level debug
+  fireactions log-level debug runner-abc123`,
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var vmid string
+			if len(args) == 2 {
+				vmid = args[1]
+			}
+
+			return runLogLevelCmd(cmd, args[0], vmid)
+		},
+	}
+
+	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
+
+	return cmd
+}
+
+func runLogLevelCmd(cmd *cobra.Command, level, vmid string) error {
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	target := "server"
+	if vmid != "" {
+		target = "machine " + vmid
+	}
+
+	resp, err := client.SetLogLevel(cmd.Context(), &serverv1.SetLogLevelRequest{Level: level, ID: vmid})
+	if err != nil {
+		return fmt.Errorf("failed to set log level of %s: %w", target, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Log level of %s changed from %s to %s\n", target, resp.GetPreviousLevel(), level)
+	return nil
+}
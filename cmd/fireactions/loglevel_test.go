@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelCmd_Structure(t *testing.T) {
+	cmd := newLogLevelCmd()
+	assert.Equal(t, "log-level LEVEL [MACHINE_ID]", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("endpoint"))
+}
+
+func TestLogLevelCmd_Args(t *testing.T) {
+	cmd := newLogLevelCmd()
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+
+	cmd.SetArgs([]string{"debug", "runner-abc123", "extra"})
+	err = cmd.Execute()
+	require.Error(t, err)
+}
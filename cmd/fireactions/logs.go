@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/hostinger/fireactions/helper/printer"
 	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
 	"github.com/spf13/cobra"
 )
@@ -14,18 +15,33 @@ import (
 func newLogsCmd() *cobra.Command {
 	var follow bool
 	var tail int
+	var source string
+	var listSources bool
 
 	cmd := &cobra.Command{
 		Use:   "logs MACHINE_ID",
 		Short: "Stream logs from the fireactions-agent service inside a machine",
 		Long: `Stream logs from the fireactions-agent gRPC service running inside a machine.
 
-This shows the zerolog output from the agent service itself, including
-agent startup, status changes, and any errors from the agent.`,
+By default this shows the zerolog output from the agent service itself,
+including agent startup, status changes, and any errors from the agent.
+
+Use --source to stream the diagnostic logs of the GitHub runner instead:
+"runner" for the listener logs (_diag/Runner_*.log) and "worker" for the
+job logs (_diag/Worker_*.log). These files rotate to timestamped names,
+--follow picks up new files as they are created and --tail applies to the
+newest file. Use --list-sources to list the sources and their files.`,
+		Example: `  fireactions logs runner-abc123 --follow
+  fireactions logs runner-abc123 --source worker --tail 100
+  fireactions logs runner-abc123 --list-sources`,
 		Args:    cobra.ExactArgs(1),
 		GroupID: "machine",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLogsCmd(cmd, args[0], follow, tail)
+			if listSources {
+				return runLogsListSourcesCmd(cmd, args[0])
+			}
+
+			return runLogsCmd(cmd, args[0], source, follow, tail)
 		},
 	}
 
@@ -33,12 +49,35 @@ agent startup, status changes, and any errors from the agent.`,
 		"Follow log output (stream continuously like tail -f)")
 	cmd.Flags().IntVar(&tail, "tail", 0,
 		"Number of lines to show from end (0 = all buffered logs)")
+	cmd.Flags().StringVar(&source, "source", "agent",
+		"Log source to stream: agent, runner or worker")
+	cmd.Flags().BoolVar(&listSources, "list-sources", false,
+		"List the log sources and their files")
+	cmd.MarkFlagsMutuallyExclusive("list-sources", "source")
+	cmd.MarkFlagsMutuallyExclusive("list-sources", "follow")
 	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
 
 	return cmd
 }
 
-func runLogsCmd(cmd *cobra.Command, vmid string, follow bool, tail int) error {
+func runLogsListSourcesCmd(cmd *cobra.Command, vmid string) error {
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	resp, err := client.ListMachineLogSources(cmd.Context(), &serverv1.ListMachineLogSourcesRequest{ID: vmid})
+	if err != nil {
+		return fmt.Errorf("failed to list log sources: %w", err)
+	}
+
+	printer.PrintText(&printableLogSource{resp.Sources}, cmd.OutOrStdout(), nil)
+	return nil
+}
+
+func runLogsCmd(cmd *cobra.Command, vmid, source string, follow bool, tail int) error {
 	endpoint, _ := cmd.Flags().GetString("endpoint")
 	client, cleanup, err := newClient(endpoint)
 	if err != nil {
@@ -57,6 +96,7 @@ func runLogsCmd(cmd *cobra.Command, vmid string, follow bool, tail int) error {
 		ID:        vmid,
 		Follow:    follow,
 		TailLines: int32(tail),
+		Source:    source,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to get logs stream: %w", err)
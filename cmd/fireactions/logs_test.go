@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/helper/printer"
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLogsCmd_Flags(t *testing.T) {
+	cmd := newLogsCmd()
+
+	source := cmd.Flags().Lookup("source")
+	require.NotNil(t, source)
+	assert.Equal(t, "agent", source.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("list-sources"))
+
+	cmd.SetArgs([]string{"--list-sources", "--source", "worker", "runner-abc123"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	assert.Error(t, cmd.Execute(), "--list-sources and --source are mutually exclusive")
+}
+
+func TestPrintableLogSource(t *testing.T) {
+	var out bytes.Buffer
+	printer.PrintText(&printableLogSource{[]*serverv1.LogSource{
+		{Name: "agent", Description: "fireactions-agent logs"},
+		{Name: "worker", Files: []*serverv1.LogFile{
+			{Path: "/opt/runner/_diag/Worker_20240101-100000-utc.log", Size: 2048, ModifiedAt: timestamppb.New(time.Now())},
+			{Path: "/opt/runner/_diag/Worker_20240101-110000-utc.log", Size: 1024, ModifiedAt: timestamppb.New(time.Now())},
+		}},
+	}}, &out, nil)
+
+	assert.Contains(t, out.String(), "<none>")
+	assert.Contains(t, out.String(), "Worker_20240101-100000-utc.log")
+	assert.Contains(t, out.String(), "Worker_20240101-110000-utc.log")
+}
@@ -8,6 +8,10 @@ import (
 func main() {
 	cmd := NewRootCommand()
 	if err := cmd.Execute(); err != nil {
+		if code, ok := exitCode(err); ok {
+			os.Exit(code)
+		}
+
 		fmt.Fprintf(os.Stderr, "Error executing command: %s\n", err.Error())
 	}
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -57,7 +59,12 @@ type printableMachine struct {
 }
 
 func (m *printableMachine) Cols() []string {
-	return []string{"Pool", "ID", "ADDR", "Runner State", "Runner Version", "Created"}
+	return []string{"Pool", "ID", "ADDR", "Runner State", "Runner Version", "Health", "Created"}
+}
+
+// WideCols returns the columns of the wide output, including the job details.
+func (m *printableMachine) WideCols() []string {
+	return append(m.Cols(), "Jobs", "Repository", "Workflow", "Job", "Run ID", "Job Started", "Result")
 }
 
 func (m *printableMachine) ColsMap() map[string]string {
@@ -67,7 +74,15 @@ func (m *printableMachine) ColsMap() map[string]string {
 		"ADDR":           "ADDR",
 		"Runner State":   "Runner State",
 		"Runner Version": "Runner Version",
+		"Health":         "Health",
 		"Created":        "Created",
+		"Jobs":           "Jobs",
+		"Repository":     "Repository",
+		"Workflow":       "Workflow",
+		"Job":            "Job",
+		"Run ID":         "Run ID",
+		"Job Started":    "Job Started",
+		"Result":         "Result",
 	}
 }
 
@@ -84,32 +99,78 @@ func (m *printableMachine) KV() []map[string]interface{} {
 			runnerVersion = "Unknown"
 		}
 
+		health := "Healthy"
+		if !vm.Healthy {
+			health = "Unhealthy"
+		}
+
+		if vm.HeldUntil != nil {
+			health = fmt.Sprintf("Held (%s left)", units.HumanDuration(time.Until(vm.HeldUntil.AsTime())))
+		}
+
 		createdAt := vm.CreatedAt.AsTime()
 
+		repository, workflow, jobName, runID, jobStarted, result := "-", "-", "-", "-", "-", "-"
+		if job := vm.Job; job != nil {
+			jobName = orDash(job.Name)
+			repository = orDash(job.Repository)
+			workflow = orDash(job.Workflow)
+			if job.RunId != 0 {
+				runID = strconv.FormatInt(job.RunId, 10)
+				if job.RunAttempt > 1 {
+					runID += fmt.Sprintf(" (attempt %d)", job.RunAttempt)
+				}
+			}
+			if job.StartedAt != nil {
+				jobStarted = units.HumanDuration(time.Since(job.StartedAt.AsTime()))
+			}
+			result = job.Result
+			if result == "" {
+				result = "Running"
+			}
+		}
+
 		kv = append(kv, map[string]interface{}{
 			"Pool":           vm.Pool,
 			"ID":             vm.ID,
 			"ADDR":           vm.Addr,
 			"Runner State":   runnerState,
 			"Runner Version": runnerVersion,
+			"Health":         health,
 			"Created":        units.HumanDuration(time.Since(createdAt)),
+			"Jobs":           vm.Jobs,
+			"Repository":     repository,
+			"Workflow":       workflow,
+			"Job":            jobName,
+			"Run ID":         runID,
+			"Job Started":    jobStarted,
+			"Result":         result,
 		})
 	}
 	return kv
 }
 
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
 // printableImage wraps a slice of proto Images for printing
 type printableImage struct {
 	Images []*serverv1.Image
 }
 
 func (i *printableImage) Cols() []string {
-	return []string{"Name", "Size", "Created"}
+	return []string{"Name", "Type", "Size", "Created"}
 }
 
 func (i *printableImage) ColsMap() map[string]string {
 	return map[string]string{
 		"Name":    "Name",
+		"Type":    "Type",
 		"Size":    "Size",
 		"Created": "Created",
 	}
@@ -122,9 +183,203 @@ func (i *printableImage) KV() []map[string]interface{} {
 
 		kv = append(kv, map[string]interface{}{
 			"Name":    img.Name,
+			"Type":    img.Type,
 			"Size":    units.HumanSize(float64(img.GetSize())),
 			"Created": units.HumanDuration(time.Since(createdAt)),
 		})
 	}
 	return kv
 }
+
+// printableImageLayer wraps a slice of proto ImageLayers for printing
+type printableImageLayer struct {
+	Layers []*serverv1.ImageLayer
+}
+
+func (l *printableImageLayer) Cols() []string {
+	return []string{"Digest", "Media Type", "Size"}
+}
+
+func (l *printableImageLayer) ColsMap() map[string]string {
+	return map[string]string{
+		"Digest":     "Digest",
+		"Media Type": "Media Type",
+		"Size":       "Size",
+	}
+}
+
+func (l *printableImageLayer) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(l.Layers))
+	for _, layer := range l.Layers {
+		kv = append(kv, map[string]interface{}{
+			"Digest":     layer.Digest,
+			"Media Type": layer.MediaType,
+			"Size":       units.HumanSize(float64(layer.Size)),
+		})
+	}
+	return kv
+}
+
+// printableDiskStats wraps a slice of proto DiskStats for printing
+type printableDiskStats struct {
+	Disks []*serverv1.DiskStats
+}
+
+func (d *printableDiskStats) Cols() []string {
+	return []string{"Mountpoint", "Device", "Type", "Size", "Used", "Available", "Use%"}
+}
+
+func (d *printableDiskStats) ColsMap() map[string]string {
+	return map[string]string{
+		"Mountpoint": "Mountpoint",
+		"Device":     "Device",
+		"Type":       "Type",
+		"Size":       "Size",
+		"Used":       "Used",
+		"Available":  "Available",
+		"Use%":       "Use%",
+	}
+}
+
+func (d *printableDiskStats) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(d.Disks))
+	for _, disk := range d.Disks {
+		usage := "-"
+		if disk.Total > 0 {
+			usage = fmt.Sprintf("%.0f%%", 100*float64(disk.Used)/float64(disk.Total))
+		}
+
+		kv = append(kv, map[string]interface{}{
+			"Mountpoint": disk.Mountpoint,
+			"Device":     disk.Device,
+			"Type":       disk.Fstype,
+			"Size":       units.BytesSize(float64(disk.Total)),
+			"Used":       units.BytesSize(float64(disk.Used)),
+			"Available":  units.BytesSize(float64(disk.Available)),
+			"Use%":       usage,
+		})
+	}
+	return kv
+}
+
+// printableNetworkStats wraps a slice of proto NetworkStats for printing
+type printableNetworkStats struct {
+	Networks []*serverv1.NetworkStats
+}
+
+func (n *printableNetworkStats) Cols() []string {
+	return []string{"Interface", "RX", "TX", "RX Packets", "TX Packets", "Errors", "Dropped"}
+}
+
+func (n *printableNetworkStats) ColsMap() map[string]string {
+	return map[string]string{
+		"Interface":  "Interface",
+		"RX":         "RX",
+		"TX":         "TX",
+		"RX Packets": "RX Packets",
+		"TX Packets": "TX Packets",
+		"Errors":     "Errors",
+		"Dropped":    "Dropped",
+	}
+}
+
+func (n *printableNetworkStats) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(n.Networks))
+	for _, network := range n.Networks {
+		kv = append(kv, map[string]interface{}{
+			"Interface":  network.Interface,
+			"RX":         units.BytesSize(float64(network.RxBytes)),
+			"TX":         units.BytesSize(float64(network.TxBytes)),
+			"RX Packets": network.RxPackets,
+			"TX Packets": network.TxPackets,
+			"Errors":     network.RxErrors + network.TxErrors,
+			"Dropped":    network.RxDropped + network.TxDropped,
+		})
+	}
+	return kv
+}
+
+// printableProcessStats wraps a slice of proto ProcessStats for printing
+type printableProcessStats struct {
+	Processes []*serverv1.ProcessStats
+}
+
+func (p *printableProcessStats) Cols() []string {
+	return []string{"PID", "CPU%", "RSS", "Name", "Command"}
+}
+
+func (p *printableProcessStats) ColsMap() map[string]string {
+	return map[string]string{
+		"PID":     "PID",
+		"CPU%":    "CPU%",
+		"RSS":     "RSS",
+		"Name":    "Name",
+		"Command": "Command",
+	}
+}
+
+func (p *printableProcessStats) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(p.Processes))
+	for _, process := range p.Processes {
+		command := process.Command
+		if command == "" {
+			command = "[" + process.Name + "]"
+		}
+
+		kv = append(kv, map[string]interface{}{
+			"PID":     process.Pid,
+			"CPU%":    fmt.Sprintf("%.1f", process.CpuPercent),
+			"RSS":     units.BytesSize(float64(process.Rss)),
+			"Name":    process.Name,
+			"Command": command,
+		})
+	}
+	return kv
+}
+
+// printableLogSource wraps a slice of proto LogSources for printing, one row
+// per file
+type printableLogSource struct {
+	Sources []*serverv1.LogSource
+}
+
+func (l *printableLogSource) Cols() []string {
+	return []string{"Source", "Description", "File", "Size", "Modified"}
+}
+
+func (l *printableLogSource) ColsMap() map[string]string {
+	return map[string]string{
+		"Source":      "Source",
+		"Description": "Description",
+		"File":        "File",
+		"Size":        "Size",
+		"Modified":    "Modified",
+	}
+}
+
+func (l *printableLogSource) KV() []map[string]interface{} {
+	kv := make([]map[string]interface{}, 0, len(l.Sources))
+	for _, source := range l.Sources {
+		if len(source.Files) == 0 {
+			kv = append(kv, map[string]interface{}{
+				"Source":      source.Name,
+				"Description": source.Description,
+				"File":        "<none>",
+				"Size":        "-",
+				"Modified":    "-",
+			})
+			continue
+		}
+
+		for _, file := range source.Files {
+			kv = append(kv, map[string]interface{}{
+				"Source":      source.Name,
+				"Description": source.Description,
+				"File":        file.Path,
+				"Size":        units.HumanSize(float64(file.Size)),
+				"Modified":    units.HumanDuration(time.Since(file.ModifiedAt.AsTime())),
+			})
+		}
+	}
+	return kv
+}
@@ -20,12 +20,18 @@ func newPsCmd() *cobra.Command {
 		GroupID: "machine",
 	}
 
+	cmd.Flags().StringP("output", "o", "", "Output format: wide to include the details of the current job")
 	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
 
 	return cmd
 }
 
 func runPsCmd(cmd *cobra.Command) error {
+	output, _ := cmd.Flags().GetString("output")
+	if output != "" && output != "wide" {
+		return fmt.Errorf("invalid output format %q, must be wide", output)
+	}
+
 	endpoint, _ := cmd.Flags().GetString("endpoint")
 	client, cleanup, err := newClient(endpoint)
 	if err != nil {
@@ -43,6 +49,13 @@ func runPsCmd(cmd *cobra.Command) error {
 		return nil
 	}
 
-	printer.PrintText(&printableMachine{resp.Machines}, cmd.OutOrStdout(), nil)
+	machines := &printableMachine{resp.Machines}
+
+	var cols []string
+	if output == "wide" {
+		cols = machines.WideCols()
+	}
+
+	printer.PrintText(machines, cmd.OutOrStdout(), cols)
 	return nil
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hostinger/fireactions/helper/printer"
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPsCmd_InvalidOutput(t *testing.T) {
+	cmd := newPsCmd()
+	cmd.SetArgs([]string{"-o", "json"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output format")
+}
+
+func TestPrintableMachine_Wide(t *testing.T) {
+	machines := &printableMachine{[]*serverv1.Machine{
+		{
+			ID:        "default-abc123",
+			Pool:      "default",
+			CreatedAt: timestamppb.New(time.Now()),
+			Jobs:      3,
+			Job: &serverv1.Job{
+				Name:       "build",
+				Repository: "hostinger/fireactions",
+				Workflow:   "CI",
+				RunId:      1234567890,
+				RunAttempt: 2,
+				StartedAt:  timestamppb.New(time.Now()),
+			},
+		},
+		{ID: "default-def456", Pool: "default", CreatedAt: timestamppb.New(time.Now())},
+	}}
+
+	var out bytes.Buffer
+	printer.PrintText(machines, &out, nil)
+	assert.NotContains(t, out.String(), "hostinger/fireactions")
+
+	out.Reset()
+	printer.PrintText(machines, &out, machines.WideCols())
+	assert.Contains(t, out.String(), "hostinger/fireactions")
+	assert.Contains(t, out.String(), "JOBS")
+	assert.Contains(t, out.String(), "1234567890 (attempt 2)")
+	assert.Contains(t, out.String(), "Running")
+}
+
+func TestPrintableMachine_Held(t *testing.T) {
+	machines := &printableMachine{[]*serverv1.Machine{
+		{ID: "default-abc123", Pool: "default", Healthy: true, CreatedAt: timestamppb.New(time.Now()), HeldUntil: timestamppb.New(time.Now().Add(2 * time.Hour))},
+		{ID: "default-def456", Pool: "default", Healthy: true, CreatedAt: timestamppb.New(time.Now())},
+	}}
+
+	var out bytes.Buffer
+	printer.PrintText(machines, &out, nil)
+	assert.Contains(t, out.String(), "Held (2 hours left)")
+	assert.Contains(t, out.String(), "Healthy")
+}
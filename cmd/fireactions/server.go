@@ -37,7 +37,18 @@ func runServerCmd(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("config: %w", err)
 	}
 
-	logger, err := logger.New(config.LogLevel)
+	logOpts := []logger.Opt{logger.WithFormat(config.LogFormat)}
+	if config.LogFile.Path != "" {
+		logFile, err := logger.NewFile(config.LogFile.Path, config.LogFile.MaxSizeMB*1024*1024, config.LogFile.MaxBackups)
+		if err != nil {
+			return fmt.Errorf("creating log file: %w", err)
+		}
+		defer logFile.Close()
+
+		logOpts = append(logOpts, logger.WithOutput(logFile))
+	}
+
+	logger, err := logger.New(config.LogLevel, logOpts...)
 	if err != nil {
 		return fmt.Errorf("creating logger: %w", err)
 	}
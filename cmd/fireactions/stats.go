@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/docker/go-units"
+	"github.com/hostinger/fireactions/helper/printer"
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/spf13/cobra"
+)
+
+func newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats MACHINE_ID",
+		Short: "Show the resource usage of a machine",
+		Long: `Show a snapshot of the resource usage of a machine as seen from inside the
+VM: CPU, load, memory, disk usage of the root drive and mounted volumes,
+network counters and the processes using the most CPU.
+
+CPU usage is sampled over a short interval by the fireactions-agent.`,
+		Example: `  fireactions stats runner-abc123
+  fireactions stats --top 20 runner-abc123`,
+		Args:    cobra.ExactArgs(1),
+		GroupID: "machine",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatsCmd(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().Int32("top", 10, "Number of processes to show")
+	cmd.Flags().StringP("endpoint", "e", "127.0.0.1:8080", "Sets the Fireactions server endpoint")
+
+	return cmd
+}
+
+func runStatsCmd(cmd *cobra.Command, vmid string) error {
+	top, _ := cmd.Flags().GetInt32("top")
+	if top < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+
+	endpoint, _ := cmd.Flags().GetString("endpoint")
+	client, cleanup, err := newClient(endpoint)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+	defer cleanup()
+
+	resp, err := client.GetMachineStats(cmd.Context(), &serverv1.GetMachineStatsRequest{ID: vmid, TopProcesses: top})
+	if err != nil {
+		return fmt.Errorf("failed to get stats of machine %s: %w", vmid, err)
+	}
+
+	printStats(cmd.OutOrStdout(), resp)
+	return nil
+}
+
+func printStats(out io.Writer, stats *serverv1.GetMachineStatsResponse) {
+	memory := stats.GetMemory()
+	_, _ = fmt.Fprintf(out, "CPU:     %.1f%% of %d CPUs\n", stats.GetCpu().GetUsagePercent(), stats.GetCpu().GetCount())
+	_, _ = fmt.Fprintf(out, "Load:    %.2f %.2f %.2f\n", stats.GetLoad().GetLoad1(), stats.GetLoad().GetLoad5(), stats.GetLoad().GetLoad15())
+	_, _ = fmt.Fprintf(out, "Memory:  %s used, %s available of %s (%s cached)\n",
+		units.BytesSize(float64(memory.GetUsed())), units.BytesSize(float64(memory.GetAvailable())),
+		units.BytesSize(float64(memory.GetTotal())), units.BytesSize(float64(memory.GetBuffers()+memory.GetCached())))
+	if memory.GetSwapTotal() > 0 {
+		_, _ = fmt.Fprintf(out, "Swap:    %s used of %s\n", units.BytesSize(float64(memory.GetSwapUsed())), units.BytesSize(float64(memory.GetSwapTotal())))
+	}
+
+	if len(stats.GetDisks()) > 0 {
+		_, _ = fmt.Fprintf(out, "\nDisks:\n")
+		printer.PrintText(&printableDiskStats{stats.GetDisks()}, out, nil)
+	}
+
+	if len(stats.GetNetworks()) > 0 {
+		_, _ = fmt.Fprintf(out, "\nNetworks:\n")
+		printer.PrintText(&printableNetworkStats{stats.GetNetworks()}, out, nil)
+	}
+
+	if len(stats.GetProcesses()) > 0 {
+		_, _ = fmt.Fprintf(out, "\nProcesses:\n")
+		printer.PrintText(&printableProcessStats{stats.GetProcesses()}, out, nil)
+	}
+}
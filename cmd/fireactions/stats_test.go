@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCmd_Structure(t *testing.T) {
+	cmd := newStatsCmd()
+	assert.Equal(t, "stats MACHINE_ID", cmd.Use)
+	assert.Equal(t, "machine", cmd.GroupID)
+	assert.NotNil(t, cmd.Flags().Lookup("top"))
+	assert.NotNil(t, cmd.Flags().Lookup("endpoint"))
+}
+
+func TestStatsCmd_InvalidTop(t *testing.T) {
+	cmd := newStatsCmd()
+	cmd.SetArgs([]string{"--top", "0", "runner-abc123"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--top must be at least 1")
+}
+
+func TestPrintStats(t *testing.T) {
+	var out bytes.Buffer
+	printStats(&out, &serverv1.GetMachineStatsResponse{
+		Cpu:       &serverv1.CPUStats{Count: 2, UsagePercent: 50},
+		Load:      &serverv1.LoadStats{Load1: 1.5, Load5: 1, Load15: 0.5},
+		Memory:    &serverv1.MemoryStats{Total: 2 << 30, Used: 1 << 30, Available: 1 << 30},
+		Disks:     []*serverv1.DiskStats{{Mountpoint: "/", Device: "/dev/vda", Fstype: "ext4", Total: 100, Used: 25}},
+		Processes: []*serverv1.ProcessStats{{Pid: 42, Name: "Runner.Worker", Command: "/opt/runner/bin/Runner.Worker", CpuPercent: 99.5}},
+	})
+
+	assert.Contains(t, out.String(), "50.0% of 2 CPUs")
+	assert.Contains(t, out.String(), "1.50 1.00 0.50")
+	assert.Contains(t, out.String(), "1GiB used, 1GiB available of 2GiB")
+	assert.Contains(t, out.String(), "25%")
+	assert.Contains(t, out.String(), "/opt/runner/bin/Runner.Worker")
+	assert.NotContains(t, out.String(), "Swap:")
+	assert.NotContains(t, out.String(), "Networks:")
+}
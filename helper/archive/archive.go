@@ -0,0 +1,257 @@
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Tar writes the file or directory at src to w as a tar archive. Entries are
+// rooted at the base name of src. Symlinks are not followed and special files
+// (devices, sockets, pipes) are skipped.
+func Tar(w io.Writer, src string) error {
+	src = filepath.Clean(src)
+	if _, err := os.Lstat(src); err != nil {
+		return err
+	}
+
+	root := filepath.Base(src)
+	tw := tar.NewWriter(w)
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() && !info.IsDir() && info.Mode()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(filepath.Join(root, rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// Untar extracts a tar archive written by Tar to dst. If dst is an existing
+// directory, the archive root is extracted into it. Otherwise the archive root
+// is extracted as dst, whose parent directory must exist. Entries escaping dst
+// are rejected.
+func Untar(r io.Reader, dst string) error {
+	dst = filepath.Clean(dst)
+
+	intoDir := false
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		intoDir = true
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	base := dst
+	if !intoDir {
+		base = filepath.Dir(dst)
+	}
+
+	base, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	root := ""
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid entry %s", header.Name)
+		}
+
+		first, rest, _ := strings.Cut(name, string(filepath.Separator))
+		if root == "" {
+			root = first
+		} else if first != root {
+			return fmt.Errorf("entry %s is outside of the archive root %s", header.Name, root)
+		}
+
+		target := filepath.Join(base, filepath.Base(dst), rest)
+		if intoDir {
+			target = filepath.Join(base, root, rest)
+		}
+
+		if err := checkParent(base, target); err != nil {
+			return err
+		}
+
+		if err := extract(tr, header, target); err != nil {
+			return fmt.Errorf("extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+// checkParent checks that the parent directory of target resolves to a path
+// within base, so entries cannot be written through symlinks.
+func checkParent(base, target string) error {
+	parent, err := filepath.EvalSymlinks(filepath.Dir(target))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("parent directory of %s does not exist", target)
+	}
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(base, parent)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is outside of %s", target, base)
+	}
+
+	return nil
+}
+
+func extract(tr *tar.Reader, header *tar.Header, target string) error {
+	mode := header.FileInfo().Mode().Perm()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return extractDir(target, mode)
+	case tar.TypeReg:
+		// Replace symlinks instead of writing through them.
+		if info, err := os.Lstat(target); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(f, tr); err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		if err := f.Chmod(mode); err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+		return os.Chtimes(target, header.ModTime, header.ModTime)
+	case tar.TypeSymlink:
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return os.Symlink(header.Linkname, target)
+	default:
+		return nil
+	}
+}
+
+// extractDir creates the directory target or updates the mode of an existing
+// one. Symlinks at target are replaced instead of followed, so the mode of
+// directories outside of the destination is never changed.
+func extractDir(target string, mode fs.FileMode) error {
+	info, err := os.Lstat(target)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	case info.Mode()&fs.ModeSymlink != 0:
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	case !info.IsDir():
+		return fmt.Errorf("%s exists and is not a directory", target)
+	}
+
+	if err := os.Mkdir(target, mode); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+
+	f, err := os.Open(target)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Check that the opened directory is the one at target, not a symlink
+	// created in the meantime
+	opened, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	info, err = os.Lstat(target)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() || !os.SameFile(info, opened) {
+		return fmt.Errorf("%s changed while it was extracted", target)
+	}
+
+	return f.Chmod(mode)
+}
@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTree(t *testing.T) string {
+	t.Helper()
+
+	src := filepath.Join(t.TempDir(), "_diag")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "pages"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "Runner_1.log"), []byte("runner"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "pages", "job.log"), []byte("job"), 0600))
+	require.NoError(t, os.Symlink("Runner_1.log", filepath.Join(src, "latest")))
+
+	return src
+}
+
+func TestTarUntar_IntoDirectory(t *testing.T) {
+	src := newTestTree(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Tar(&buf, src))
+
+	dst := t.TempDir()
+	require.NoError(t, Untar(&buf, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "_diag", "pages", "job.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "job", string(data))
+
+	info, err := os.Stat(filepath.Join(dst, "_diag", "pages", "job.log"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "_diag", "latest"))
+	require.NoError(t, err)
+	assert.Equal(t, "Runner_1.log", link)
+}
+
+func TestTarUntar_Rename(t *testing.T) {
+	src := newTestTree(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Tar(&buf, filepath.Join(src, "Runner_1.log")))
+
+	dst := filepath.Join(t.TempDir(), "runner.log")
+	require.NoError(t, Untar(&buf, dst))
+
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "runner", string(data))
+
+	buf.Reset()
+	require.NoError(t, Tar(&buf, src))
+	assert.Error(t, Untar(&buf, filepath.Join(t.TempDir(), "missing", "diag")), "the parent directory must exist")
+}
+
+func TestUntar_RejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tar.Header
+	}{
+		{"parent", []tar.Header{{Name: "../evil", Typeflag: tar.TypeReg}}},
+		{"absolute", []tar.Header{{Name: "/evil", Typeflag: tar.TypeReg}}},
+		{"symlink", []tar.Header{
+			{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "/"},
+			{Name: "dir/link/evil", Typeflag: tar.TypeReg},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, header := range tt.entries {
+				require.NoError(t, tw.WriteHeader(&header))
+			}
+			require.NoError(t, tw.Close())
+
+			assert.Error(t, Untar(&buf, t.TempDir()))
+		})
+	}
+}
+
+func TestUntar_DirectoryThroughSymlink(t *testing.T) {
+	outside := t.TempDir()
+	require.NoError(t, os.Chmod(outside, 0700))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, header := range []tar.Header{
+		{Name: "r/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "r/x", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "r/x/", Typeflag: tar.TypeDir, Mode: 0777},
+	} {
+		require.NoError(t, tw.WriteHeader(&header))
+	}
+	require.NoError(t, tw.Close())
+
+	dst := t.TempDir()
+	require.NoError(t, Untar(&buf, dst))
+
+	info, err := os.Stat(outside)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm(), "the mode of the symlink target is unchanged")
+
+	info, err = os.Lstat(filepath.Join(dst, "r", "x"))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir(), "the symlink is replaced by the directory")
+}
@@ -3,6 +3,7 @@ package deepcopy
 import (
 	"bytes"
 	"encoding/gob"
+	"time"
 )
 
 // Map returns a deep copy of a map.
@@ -27,4 +28,6 @@ func Map(m map[string]interface{}) map[string]interface{} {
 
 func init() {
 	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(time.Time{})
 }
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// File is a log file that is rotated once it reaches its maximum size. The
+// rotated files are named <path>.1 (newest) up to <path>.<max backups>.
+type File struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu        sync.Mutex
+	file      *os.File
+	size      int64
+	movedAway bool // Whether file was rotated, but the new file not opened yet
+}
+
+// NewFile opens the log file at path for appending, creating it and its
+// directory if needed. A maxSize of 0 disables rotation.
+func NewFile(path string, maxSize int64, maxBackups int) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+
+	f := &File{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, size, err := openFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.file = file
+	f.size = size
+	return nil
+}
+
+// openFile opens the log file at path for appending and returns its size.
+func openFile(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, fmt.Errorf("stat log file: %w", err)
+	}
+
+	return file, info.Size(), nil
+}
+
+// Write writes p to the log file, rotating it first if p would make it
+// exceed its maximum size. If the rotation fails, p is written to the current
+// file and the rotation is retried on the next write.
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		_ = f.rotate()
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the rotated files, moves the current file to <path>.1 and
+// opens a new one. The oldest file is removed. The current file stays open
+// until the new one is, so a failed rotation never leaves the log closed.
+func (f *File) rotate() error {
+	if !f.movedAway {
+		if f.maxBackups > 0 {
+			_ = os.Remove(f.backupPath(f.maxBackups))
+			for i := f.maxBackups - 1; i > 0; i-- {
+				_ = os.Rename(f.backupPath(i), f.backupPath(i+1))
+			}
+
+			if err := os.Rename(f.path, f.backupPath(1)); err != nil {
+				return fmt.Errorf("rotate log file: %w", err)
+			}
+		} else if err := os.Remove(f.path); err != nil {
+			return fmt.Errorf("rotate log file: %w", err)
+		}
+
+		f.movedAway = true
+	}
+
+	file, size, err := openFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	_ = f.file.Close()
+	f.file = file
+	f.size = size
+	f.movedAway = false
+	return nil
+}
+
+func (f *File) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
+
+// Sync commits the log file to stable storage.
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Sync()
+}
+
+// Close closes the log file.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
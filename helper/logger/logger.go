@@ -1,19 +1,61 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"strconv"
 
 	"github.com/rs/zerolog"
 )
 
+// Log formats.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+type options struct {
+	format string
+	out    io.Writer
+}
+
+// Opt is a functional option for New.
+type Opt func(o *options)
+
+// WithFormat sets the log format, FormatConsole (default) or FormatJSON.
+func WithFormat(format string) Opt {
+	f := func(o *options) {
+		o.format = format
+	}
+
+	return f
+}
+
+// WithOutput sets where logs are written to, stdout by default.
+func WithOutput(out io.Writer) Opt {
+	f := func(o *options) {
+		o.out = out
+	}
+
+	return f
+}
+
 // New returns a new logger with the given configuration.
-func New(level string) (*zerolog.Logger, error) {
-	logLevel, err := zerolog.ParseLevel(level)
+//
+// The level is applied globally with zerolog.SetGlobalLevel, so it can be
+// changed at runtime with SetLevel for every logger derived from this one.
+func New(level string, opts ...Opt) (*zerolog.Logger, error) {
+	logLevel, err := ParseLevel(level)
 	if err != nil {
 		return nil, err
 	}
 
+	o := &options{format: FormatConsole, out: os.Stdout}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
 		short := file
 		for i := len(file) - 1; i > 0; i-- {
@@ -27,11 +69,23 @@ func New(level string) (*zerolog.Logger, error) {
 		return file + ":" + strconv.Itoa(line)
 	}
 
-	logger := zerolog.New(zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: "2006-01-02 15:04:05",
-	}).
-		Level(logLevel).
+	var out io.Writer
+	switch o.format {
+	case FormatConsole, "":
+		out = zerolog.ConsoleWriter{
+			Out:        o.out,
+			TimeFormat: "2006-01-02 15:04:05",
+			NoColor:    o.out != os.Stdout,
+		}
+	case FormatJSON:
+		out = o.out
+	default:
+		return nil, fmt.Errorf("invalid log format %q", o.format)
+	}
+
+	zerolog.SetGlobalLevel(logLevel)
+	logger := zerolog.New(out).
+		Level(zerolog.TraceLevel).
 		With().
 		Timestamp().
 		Caller().
@@ -39,3 +93,31 @@ func New(level string) (*zerolog.Logger, error) {
 
 	return &logger, nil
 }
+
+// ParseLevel parses a log level. Unlike zerolog.ParseLevel, it rejects the
+// empty level.
+func ParseLevel(level string) (zerolog.Level, error) {
+	if level == "" {
+		return zerolog.NoLevel, fmt.Errorf("log level is required")
+	}
+
+	return zerolog.ParseLevel(level)
+}
+
+// SetLevel sets the level of all loggers and returns the previous one.
+func SetLevel(level string) (string, error) {
+	logLevel, err := ParseLevel(level)
+	if err != nil {
+		return "", err
+	}
+
+	previous := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(logLevel)
+
+	return previous.String(), nil
+}
+
+// Level returns the level of all loggers.
+func Level() string {
+	return zerolog.GlobalLevel().String()
+}
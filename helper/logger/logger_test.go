@@ -1,10 +1,15 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -35,8 +40,99 @@ func TestNewLogger(t *testing.T) {
 
 				assert.NoError(t, err)
 				assert.NotNil(t, logger)
-				assert.Equal(t, zerolog.Level(level), logger.GetLevel())
+				assert.Equal(t, zerolog.Level(level), zerolog.GlobalLevel())
 			}
 		})
 	}
 }
+
+func TestNew_JSON(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New("info", WithFormat(FormatJSON), WithOutput(&out))
+	require.NoError(t, err)
+
+	logger.Info().Str("pool", "default").Msg("hello")
+	logger.Debug().Msg("hidden")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry), "a single JSON line is written")
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "default", entry["pool"])
+	assert.Equal(t, "hello", entry["message"])
+
+	_, err = New("info", WithFormat("logfmt"))
+	assert.Error(t, err)
+}
+
+func TestSetLevel(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New("info", WithFormat(FormatJSON), WithOutput(&out))
+	require.NoError(t, err)
+
+	derived := logger.With().Str("component", "test").Logger()
+	derived.Debug().Msg("hidden")
+	assert.Empty(t, out.String())
+
+	previous, err := SetLevel("debug")
+	require.NoError(t, err)
+	assert.Equal(t, "info", previous)
+	assert.Equal(t, "debug", Level())
+
+	derived.Debug().Msg("shown")
+	assert.Contains(t, out.String(), "shown")
+
+	_, err = SetLevel("verbose")
+	assert.Error(t, err)
+	_, err = SetLevel("")
+	assert.Error(t, err)
+	assert.Equal(t, "debug", Level())
+}
+
+func TestFile_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "server.log")
+	f, err := NewFile(path, 10, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	for file, expected := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+
+	assert.NoFileExists(t, path+".3", "only max backups are kept")
+}
+
+func TestFile_RotateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+	f, err := NewFile(path, 10, 1)
+	require.NoError(t, err)
+	defer f.Close()
+
+	// A non-empty directory in place of the backup cannot be replaced
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0755))
+
+	for _, line := range []string{"first\n", "second\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err, "writes succeed while the rotation fails")
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data), "the current file is kept")
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = f.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	for file, expected := range map[string]string{path: "third\n", path + ".1": "first\nsecond\n"} {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data), "the rotation is retried on the next write")
+	}
+}
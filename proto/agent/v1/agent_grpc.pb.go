@@ -22,6 +22,14 @@ const (
 	AgentService_GetRunnerState_FullMethodName   = "/fireactions.agent.v1.AgentService/GetRunnerState"
 	AgentService_GetRunnerVersion_FullMethodName = "/fireactions.agent.v1.AgentService/GetRunnerVersion"
 	AgentService_GetLogs_FullMethodName          = "/fireactions.agent.v1.AgentService/GetLogs"
+	AgentService_ListLogSources_FullMethodName   = "/fireactions.agent.v1.AgentService/ListLogSources"
+	AgentService_WatchRunnerState_FullMethodName = "/fireactions.agent.v1.AgentService/WatchRunnerState"
+	AgentService_Exec_FullMethodName             = "/fireactions.agent.v1.AgentService/Exec"
+	AgentService_CopyFrom_FullMethodName         = "/fireactions.agent.v1.AgentService/CopyFrom"
+	AgentService_CopyTo_FullMethodName           = "/fireactions.agent.v1.AgentService/CopyTo"
+	AgentService_GetStats_FullMethodName         = "/fireactions.agent.v1.AgentService/GetStats"
+	AgentService_SetLogLevel_FullMethodName      = "/fireactions.agent.v1.AgentService/SetLogLevel"
+	AgentService_Shutdown_FullMethodName         = "/fireactions.agent.v1.AgentService/Shutdown"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -34,8 +42,36 @@ type AgentServiceClient interface {
 	GetRunnerState(ctx context.Context, in *GetRunnerStateRequest, opts ...grpc.CallOption) (*GetRunnerStateResponse, error)
 	// GetRunnerVersion retrieves the agent version.
 	GetRunnerVersion(ctx context.Context, in *GetRunnerVersionRequest, opts ...grpc.CallOption) (*GetRunnerVersionResponse, error)
-	// GetLogs streams logs from the agent service (server-side streaming).
+	// GetLogs streams logs from the agent service or the GitHub runner
+	// (server-side streaming).
 	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetLogsResponse], error)
+	// ListLogSources lists the log sources GetLogs can stream and their files.
+	ListLogSources(ctx context.Context, in *ListLogSourcesRequest, opts ...grpc.CallOption) (*ListLogSourcesResponse, error)
+	// WatchRunnerState streams the runner state transitions (server-side
+	// streaming), starting with the transitions that already happened. The
+	// stream ends once the runner exits for good, after its last job in
+	// multi-job mode. It ends with RESOURCE_EXHAUSTED if the client falls
+	// behind the transitions instead of skipping any.
+	WatchRunnerState(ctx context.Context, in *WatchRunnerStateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRunnerStateResponse], error)
+	// Exec runs a command in the VM (bidirectional streaming), optionally in a
+	// pseudo-terminal. The first request starts the command, the following ones
+	// carry its stdin, terminal resizes and signals. The last response carries
+	// the exit code.
+	Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error)
+	// CopyFrom streams a file or directory out of the VM as a tar archive
+	// (server-side streaming). Entries are rooted at the base name of the path.
+	CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyFromResponse], error)
+	// CopyTo extracts a tar archive into the VM (client-side streaming). The
+	// first request carries the destination path.
+	CopyTo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToRequest, CopyToResponse], error)
+	// GetStats returns the resource usage of the VM, read from /proc.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// SetLogLevel changes the log level of the agent without restarting it.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// Shutdown stops the runner from taking new jobs and powers the VM off once
+	// the runner exits: right away if it is idle, after the current job
+	// otherwise. The VM is powered off after the grace period regardless.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 }
 
 type agentServiceClient struct {
@@ -85,6 +121,110 @@ func (c *agentServiceClient) GetLogs(ctx context.Context, in *GetLogsRequest, op
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_GetLogsClient = grpc.ServerStreamingClient[GetLogsResponse]
 
+func (c *agentServiceClient) ListLogSources(ctx context.Context, in *ListLogSourcesRequest, opts ...grpc.CallOption) (*ListLogSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLogSourcesResponse)
+	err := c.cc.Invoke(ctx, AgentService_ListLogSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) WatchRunnerState(ctx context.Context, in *WatchRunnerStateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchRunnerStateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_WatchRunnerState_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRunnerStateRequest, WatchRunnerStateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_WatchRunnerStateClient = grpc.ServerStreamingClient[WatchRunnerStateResponse]
+
+func (c *agentServiceClient) Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_Exec_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecRequest, ExecResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ExecClient = grpc.BidiStreamingClient[ExecRequest, ExecResponse]
+
+func (c *agentServiceClient) CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyFromResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[3], AgentService_CopyFrom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyFromRequest, CopyFromResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_CopyFromClient = grpc.ServerStreamingClient[CopyFromResponse]
+
+func (c *agentServiceClient) CopyTo(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToRequest, CopyToResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[4], AgentService_CopyTo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyToRequest, CopyToResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_CopyToClient = grpc.ClientStreamingClient[CopyToRequest, CopyToResponse]
+
+func (c *agentServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, AgentService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, AgentService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, AgentService_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
@@ -95,8 +235,36 @@ type AgentServiceServer interface {
 	GetRunnerState(context.Context, *GetRunnerStateRequest) (*GetRunnerStateResponse, error)
 	// GetRunnerVersion retrieves the agent version.
 	GetRunnerVersion(context.Context, *GetRunnerVersionRequest) (*GetRunnerVersionResponse, error)
-	// GetLogs streams logs from the agent service (server-side streaming).
+	// GetLogs streams logs from the agent service or the GitHub runner
+	// (server-side streaming).
 	GetLogs(*GetLogsRequest, grpc.ServerStreamingServer[GetLogsResponse]) error
+	// ListLogSources lists the log sources GetLogs can stream and their files.
+	ListLogSources(context.Context, *ListLogSourcesRequest) (*ListLogSourcesResponse, error)
+	// WatchRunnerState streams the runner state transitions (server-side
+	// streaming), starting with the transitions that already happened. The
+	// stream ends once the runner exits for good, after its last job in
+	// multi-job mode. It ends with RESOURCE_EXHAUSTED if the client falls
+	// behind the transitions instead of skipping any.
+	WatchRunnerState(*WatchRunnerStateRequest, grpc.ServerStreamingServer[WatchRunnerStateResponse]) error
+	// Exec runs a command in the VM (bidirectional streaming), optionally in a
+	// pseudo-terminal. The first request starts the command, the following ones
+	// carry its stdin, terminal resizes and signals. The last response carries
+	// the exit code.
+	Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error
+	// CopyFrom streams a file or directory out of the VM as a tar archive
+	// (server-side streaming). Entries are rooted at the base name of the path.
+	CopyFrom(*CopyFromRequest, grpc.ServerStreamingServer[CopyFromResponse]) error
+	// CopyTo extracts a tar archive into the VM (client-side streaming). The
+	// first request carries the destination path.
+	CopyTo(grpc.ClientStreamingServer[CopyToRequest, CopyToResponse]) error
+	// GetStats returns the resource usage of the VM, read from /proc.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// SetLogLevel changes the log level of the agent without restarting it.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// Shutdown stops the runner from taking new jobs and powers the VM off once
+	// the runner exits: right away if it is idle, after the current job
+	// otherwise. The VM is powered off after the grace period regardless.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -116,6 +284,30 @@ func (UnimplementedAgentServiceServer) GetRunnerVersion(context.Context, *GetRun
 func (UnimplementedAgentServiceServer) GetLogs(*GetLogsRequest, grpc.ServerStreamingServer[GetLogsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
+func (UnimplementedAgentServiceServer) ListLogSources(context.Context, *ListLogSourcesRequest) (*ListLogSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogSources not implemented")
+}
+func (UnimplementedAgentServiceServer) WatchRunnerState(*WatchRunnerStateRequest, grpc.ServerStreamingServer[WatchRunnerStateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRunnerState not implemented")
+}
+func (UnimplementedAgentServiceServer) Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedAgentServiceServer) CopyFrom(*CopyFromRequest, grpc.ServerStreamingServer[CopyFromResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyFrom not implemented")
+}
+func (UnimplementedAgentServiceServer) CopyTo(grpc.ClientStreamingServer[CopyToRequest, CopyToResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyTo not implemented")
+}
+func (UnimplementedAgentServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedAgentServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAgentServiceServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -184,6 +376,114 @@ func _AgentService_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) er
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AgentService_GetLogsServer = grpc.ServerStreamingServer[GetLogsResponse]
 
+func _AgentService_ListLogSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLogSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ListLogSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ListLogSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ListLogSources(ctx, req.(*ListLogSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_WatchRunnerState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRunnerStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).WatchRunnerState(m, &grpc.GenericServerStream[WatchRunnerStateRequest, WatchRunnerStateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_WatchRunnerStateServer = grpc.ServerStreamingServer[WatchRunnerStateResponse]
+
+func _AgentService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).Exec(&grpc.GenericServerStream[ExecRequest, ExecResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ExecServer = grpc.BidiStreamingServer[ExecRequest, ExecResponse]
+
+func _AgentService_CopyFrom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).CopyFrom(m, &grpc.GenericServerStream[CopyFromRequest, CopyFromResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_CopyFromServer = grpc.ServerStreamingServer[CopyFromResponse]
+
+func _AgentService_CopyTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).CopyTo(&grpc.GenericServerStream[CopyToRequest, CopyToResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_CopyToServer = grpc.ClientStreamingServer[CopyToRequest, CopyToResponse]
+
+func _AgentService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,6 +499,22 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRunnerVersion",
 			Handler:    _AgentService_GetRunnerVersion_Handler,
 		},
+		{
+			MethodName: "ListLogSources",
+			Handler:    _AgentService_ListLogSources_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _AgentService_GetStats_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AgentService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _AgentService_Shutdown_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -206,6 +522,311 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AgentService_GetLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchRunnerState",
+			Handler:       _AgentService_WatchRunnerState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _AgentService_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFrom",
+			Handler:       _AgentService_CopyFrom_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyTo",
+			Handler:       _AgentService_CopyTo_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/agent/v1/agent.proto",
+}
+
+const (
+	HostService_Heartbeat_FullMethodName             = "/fireactions.agent.v1.HostService/Heartbeat"
+	HostService_ReportStateTransition_FullMethodName = "/fireactions.agent.v1.HostService/ReportStateTransition"
+	HostService_ReportConfigStatus_FullMethodName    = "/fireactions.agent.v1.HostService/ReportConfigStatus"
+	HostService_RenewRunner_FullMethodName           = "/fireactions.agent.v1.HostService/RenewRunner"
+	HostService_ReportFailedJob_FullMethodName       = "/fireactions.agent.v1.HostService/ReportFailedJob"
+)
+
+// HostServiceClient is the client API for HostService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HostService is served by the server on the host for every VM. The agent
+// connects to it over vsock to push its state.
+type HostServiceClient interface {
+	// Heartbeat reports that the agent is alive, along with the current runner state.
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// ReportStateTransition reports a runner state transition as it happens.
+	ReportStateTransition(ctx context.Context, in *ReportStateTransitionRequest, opts ...grpc.CallOption) (*ReportStateTransitionResponse, error)
+	// ReportConfigStatus reports whether the agent has consumed its
+	// configuration from MMDS, so the server can scrub sensitive values from
+	// it, and whether it waits for a runner JIT config, so the server can
+	// snapshot the VM. It is pushed whenever either changes.
+	ReportConfigStatus(ctx context.Context, in *ReportConfigStatusRequest, opts ...grpc.CallOption) (*ReportConfigStatusResponse, error)
+	// RenewRunner registers the runner again for its next job in multi-job
+	// mode and returns its new JIT config.
+	RenewRunner(ctx context.Context, in *RenewRunnerRequest, opts ...grpc.CallOption) (*RenewRunnerResponse, error)
+	// ReportFailedJob reports that the job of the runner failed and that the
+	// agent keeps the VM running for debugging instead of powering it off. The
+	// server holds the VM until it expires, or returns an error if the VM must
+	// be powered off as usual.
+	ReportFailedJob(ctx context.Context, in *ReportFailedJobRequest, opts ...grpc.CallOption) (*ReportFailedJobResponse, error)
+}
+
+type hostServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHostServiceClient(cc grpc.ClientConnInterface) HostServiceClient {
+	return &hostServiceClient{cc}
+}
+
+func (c *hostServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, HostService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) ReportStateTransition(ctx context.Context, in *ReportStateTransitionRequest, opts ...grpc.CallOption) (*ReportStateTransitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportStateTransitionResponse)
+	err := c.cc.Invoke(ctx, HostService_ReportStateTransition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) ReportConfigStatus(ctx context.Context, in *ReportConfigStatusRequest, opts ...grpc.CallOption) (*ReportConfigStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportConfigStatusResponse)
+	err := c.cc.Invoke(ctx, HostService_ReportConfigStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) RenewRunner(ctx context.Context, in *RenewRunnerRequest, opts ...grpc.CallOption) (*RenewRunnerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewRunnerResponse)
+	err := c.cc.Invoke(ctx, HostService_RenewRunner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) ReportFailedJob(ctx context.Context, in *ReportFailedJobRequest, opts ...grpc.CallOption) (*ReportFailedJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportFailedJobResponse)
+	err := c.cc.Invoke(ctx, HostService_ReportFailedJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostServiceServer is the server API for HostService service.
+// All implementations must embed UnimplementedHostServiceServer
+// for forward compatibility.
+//
+// HostService is served by the server on the host for every VM. The agent
+// connects to it over vsock to push its state.
+type HostServiceServer interface {
+	// Heartbeat reports that the agent is alive, along with the current runner state.
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// ReportStateTransition reports a runner state transition as it happens.
+	ReportStateTransition(context.Context, *ReportStateTransitionRequest) (*ReportStateTransitionResponse, error)
+	// ReportConfigStatus reports whether the agent has consumed its
+	// configuration from MMDS, so the server can scrub sensitive values from
+	// it, and whether it waits for a runner JIT config, so the server can
+	// snapshot the VM. It is pushed whenever either changes.
+	ReportConfigStatus(context.Context, *ReportConfigStatusRequest) (*ReportConfigStatusResponse, error)
+	// RenewRunner registers the runner again for its next job in multi-job
+	// mode and returns its new JIT config.
+	RenewRunner(context.Context, *RenewRunnerRequest) (*RenewRunnerResponse, error)
+	// ReportFailedJob reports that the job of the runner failed and that the
+	// agent keeps the VM running for debugging instead of powering it off. The
+	// server holds the VM until it expires, or returns an error if the VM must
+	// be powered off as usual.
+	ReportFailedJob(context.Context, *ReportFailedJobRequest) (*ReportFailedJobResponse, error)
+	mustEmbedUnimplementedHostServiceServer()
+}
+
+// UnimplementedHostServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHostServiceServer struct{}
+
+func (UnimplementedHostServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedHostServiceServer) ReportStateTransition(context.Context, *ReportStateTransitionRequest) (*ReportStateTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportStateTransition not implemented")
+}
+func (UnimplementedHostServiceServer) ReportConfigStatus(context.Context, *ReportConfigStatusRequest) (*ReportConfigStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportConfigStatus not implemented")
+}
+func (UnimplementedHostServiceServer) RenewRunner(context.Context, *RenewRunnerRequest) (*RenewRunnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewRunner not implemented")
+}
+func (UnimplementedHostServiceServer) ReportFailedJob(context.Context, *ReportFailedJobRequest) (*ReportFailedJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportFailedJob not implemented")
+}
+func (UnimplementedHostServiceServer) mustEmbedUnimplementedHostServiceServer() {}
+func (UnimplementedHostServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeHostServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HostServiceServer will
+// result in compilation errors.
+type UnsafeHostServiceServer interface {
+	mustEmbedUnimplementedHostServiceServer()
+}
+
+func RegisterHostServiceServer(s grpc.ServiceRegistrar, srv HostServiceServer) {
+	// If the following call pancis, it indicates UnimplementedHostServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&HostService_ServiceDesc, srv)
+}
+
+func _HostService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_ReportStateTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportStateTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).ReportStateTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_ReportStateTransition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).ReportStateTransition(ctx, req.(*ReportStateTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_ReportConfigStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportConfigStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).ReportConfigStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_ReportConfigStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).ReportConfigStatus(ctx, req.(*ReportConfigStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_RenewRunner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRunnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).RenewRunner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_RenewRunner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).RenewRunner(ctx, req.(*RenewRunnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_ReportFailedJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportFailedJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).ReportFailedJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HostService_ReportFailedJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).ReportFailedJob(ctx, req.(*ReportFailedJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HostService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fireactions.agent.v1.HostService",
+	HandlerType: (*HostServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Heartbeat",
+			Handler:    _HostService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "ReportStateTransition",
+			Handler:    _HostService_ReportStateTransition_Handler,
+		},
+		{
+			MethodName: "ReportConfigStatus",
+			Handler:    _HostService_ReportConfigStatus_Handler,
+		},
+		{
+			MethodName: "RenewRunner",
+			Handler:    _HostService_RenewRunner_Handler,
+		},
+		{
+			MethodName: "ReportFailedJob",
+			Handler:    _HostService_ReportFailedJob_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/agent/v1/agent.proto",
 }
@@ -19,18 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServerService_ListPools_FullMethodName      = "/fireactions.server.v1.ServerService/ListPools"
-	ServerService_GetPool_FullMethodName        = "/fireactions.server.v1.ServerService/GetPool"
-	ServerService_ScalePool_FullMethodName      = "/fireactions.server.v1.ServerService/ScalePool"
-	ServerService_PausePool_FullMethodName      = "/fireactions.server.v1.ServerService/PausePool"
-	ServerService_ResumePool_FullMethodName     = "/fireactions.server.v1.ServerService/ResumePool"
-	ServerService_ListMachines_FullMethodName   = "/fireactions.server.v1.ServerService/ListMachines"
-	ServerService_GetMachine_FullMethodName     = "/fireactions.server.v1.ServerService/GetMachine"
-	ServerService_GetMachineLogs_FullMethodName = "/fireactions.server.v1.ServerService/GetMachineLogs"
-	ServerService_ListImages_FullMethodName     = "/fireactions.server.v1.ServerService/ListImages"
-	ServerService_RemoveImage_FullMethodName    = "/fireactions.server.v1.ServerService/RemoveImage"
-	ServerService_GetHealth_FullMethodName      = "/fireactions.server.v1.ServerService/GetHealth"
-	ServerService_GetVersion_FullMethodName     = "/fireactions.server.v1.ServerService/GetVersion"
+	ServerService_ListPools_FullMethodName             = "/fireactions.server.v1.ServerService/ListPools"
+	ServerService_GetPool_FullMethodName               = "/fireactions.server.v1.ServerService/GetPool"
+	ServerService_ScalePool_FullMethodName             = "/fireactions.server.v1.ServerService/ScalePool"
+	ServerService_PausePool_FullMethodName             = "/fireactions.server.v1.ServerService/PausePool"
+	ServerService_ResumePool_FullMethodName            = "/fireactions.server.v1.ServerService/ResumePool"
+	ServerService_ListMachines_FullMethodName          = "/fireactions.server.v1.ServerService/ListMachines"
+	ServerService_GetMachine_FullMethodName            = "/fireactions.server.v1.ServerService/GetMachine"
+	ServerService_GetMachineLogs_FullMethodName        = "/fireactions.server.v1.ServerService/GetMachineLogs"
+	ServerService_ListMachineLogSources_FullMethodName = "/fireactions.server.v1.ServerService/ListMachineLogSources"
+	ServerService_ExecMachine_FullMethodName           = "/fireactions.server.v1.ServerService/ExecMachine"
+	ServerService_CopyFromMachine_FullMethodName       = "/fireactions.server.v1.ServerService/CopyFromMachine"
+	ServerService_CopyToMachine_FullMethodName         = "/fireactions.server.v1.ServerService/CopyToMachine"
+	ServerService_GetMachineStats_FullMethodName       = "/fireactions.server.v1.ServerService/GetMachineStats"
+	ServerService_ListImages_FullMethodName            = "/fireactions.server.v1.ServerService/ListImages"
+	ServerService_RemoveImage_FullMethodName           = "/fireactions.server.v1.ServerService/RemoveImage"
+	ServerService_PullImage_FullMethodName             = "/fireactions.server.v1.ServerService/PullImage"
+	ServerService_InspectImage_FullMethodName          = "/fireactions.server.v1.ServerService/InspectImage"
+	ServerService_GetHealth_FullMethodName             = "/fireactions.server.v1.ServerService/GetHealth"
+	ServerService_GetVersion_FullMethodName            = "/fireactions.server.v1.ServerService/GetVersion"
+	ServerService_SetLogLevel_FullMethodName           = "/fireactions.server.v1.ServerService/SetLogLevel"
 )
 
 // ServerServiceClient is the client API for ServerService service.
@@ -47,10 +55,18 @@ type ServerServiceClient interface {
 	ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error)
 	GetMachine(ctx context.Context, in *GetMachineRequest, opts ...grpc.CallOption) (*GetMachineResponse, error)
 	GetMachineLogs(ctx context.Context, in *GetMachineLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetMachineLogsResponse], error)
+	ListMachineLogSources(ctx context.Context, in *ListMachineLogSourcesRequest, opts ...grpc.CallOption) (*ListMachineLogSourcesResponse, error)
+	ExecMachine(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecMachineRequest, ExecMachineResponse], error)
+	CopyFromMachine(ctx context.Context, in *CopyFromMachineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyFromMachineResponse], error)
+	CopyToMachine(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToMachineRequest, CopyToMachineResponse], error)
+	GetMachineStats(ctx context.Context, in *GetMachineStatsRequest, opts ...grpc.CallOption) (*GetMachineStatsResponse, error)
 	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
 	RemoveImage(ctx context.Context, in *RemoveImageRequest, opts ...grpc.CallOption) (*RemoveImageResponse, error)
+	PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PullImageResponse], error)
+	InspectImage(ctx context.Context, in *InspectImageRequest, opts ...grpc.CallOption) (*InspectImageResponse, error)
 	GetHealth(ctx context.Context, in *GetHealthRequest, opts ...grpc.CallOption) (*GetHealthResponse, error)
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
 }
 
 type serverServiceClient struct {
@@ -150,6 +166,71 @@ func (c *serverServiceClient) GetMachineLogs(ctx context.Context, in *GetMachine
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ServerService_GetMachineLogsClient = grpc.ServerStreamingClient[GetMachineLogsResponse]
 
+func (c *serverServiceClient) ListMachineLogSources(ctx context.Context, in *ListMachineLogSourcesRequest, opts ...grpc.CallOption) (*ListMachineLogSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMachineLogSourcesResponse)
+	err := c.cc.Invoke(ctx, ServerService_ListMachineLogSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) ExecMachine(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecMachineRequest, ExecMachineResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServerService_ServiceDesc.Streams[1], ServerService_ExecMachine_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecMachineRequest, ExecMachineResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_ExecMachineClient = grpc.BidiStreamingClient[ExecMachineRequest, ExecMachineResponse]
+
+func (c *serverServiceClient) CopyFromMachine(ctx context.Context, in *CopyFromMachineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CopyFromMachineResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServerService_ServiceDesc.Streams[2], ServerService_CopyFromMachine_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyFromMachineRequest, CopyFromMachineResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_CopyFromMachineClient = grpc.ServerStreamingClient[CopyFromMachineResponse]
+
+func (c *serverServiceClient) CopyToMachine(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CopyToMachineRequest, CopyToMachineResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServerService_ServiceDesc.Streams[3], ServerService_CopyToMachine_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CopyToMachineRequest, CopyToMachineResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_CopyToMachineClient = grpc.ClientStreamingClient[CopyToMachineRequest, CopyToMachineResponse]
+
+func (c *serverServiceClient) GetMachineStats(ctx context.Context, in *GetMachineStatsRequest, opts ...grpc.CallOption) (*GetMachineStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMachineStatsResponse)
+	err := c.cc.Invoke(ctx, ServerService_GetMachineStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverServiceClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListImagesResponse)
@@ -170,6 +251,35 @@ func (c *serverServiceClient) RemoveImage(ctx context.Context, in *RemoveImageRe
 	return out, nil
 }
 
+func (c *serverServiceClient) PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PullImageResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServerService_ServiceDesc.Streams[4], ServerService_PullImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PullImageRequest, PullImageResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_PullImageClient = grpc.ServerStreamingClient[PullImageResponse]
+
+func (c *serverServiceClient) InspectImage(ctx context.Context, in *InspectImageRequest, opts ...grpc.CallOption) (*InspectImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InspectImageResponse)
+	err := c.cc.Invoke(ctx, ServerService_InspectImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverServiceClient) GetHealth(ctx context.Context, in *GetHealthRequest, opts ...grpc.CallOption) (*GetHealthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetHealthResponse)
@@ -190,6 +300,16 @@ func (c *serverServiceClient) GetVersion(ctx context.Context, in *GetVersionRequ
 	return out, nil
 }
 
+func (c *serverServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, ServerService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServerServiceServer is the server API for ServerService service.
 // All implementations must embed UnimplementedServerServiceServer
 // for forward compatibility.
@@ -204,10 +324,18 @@ type ServerServiceServer interface {
 	ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error)
 	GetMachine(context.Context, *GetMachineRequest) (*GetMachineResponse, error)
 	GetMachineLogs(*GetMachineLogsRequest, grpc.ServerStreamingServer[GetMachineLogsResponse]) error
+	ListMachineLogSources(context.Context, *ListMachineLogSourcesRequest) (*ListMachineLogSourcesResponse, error)
+	ExecMachine(grpc.BidiStreamingServer[ExecMachineRequest, ExecMachineResponse]) error
+	CopyFromMachine(*CopyFromMachineRequest, grpc.ServerStreamingServer[CopyFromMachineResponse]) error
+	CopyToMachine(grpc.ClientStreamingServer[CopyToMachineRequest, CopyToMachineResponse]) error
+	GetMachineStats(context.Context, *GetMachineStatsRequest) (*GetMachineStatsResponse, error)
 	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
 	RemoveImage(context.Context, *RemoveImageRequest) (*RemoveImageResponse, error)
+	PullImage(*PullImageRequest, grpc.ServerStreamingServer[PullImageResponse]) error
+	InspectImage(context.Context, *InspectImageRequest) (*InspectImageResponse, error)
 	GetHealth(context.Context, *GetHealthRequest) (*GetHealthResponse, error)
 	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 	mustEmbedUnimplementedServerServiceServer()
 }
 
@@ -242,18 +370,42 @@ func (UnimplementedServerServiceServer) GetMachine(context.Context, *GetMachineR
 func (UnimplementedServerServiceServer) GetMachineLogs(*GetMachineLogsRequest, grpc.ServerStreamingServer[GetMachineLogsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetMachineLogs not implemented")
 }
+func (UnimplementedServerServiceServer) ListMachineLogSources(context.Context, *ListMachineLogSourcesRequest) (*ListMachineLogSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMachineLogSources not implemented")
+}
+func (UnimplementedServerServiceServer) ExecMachine(grpc.BidiStreamingServer[ExecMachineRequest, ExecMachineResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExecMachine not implemented")
+}
+func (UnimplementedServerServiceServer) CopyFromMachine(*CopyFromMachineRequest, grpc.ServerStreamingServer[CopyFromMachineResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyFromMachine not implemented")
+}
+func (UnimplementedServerServiceServer) CopyToMachine(grpc.ClientStreamingServer[CopyToMachineRequest, CopyToMachineResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CopyToMachine not implemented")
+}
+func (UnimplementedServerServiceServer) GetMachineStats(context.Context, *GetMachineStatsRequest) (*GetMachineStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMachineStats not implemented")
+}
 func (UnimplementedServerServiceServer) ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListImages not implemented")
 }
 func (UnimplementedServerServiceServer) RemoveImage(context.Context, *RemoveImageRequest) (*RemoveImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveImage not implemented")
 }
+func (UnimplementedServerServiceServer) PullImage(*PullImageRequest, grpc.ServerStreamingServer[PullImageResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PullImage not implemented")
+}
+func (UnimplementedServerServiceServer) InspectImage(context.Context, *InspectImageRequest) (*InspectImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectImage not implemented")
+}
 func (UnimplementedServerServiceServer) GetHealth(context.Context, *GetHealthRequest) (*GetHealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHealth not implemented")
 }
 func (UnimplementedServerServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
 }
+func (UnimplementedServerServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
 func (UnimplementedServerServiceServer) mustEmbedUnimplementedServerServiceServer() {}
 func (UnimplementedServerServiceServer) testEmbeddedByValue()                       {}
 
@@ -412,6 +564,67 @@ func _ServerService_GetMachineLogs_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ServerService_GetMachineLogsServer = grpc.ServerStreamingServer[GetMachineLogsResponse]
 
+func _ServerService_ListMachineLogSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMachineLogSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).ListMachineLogSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_ListMachineLogSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).ListMachineLogSources(ctx, req.(*ListMachineLogSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_ExecMachine_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServerServiceServer).ExecMachine(&grpc.GenericServerStream[ExecMachineRequest, ExecMachineResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_ExecMachineServer = grpc.BidiStreamingServer[ExecMachineRequest, ExecMachineResponse]
+
+func _ServerService_CopyFromMachine_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromMachineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerServiceServer).CopyFromMachine(m, &grpc.GenericServerStream[CopyFromMachineRequest, CopyFromMachineResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_CopyFromMachineServer = grpc.ServerStreamingServer[CopyFromMachineResponse]
+
+func _ServerService_CopyToMachine_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServerServiceServer).CopyToMachine(&grpc.GenericServerStream[CopyToMachineRequest, CopyToMachineResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_CopyToMachineServer = grpc.ClientStreamingServer[CopyToMachineRequest, CopyToMachineResponse]
+
+func _ServerService_GetMachineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMachineStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).GetMachineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_GetMachineStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).GetMachineStats(ctx, req.(*GetMachineStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerService_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListImagesRequest)
 	if err := dec(in); err != nil {
@@ -448,6 +661,35 @@ func _ServerService_RemoveImage_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_PullImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PullImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerServiceServer).PullImage(m, &grpc.GenericServerStream[PullImageRequest, PullImageResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServerService_PullImageServer = grpc.ServerStreamingServer[PullImageResponse]
+
+func _ServerService_InspectImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).InspectImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_InspectImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).InspectImage(ctx, req.(*InspectImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerService_GetHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetHealthRequest)
 	if err := dec(in); err != nil {
@@ -484,6 +726,24 @@ func _ServerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServerService_ServiceDesc is the grpc.ServiceDesc for ServerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -519,6 +779,14 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMachine",
 			Handler:    _ServerService_GetMachine_Handler,
 		},
+		{
+			MethodName: "ListMachineLogSources",
+			Handler:    _ServerService_ListMachineLogSources_Handler,
+		},
+		{
+			MethodName: "GetMachineStats",
+			Handler:    _ServerService_GetMachineStats_Handler,
+		},
 		{
 			MethodName: "ListImages",
 			Handler:    _ServerService_ListImages_Handler,
@@ -527,6 +795,10 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveImage",
 			Handler:    _ServerService_RemoveImage_Handler,
 		},
+		{
+			MethodName: "InspectImage",
+			Handler:    _ServerService_InspectImage_Handler,
+		},
 		{
 			MethodName: "GetHealth",
 			Handler:    _ServerService_GetHealth_Handler,
@@ -535,6 +807,10 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVersion",
 			Handler:    _ServerService_GetVersion_Handler,
 		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _ServerService_SetLogLevel_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -542,6 +818,27 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ServerService_GetMachineLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExecMachine",
+			Handler:       _ServerService_ExecMachine_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFromMachine",
+			Handler:       _ServerService_CopyFromMachine_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyToMachine",
+			Handler:       _ServerService_CopyToMachine_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PullImage",
+			Handler:       _ServerService_PullImage_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/server/v1/server.proto",
 }
@@ -3,33 +3,97 @@ package server
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	defaultSnapshotter = "devmapper"
+)
+
 // Config is the configuration for the Client.
 type Config struct {
-	BindAddress      string            `yaml:"bind_address" validate:"required,hostname_port"`
-	Containerd       *ContainerdConfig `yaml:"containerd" validate:"required"`
-	Metrics          *MetricsConfig    `yaml:"metrics"`
-	BasicAuthEnabled bool              `yaml:"basic_auth_enabled" validate:""`
-	BasicAuthUsers   map[string]string `yaml:"basic_auth_users" validate:"required_if=basic_auth_enabled true"`
-	GitHub           *GitHubConfig     `yaml:"github" validate:"required"`
-	Pools            []*PoolConfig     `yaml:"pools" validate:"required,min=1"`
-	LogLevel         string            `yaml:"log_level" validate:"required,oneof=debug info warn error fatal panic trace"`
+	BindAddress      string                     `yaml:"bind_address" validate:"required,hostname_port"`
+	Containerd       *ContainerdConfig          `yaml:"containerd" validate:"required"`
+	Rootfs           *RootfsConfig              `yaml:"rootfs" validate:"required"`
+	Images           *ImagesConfig              `yaml:"images" validate:"required"`
+	Registries       map[string]*RegistryConfig `yaml:"registries" validate:"dive"`
+	Heartbeat        *HeartbeatConfig           `yaml:"heartbeat" validate:"required"`
+	Metrics          *MetricsConfig             `yaml:"metrics"`
+	BasicAuthEnabled bool                       `yaml:"basic_auth_enabled" validate:""`
+	BasicAuthUsers   map[string]string          `yaml:"basic_auth_users" validate:"required_if=basic_auth_enabled true"`
+	GitHub           *GitHubConfig              `yaml:"github" validate:"required"`
+	Pools            []*PoolConfig              `yaml:"pools" validate:"required,min=1"`
+	LogLevel         string                     `yaml:"log_level" validate:"required,oneof=debug info warn error fatal panic trace"`
+	LogFormat        string                     `yaml:"log_format" validate:"required,oneof=console json"`
+	LogFile          *LogFileConfig             `yaml:"log_file" validate:"required"`
+
+	// ShutdownTimeout bounds how long the server takes to stop. When zero, it
+	// is derived from the longest shutdown grace period of the pools, see
+	// shutdownTimeout.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" validate:"min=0"`
 
 	path string
 }
 
 type ContainerdConfig struct {
-	Address   string `yaml:"address" validate:"required"`
-	Namespace string `yaml:"namespace" validate:"required"`
+	Address     string `yaml:"address" validate:"required"`
+	Namespace   string `yaml:"namespace" validate:"required"`
+	Snapshotter string `yaml:"snapshotter" validate:"required"`
+}
+
+// RootfsConfig configures how root drives are prepared for Firecracker VMs.
+//
+// The "snapshotter" backend hands the block device of a containerd snapshot
+// directly to Firecracker and therefore requires a block-based snapshotter
+// such as devmapper. The "file" backend converts the unpacked image into an
+// ext4 image once per image digest and gives every VM a copy-on-write clone
+// of it, which works with any snapshotter (e.g. overlayfs) on plain hosts.
+type RootfsConfig struct {
+	Backend string `yaml:"backend" validate:"required,oneof=snapshotter file"`
+	Dir     string `yaml:"dir" validate:"required_if=Backend file"`
+	SizeMib int64  `yaml:"size_mib" validate:"required_if=Backend file,min=0"`
+}
+
+// ImagesConfig configures how the server keeps pool images up to date.
+type ImagesConfig struct {
+	// RefreshInterval is how often image tags of pools with the Always pull
+	// policy are resolved to a digest. New digests are pulled ahead of time
+	// and new machines are switched to them once the pull completes.
+	RefreshInterval time.Duration `yaml:"refresh_interval" validate:"min=0"`
+
+	// KernelDir is the directory the kernels of kernel images are extracted
+	// to, one subdirectory per image digest.
+	KernelDir string `yaml:"kernel_dir" validate:"required"`
+
+	GC *ImageGCConfig `yaml:"gc" validate:"required"`
+}
+
+// ImageGCConfig configures the removal of images that are no longer
+// referenced by any pool nor used by any live machine.
+type ImageGCConfig struct {
+	Enabled              bool          `yaml:"enabled" validate:""`
+	Interval             time.Duration `yaml:"interval" validate:"required_if=Enabled true"`
+	GracePeriod          time.Duration `yaml:"grace_period" validate:"min=0"`
+	HighWaterMarkPercent int           `yaml:"high_water_mark_percent" validate:"min=0,max=100"`
+	Path                 string        `yaml:"path" validate:"required_if=Enabled true"`
+	DryRun               bool          `yaml:"dry_run" validate:""`
+}
+
+// LogFileConfig configures writing logs to a file instead of stdout. The file
+// is rotated once it reaches MaxSizeMB, keeping MaxBackups rotated files.
+type LogFileConfig struct {
+	Path       string `yaml:"path"` // Empty logs to stdout
+	MaxSizeMB  int64  `yaml:"max_size_mb" validate:"min=0"`
+	MaxBackups int    `yaml:"max_backups" validate:"min=0"`
 }
 
 type MetricsConfig struct {
-	Enabled bool   `yaml:"enabled" validate:""`
-	Address string `yaml:"address" validate:"required_if=enabled true,hostname_port"`
+	Enabled       bool          `yaml:"enabled" validate:""`
+	Address       string        `yaml:"address" validate:"required_if=enabled true,hostname_port"`
+	StatsInterval time.Duration `yaml:"stats_interval" validate:"gte=0"` // 0 disables scraping machine stats
 }
 
 type GitHubConfig struct {
@@ -44,14 +108,197 @@ type RunnerConfig struct {
 	Organization    string   `yaml:"organization" validate:"required"`
 	GroupID         int64    `yaml:"group_id" validate:"required"`
 	Labels          []string `yaml:"labels" validate:"required"`
+
+	ImageVerification *ImageVerificationConfig `yaml:"image_verification"`
+
+	// Layout of the runner in the image and how the agent runs it. Empty
+	// values fall back to the agent defaults: /opt/runner, runner and docker.
+	Dir   string            `yaml:"dir" validate:"omitempty,startswith=/"`
+	User  string            `yaml:"user"`
+	Group string            `yaml:"group"`
+	Env   map[string]string `yaml:"env" validate:"dive,keys,required,excludesall==,endkeys"`
+	Args  []string          `yaml:"args"` // Extra run.sh arguments
+}
+
+// metadata returns the settings of the runner the agent needs, or nil if
+// none are set.
+func (r *RunnerConfig) metadata() map[string]interface{} {
+	runner := make(map[string]interface{})
+	for key, value := range map[string]string{"dir": r.Dir, "user": r.User, "group": r.Group} {
+		if value != "" {
+			runner[key] = value
+		}
+	}
+
+	if len(r.Env) > 0 {
+		runner["env"] = r.Env
+	}
+
+	if len(r.Args) > 0 {
+		runner["args"] = r.Args
+	}
+
+	if len(runner) == 0 {
+		return nil
+	}
+
+	return runner
+}
+
+// HeartbeatConfig represents the heartbeats agents push to the server. A
+// machine is unhealthy if its agent was not seen within the timeout.
+type HeartbeatConfig struct {
+	Interval time.Duration `yaml:"interval" validate:"required,gt=0"`
+	Timeout  time.Duration `yaml:"timeout" validate:"required,gtfield=Interval"`
+}
+
+// RegistryConfig represents the configuration of a container registry, keyed
+// by registry host (e.g. ghcr.io). Secrets are read from files or environment
+// variables, never from the configuration itself.
+type RegistryConfig struct {
+	Username     string   `yaml:"username" validate:"required_with=PasswordFile PasswordEnv"`
+	PasswordFile string   `yaml:"password_file" validate:"excluded_with=PasswordEnv IdentityTokenFile IdentityTokenEnv"`
+	PasswordEnv  string   `yaml:"password_env" validate:"excluded_with=PasswordFile IdentityTokenFile IdentityTokenEnv"`
+	Mirrors      []string `yaml:"mirrors" validate:"dive,url"`
+	Insecure     bool     `yaml:"insecure"`
+	PlainHTTP    bool     `yaml:"plain_http"`
+
+	// The identity token is an OAuth2 refresh token exchanged for an access
+	// token at the token server of the registry, like the identitytoken of
+	// the docker config. Registries taking a token as a password, e.g.
+	// personal access tokens, are configured with a username and password.
+	IdentityTokenFile string `yaml:"identity_token_file" validate:"excluded_with=Username IdentityTokenEnv"`
+	IdentityTokenEnv  string `yaml:"identity_token_env" validate:"excluded_with=Username IdentityTokenFile"`
+}
+
+// SecretConfig represents a secret delivered to the VMs of a pool. The value
+// is read from the file or environment variable when a VM is created and is
+// removed from MMDS once the agent has installed it.
+type SecretConfig struct {
+	Name   string `yaml:"name" validate:"required,excludesall=/= "`
+	File   string `yaml:"file" validate:"required_without=Env,excluded_with=Env"`
+	Env    string `yaml:"env" validate:"required_without=File,excluded_with=File"`
+	Target string `yaml:"target" validate:"required,oneof=env file"`
+}
+
+// UnmarshalYAML implements custom unmarshaling to set defaults.
+func (s *SecretConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type secretConfigAlias SecretConfig
+	defaults := secretConfigAlias{
+		Target: "file",
+	}
+
+	if err := unmarshal(&defaults); err != nil {
+		return err
+	}
+
+	*s = SecretConfig(defaults)
+	return nil
+}
+
+// HooksConfig represents the hook scripts run in the VMs of a pool. Hooks
+// not set here fall back to the scripts baked into the image under
+// /etc/fireactions/hooks/<name>.
+type HooksConfig struct {
+	PreStart     string `yaml:"pre_start"`
+	JobStarted   string `yaml:"job_started"`
+	JobCompleted string `yaml:"job_completed"`
+	PostExit     string `yaml:"post_exit"`
+}
+
+// metadata returns the hooks that are set, keyed by hook name.
+func (h *HooksConfig) metadata() map[string]interface{} {
+	hooks := make(map[string]interface{})
+	for name, script := range map[string]string{
+		"pre_start":     h.PreStart,
+		"job_started":   h.JobStarted,
+		"job_completed": h.JobCompleted,
+		"post_exit":     h.PostExit,
+	} {
+		if script != "" {
+			hooks[name] = script
+		}
+	}
+
+	return hooks
+}
+
+// MultiJobConfig configures the VMs of a pool to run several jobs one after
+// the other instead of a single one. Between jobs the agent cleans up the
+// workspace and the runner is registered again with a new JIT config.
+type MultiJobConfig struct {
+	MaxJobs      int           `yaml:"max_jobs" validate:"required,min=1"`
+	MaxLifetime  time.Duration `yaml:"max_lifetime" validate:"min=0"` // 0 for no limit
+	CleanupPaths []string      `yaml:"cleanup_paths" validate:"dive,startswith=/"`
+}
+
+// metadata returns the settings the agent needs.
+func (m *MultiJobConfig) metadata() map[string]interface{} {
+	metadata := map[string]interface{}{
+		"max_jobs":     m.MaxJobs,
+		"max_lifetime": m.MaxLifetime.Seconds(),
+	}
+
+	if len(m.CleanupPaths) > 0 {
+		metadata["cleanup_paths"] = m.CleanupPaths
+	}
+
+	return metadata
+}
+
+// KeepOnFailureConfig configures the VMs of a pool to be kept after a failed
+// job for debugging. Kept VMs are held: they are replaced by a new VM and
+// deleted once the TTL expired. At most MaxHeld VMs of the pool are held at
+// once. Failed jobs are reported by the guest, which can therefore force a
+// hold.
+type KeepOnFailureConfig struct {
+	TTL     time.Duration `yaml:"ttl" validate:"required,gt=0"`
+	MaxHeld int           `yaml:"max_held" validate:"min=1"`
+}
+
+// UnmarshalYAML implements custom unmarshaling to set defaults.
+func (k *KeepOnFailureConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type keepOnFailureConfigAlias KeepOnFailureConfig
+	defaults := keepOnFailureConfigAlias{
+		MaxHeld: 1,
+	}
+
+	if err := unmarshal(&defaults); err != nil {
+		return err
+	}
+
+	*k = KeepOnFailureConfig(defaults)
+	return nil
+}
+
+// AgentConfig configures the agent in the VMs of a pool.
+type AgentConfig struct {
+	LogFormat string `yaml:"log_format" validate:"omitempty,oneof=console json"` // Empty for console
+}
+
+// metadata returns the settings of the agent, or nil if none are set.
+func (a *AgentConfig) metadata() map[string]interface{} {
+	if a.LogFormat == "" {
+		return nil
+	}
+
+	return map[string]interface{}{"log_format": a.LogFormat}
+}
+
+// ImageVerificationConfig represents the signature verification of runner
+// images. Images must carry a cosign signature made with one of the keys.
+type ImageVerificationConfig struct {
+	PublicKeys []string `yaml:"public_keys" validate:"required,min=1,dive,required"`
 }
 
 type FirecrackerConfig struct {
-	BinaryPath      string                   `yaml:"binary_path" `
-	KernelImagePath string                   `yaml:"kernel_image_path"`
-	KernelArgs      string                   `yaml:"kernel_args"`
-	MachineConfig   FirecrackerMachineConfig `yaml:"machine_config"`
-	Metadata        map[string]interface{}   `yaml:"metadata"`
+	BinaryPath            string                   `yaml:"binary_path" `
+	KernelImagePath       string                   `yaml:"kernel_image_path" validate:"required_without=KernelImage"`
+	KernelImage           string                   `yaml:"kernel_image" validate:"excluded_with=KernelImagePath"`
+	KernelImagePullPolicy string                   `yaml:"kernel_image_pull_policy" validate:"required_with=KernelImage,omitempty,oneof=Always Never IfNotPresent"`
+	KernelArgs            string                   `yaml:"kernel_args"`
+	MachineConfig         FirecrackerMachineConfig `yaml:"machine_config"`
+	Metadata              map[string]interface{}   `yaml:"metadata"`
 }
 
 type FirecrackerMachineConfig struct {
@@ -63,13 +310,18 @@ type FirecrackerMachineConfig struct {
 func DefaultConfig() *Config {
 	c := &Config{
 		BindAddress:      ":8080",
-		Containerd:       &ContainerdConfig{Address: "/run/containerd/containerd.sock", Namespace: "fireactions"},
-		Metrics:          &MetricsConfig{Enabled: true, Address: ":8081"},
+		Containerd:       &ContainerdConfig{Address: "/run/containerd/containerd.sock", Namespace: "fireactions", Snapshotter: defaultSnapshotter},
+		Rootfs:           &RootfsConfig{Backend: "snapshotter", Dir: "/var/lib/fireactions/rootfs", SizeMib: 10240},
+		Images:           &ImagesConfig{RefreshInterval: 5 * time.Minute, KernelDir: "/var/lib/fireactions/kernels", GC: &ImageGCConfig{Enabled: false, Interval: 10 * time.Minute, GracePeriod: 24 * time.Hour, HighWaterMarkPercent: 85, Path: "/var/lib/containerd"}},
+		Heartbeat:        &HeartbeatConfig{Interval: 10 * time.Second, Timeout: 30 * time.Second},
+		Metrics:          &MetricsConfig{Enabled: true, Address: ":8081", StatsInterval: 30 * time.Second},
 		BasicAuthEnabled: false,
 		BasicAuthUsers:   map[string]string{},
 		GitHub:           &GitHubConfig{AppPrivateKey: "", AppID: 0},
 		Pools:            []*PoolConfig{},
 		LogLevel:         "debug",
+		LogFormat:        "console",
+		LogFile:          &LogFileConfig{Path: "", MaxSizeMB: 100, MaxBackups: 5},
 	}
 
 	return c
@@ -109,5 +361,37 @@ func (c *Config) Load() error {
 
 // Validate validates the configuration.
 func (c *Config) Validate() error {
-	return validator.New().Struct(c)
+	err := validator.New().Struct(c)
+	if err != nil {
+		return err
+	}
+
+	if c.ShutdownTimeout == 0 {
+		return nil
+	}
+
+	for _, pool := range c.Pools {
+		if pool.ShutdownGracePeriod > c.ShutdownTimeout-stopCleanupTimeout {
+			return fmt.Errorf("pool %s: shutdown_grace_period (%s) must be at most shutdown_timeout (%s) minus %s", pool.Name, pool.ShutdownGracePeriod, c.ShutdownTimeout, stopCleanupTimeout)
+		}
+	}
+
+	return nil
+}
+
+// shutdownTimeout returns how long the server takes at most to stop. It
+// is ShutdownTimeout if set, long enough otherwise for the VMs of every pool
+// to complete their job within the shutdown grace period, power off and be
+// cleaned up.
+func (c *Config) shutdownTimeout() time.Duration {
+	if c.ShutdownTimeout > 0 {
+		return c.ShutdownTimeout
+	}
+
+	var gracePeriod time.Duration
+	for _, pool := range c.Pools {
+		gracePeriod = max(gracePeriod, pool.ShutdownGracePeriod)
+	}
+
+	return gracePeriod + shutdownTimeoutMargin + stopCleanupTimeout
 }
@@ -2,8 +2,12 @@ package server
 
 import (
 	"testing"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -14,3 +18,219 @@ func TestNewConfig(t *testing.T) {
 
 	assert.Equal(t, "testdata/config1.yaml", config.path)
 }
+
+func TestNewConfig_RootfsDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "devmapper", config.Containerd.Snapshotter)
+	assert.Equal(t, "snapshotter", config.Rootfs.Backend)
+}
+
+func TestFirecrackerConfig_Kernel(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  FirecrackerConfig
+		wantErr bool
+	}{
+		{"path", FirecrackerConfig{KernelImagePath: "/var/lib/fireactions/vmlinux"}, false},
+		{"image", FirecrackerConfig{KernelImage: "ghcr.io/example/kernel:6.1", KernelImagePullPolicy: "IfNotPresent"}, false},
+		{"image without pull policy", FirecrackerConfig{KernelImage: "ghcr.io/example/kernel:6.1"}, true},
+		{"path and image", FirecrackerConfig{KernelImagePath: "/vmlinux", KernelImage: "ghcr.io/example/kernel:6.1", KernelImagePullPolicy: "Always"}, true},
+		{"neither", FirecrackerConfig{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.New().Struct(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewConfig_ImagesDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, 5*time.Minute, config.Images.RefreshInterval)
+	assert.Equal(t, "/var/lib/fireactions/kernels", config.Images.KernelDir)
+}
+
+func TestNewConfig_HeartbeatDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, 10*time.Second, config.Heartbeat.Interval)
+	assert.Equal(t, 30*time.Second, config.Heartbeat.Timeout)
+}
+
+func TestNewConfig_MetricsDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, 30*time.Second, config.Metrics.StatsInterval)
+}
+
+func TestNewConfig_LogDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "console", config.LogFormat)
+	assert.Equal(t, "", config.LogFile.Path)
+	assert.Equal(t, int64(100), config.LogFile.MaxSizeMB)
+	assert.Equal(t, 5, config.LogFile.MaxBackups)
+}
+
+func TestNewConfig_ShutdownTimeoutDefault(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Zero(t, config.ShutdownTimeout)
+	assert.Equal(t, 5*time.Minute+50*time.Second, config.shutdownTimeout(), "derived from the shutdown grace period of the pool")
+}
+
+func TestConfig_ShutdownTimeout(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	require.NoError(t, err)
+
+	config.Pools = append(config.Pools, &PoolConfig{Name: "slow", ShutdownGracePeriod: 10 * time.Minute})
+	assert.Equal(t, 10*time.Minute+50*time.Second, config.shutdownTimeout(), "the longest shutdown grace period is used")
+
+	config.ShutdownTimeout = 90 * time.Second
+	assert.Equal(t, 90*time.Second, config.shutdownTimeout())
+}
+
+func TestConfig_ValidateShutdownTimeout(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	require.NoError(t, err)
+
+	config.ShutdownTimeout = 80 * time.Second
+	for _, pool := range config.Pools {
+		pool.ShutdownGracePeriod = time.Minute
+	}
+	assert.NoError(t, config.Validate())
+
+	config.Pools[0].ShutdownGracePeriod = time.Minute + time.Second
+	assert.ErrorContains(t, config.Validate(), "shutdown_grace_period")
+}
+
+func TestNewConfig_PoolDefaults(t *testing.T) {
+	config, err := NewConfig("testdata/config1.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pool := config.Pools[0]
+	assert.True(t, *pool.ShutdownOnExit)
+	assert.Equal(t, 5*time.Minute, pool.ShutdownGracePeriod)
+}
+
+func TestHeartbeatConfig_Validate(t *testing.T) {
+	assert.NoError(t, validator.New().Struct(HeartbeatConfig{Interval: time.Second, Timeout: 3 * time.Second}))
+	assert.Error(t, validator.New().Struct(HeartbeatConfig{Interval: 3 * time.Second, Timeout: time.Second}))
+	assert.Error(t, validator.New().Struct(HeartbeatConfig{Timeout: time.Second}))
+}
+
+func TestRunnerConfig_Metadata(t *testing.T) {
+	runner := &RunnerConfig{Name: "runner"}
+	assert.Nil(t, runner.metadata())
+
+	runner = &RunnerConfig{
+		Name: "runner",
+		Dir:  "/home/runner/actions-runner",
+		User: "builder",
+		Env:  map[string]string{"RUNNER_TOOL_CACHE": "/cache"},
+		Args: []string{"--once"},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"dir":  "/home/runner/actions-runner",
+		"user": "builder",
+		"env":  map[string]string{"RUNNER_TOOL_CACHE": "/cache"},
+		"args": []string{"--once"},
+	}, runner.metadata())
+}
+
+func TestRunnerConfig_Validate(t *testing.T) {
+	runner := RunnerConfig{Name: "runner", ImagePullPolicy: "Always", Image: "runner:latest", Organization: "org", GroupID: 1, Labels: []string{"self-hosted"}}
+	assert.NoError(t, validator.New().Struct(runner))
+
+	runner.Dir = "actions-runner"
+	assert.Error(t, validator.New().Struct(runner), "the directory must be absolute")
+
+	runner.Dir = ""
+	runner.Env = map[string]string{"A=B": "C"}
+	assert.Error(t, validator.New().Struct(runner), "env names must not contain =")
+
+	runner.Env = map[string]string{"": "C"}
+	assert.Error(t, validator.New().Struct(runner))
+}
+
+func TestMultiJobConfig_Metadata(t *testing.T) {
+	multiJob := &MultiJobConfig{MaxJobs: 10, MaxLifetime: time.Hour}
+	assert.Equal(t, map[string]interface{}{
+		"max_jobs":     10,
+		"max_lifetime": 3600.0,
+	}, multiJob.metadata())
+
+	multiJob.CleanupPaths = []string{"/home/runner/.docker"}
+	assert.Equal(t, []string{"/home/runner/.docker"}, multiJob.metadata()["cleanup_paths"])
+}
+
+func TestMultiJobConfig_Validate(t *testing.T) {
+	assert.NoError(t, validator.New().Struct(MultiJobConfig{MaxJobs: 10, CleanupPaths: []string{"/home/runner/.cache/*"}}))
+	assert.Error(t, validator.New().Struct(MultiJobConfig{}), "max_jobs is required")
+	assert.Error(t, validator.New().Struct(MultiJobConfig{MaxJobs: 10, MaxLifetime: -time.Hour}))
+	assert.Error(t, validator.New().Struct(MultiJobConfig{MaxJobs: 10, CleanupPaths: []string{".cache"}}), "cleanup paths must be absolute")
+}
+
+func TestPoolConfig_ValidateRestoreBetweenJobs(t *testing.T) {
+	pool := PoolConfig{
+		Name:               "default",
+		Runner:             &RunnerConfig{Name: "runner", ImagePullPolicy: "Always", Image: "runner:latest", Organization: "org", GroupID: 1, Labels: []string{"self-hosted"}},
+		Firecracker:        &FirecrackerConfig{BinaryPath: "firecracker", KernelImagePath: "vmlinux", MachineConfig: FirecrackerMachineConfig{VcpuCount: 1, MemSizeMib: 1024}},
+		RestoreBetweenJobs: true,
+	}
+	assert.NoError(t, validator.New().Struct(pool))
+
+	pool.MultiJob = &MultiJobConfig{MaxJobs: 10}
+	assert.Error(t, validator.New().Struct(pool), "multi_job and restore_between_jobs are exclusive")
+}
+
+func TestKeepOnFailureConfig_Validate(t *testing.T) {
+	assert.NoError(t, validator.New().Struct(KeepOnFailureConfig{TTL: 2 * time.Hour, MaxHeld: 1}))
+	assert.Error(t, validator.New().Struct(KeepOnFailureConfig{MaxHeld: 1}), "ttl is required")
+	assert.Error(t, validator.New().Struct(KeepOnFailureConfig{TTL: -time.Hour, MaxHeld: 1}))
+	assert.Error(t, validator.New().Struct(KeepOnFailureConfig{TTL: 2 * time.Hour}), "at least one VM is held")
+
+	var config KeepOnFailureConfig
+	require.NoError(t, yaml.Unmarshal([]byte("ttl: 2h"), &config))
+	assert.Equal(t, 1, config.MaxHeld)
+}
+
+func TestAgentConfig_Metadata(t *testing.T) {
+	agent := &AgentConfig{}
+	assert.Nil(t, agent.metadata())
+	assert.NoError(t, validator.New().Struct(agent))
+
+	agent.LogFormat = "json"
+	assert.Equal(t, map[string]interface{}{"log_format": "json"}, agent.metadata())
+
+	agent.LogFormat = "text"
+	assert.Error(t, validator.New().Struct(agent))
+}
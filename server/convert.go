@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/containerd/containerd"
+	"github.com/containerd/platforms"
 	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
 	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -30,39 +31,48 @@ func convertPoolToProto(ctx context.Context, pool *Pool) *serverv1.Pool {
 	}
 }
 
-func convertMachineToProto(ctx context.Context, machine *Machine) *serverv1.Machine {
-	m := &serverv1.Machine{
-		ID:        machine.Name,
-		Pool:      machine.Pool,
-		Addr:      machine.GetAddr(),
-		CreatedAt: timestamppb.New(machine.CreatedAt),
+func convertMachineToProto(machine *Machine) *serverv1.Machine {
+	runnerState, runnerVersion, lastSeen := machine.status()
+	if runnerState == "" {
+		runnerState = "Unknown"
+	}
+
+	if runnerVersion == "" {
+		runnerVersion = "Unknown"
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	m := &serverv1.Machine{
+		ID:            machine.Name,
+		Pool:          machine.Pool,
+		Addr:          machine.GetAddr(),
+		CreatedAt:     timestamppb.New(machine.CreatedAt),
+		ImageDigest:   machine.ImageDigest,
+		KernelDigest:  machine.KernelDigest,
+		RunnerState:   runnerState,
+		RunnerVersion: runnerVersion,
+		Healthy:       machine.isHealthy(time.Now()),
+		Jobs:          machine.jobCount(),
+	}
 
-	conn, client, err := machine.ConnectToGuestAgent(ctx)
-	if err != nil {
-		m.RunnerState = "Unknown"
-		m.RunnerVersion = "Unknown"
-		return m
+	if !lastSeen.IsZero() {
+		m.LastSeenAt = timestamppb.New(lastSeen)
 	}
-	defer conn.Close()
 
-	runnerStateResp, err := client.GetRunnerState(
-		ctx, &agentv1.GetRunnerStateRequest{})
-	if err != nil {
-		m.RunnerState = "Unknown"
-	} else {
-		m.RunnerState = runnerStateResp.GetState()
+	if heldUntil := machine.holdExpiry(); !heldUntil.IsZero() {
+		m.HeldUntil = timestamppb.New(heldUntil)
 	}
 
-	runnerVersionResp, err := client.GetRunnerVersion(
-		ctx, &agentv1.GetRunnerVersionRequest{})
-	if err != nil {
-		m.RunnerVersion = "Unknown"
-	} else {
-		m.RunnerVersion = runnerVersionResp.GetVersion()
+	if job := machine.currentJob(); job != nil {
+		m.Job = &serverv1.Job{
+			Name:        job.GetName(),
+			Repository:  job.GetRepository(),
+			Workflow:    job.GetWorkflow(),
+			RunId:       job.GetRunId(),
+			RunAttempt:  job.GetRunAttempt(),
+			StartedAt:   job.GetStartedAt(),
+			CompletedAt: job.GetCompletedAt(),
+			Result:      job.GetResult(),
+		}
 	}
 
 	return m
@@ -73,11 +83,158 @@ func convertImageToProto(ctx context.Context, img containerd.Image) *serverv1.Im
 	size, _ := img.Size(ctx)
 	createdAt := img.Metadata().CreatedAt
 
+	imageType := img.Labels()[imageTypeLabel]
+	if imageType == "" {
+		imageType = imageTypeRootfs
+	}
+
 	i := &serverv1.Image{
 		Name:      img.Name(),
 		Size:      size,
 		CreatedAt: timestamppb.New(createdAt),
+		Type:      imageType,
 	}
 
 	return i
 }
+
+// convertPullProgressToProto converts the progress of a pull to its protobuf representation.
+func convertPullProgressToProto(layers []layerProgress, resolved bool) *serverv1.PullImageResponse {
+	resp := &serverv1.PullImageResponse{
+		Status: serverv1.PullImageStatus_PULL_IMAGE_STATUS_RESOLVING,
+		Layers: make([]*serverv1.LayerProgress, len(layers)),
+	}
+
+	if resolved {
+		resp.Status = serverv1.PullImageStatus_PULL_IMAGE_STATUS_UNPACKING
+	}
+
+	for i, layer := range layers {
+		layerStatus := serverv1.LayerStatus_LAYER_STATUS_WAITING
+		switch layer.Status {
+		case layerStatusDownloading:
+			layerStatus = serverv1.LayerStatus_LAYER_STATUS_DOWNLOADING
+		case layerStatusDone:
+			layerStatus = serverv1.LayerStatus_LAYER_STATUS_DONE
+		}
+
+		if resolved && layerStatus != serverv1.LayerStatus_LAYER_STATUS_DONE {
+			resp.Status = serverv1.PullImageStatus_PULL_IMAGE_STATUS_DOWNLOADING
+		}
+
+		resp.Layers[i] = &serverv1.LayerProgress{
+			Digest:    layer.Desc.Digest.String(),
+			MediaType: layer.Desc.MediaType,
+			Status:    layerStatus,
+			Offset:    layer.Offset,
+			Total:     layer.Total,
+		}
+	}
+
+	if resolved && len(layers) == 0 {
+		resp.Status = serverv1.PullImageStatus_PULL_IMAGE_STATUS_DOWNLOADING
+	}
+
+	return resp
+}
+
+// convertImageDetailsToProto converts an inspected image to its protobuf representation.
+func convertImageDetailsToProto(ctx context.Context, img containerd.Image, details *imageDetails) *serverv1.InspectImageResponse {
+	resp := &serverv1.InspectImageResponse{
+		Image:    convertImageToProto(ctx, img),
+		Digest:   img.Target().Digest.String(),
+		Unpacked: details.Unpacked,
+	}
+
+	for _, platform := range details.Platforms {
+		resp.Platforms = append(resp.Platforms, platforms.Format(platform))
+	}
+
+	for _, layer := range details.Layers {
+		resp.Layers = append(resp.Layers, &serverv1.ImageLayer{
+			Digest:    layer.Digest.String(),
+			MediaType: layer.MediaType,
+			Size:      layer.Size,
+		})
+	}
+
+	return resp
+}
+
+// convertLogSourceToProto converts a log source reported by an agent to its
+// protobuf representation.
+func convertLogSourceToProto(source *agentv1.LogSource) *serverv1.LogSource {
+	s := &serverv1.LogSource{Name: source.GetName(), Description: source.GetDescription()}
+	for _, file := range source.GetFiles() {
+		s.Files = append(s.Files, &serverv1.LogFile{
+			Path:       file.GetPath(),
+			Size:       file.GetSize(),
+			ModifiedAt: file.GetModifiedAt(),
+		})
+	}
+
+	return s
+}
+
+// convertStatsToProto converts the stats reported by an agent to their
+// protobuf representation.
+func convertStatsToProto(stats *agentv1.GetStatsResponse) *serverv1.GetMachineStatsResponse {
+	resp := &serverv1.GetMachineStatsResponse{
+		Time: stats.GetTime(),
+		Cpu: &serverv1.CPUStats{
+			Count:        stats.GetCpu().GetCount(),
+			UsagePercent: stats.GetCpu().GetUsagePercent(),
+		},
+		Load: &serverv1.LoadStats{
+			Load1:  stats.GetLoad().GetLoad1(),
+			Load5:  stats.GetLoad().GetLoad5(),
+			Load15: stats.GetLoad().GetLoad15(),
+		},
+		Memory: &serverv1.MemoryStats{
+			Total:     stats.GetMemory().GetTotal(),
+			Available: stats.GetMemory().GetAvailable(),
+			Used:      stats.GetMemory().GetUsed(),
+			Buffers:   stats.GetMemory().GetBuffers(),
+			Cached:    stats.GetMemory().GetCached(),
+			SwapTotal: stats.GetMemory().GetSwapTotal(),
+			SwapUsed:  stats.GetMemory().GetSwapUsed(),
+		},
+	}
+
+	for _, disk := range stats.GetDisks() {
+		resp.Disks = append(resp.Disks, &serverv1.DiskStats{
+			Mountpoint: disk.GetMountpoint(),
+			Device:     disk.GetDevice(),
+			Fstype:     disk.GetFstype(),
+			Total:      disk.GetTotal(),
+			Used:       disk.GetUsed(),
+			Available:  disk.GetAvailable(),
+		})
+	}
+
+	for _, network := range stats.GetNetworks() {
+		resp.Networks = append(resp.Networks, &serverv1.NetworkStats{
+			Interface: network.GetInterface(),
+			RxBytes:   network.GetRxBytes(),
+			TxBytes:   network.GetTxBytes(),
+			RxPackets: network.GetRxPackets(),
+			TxPackets: network.GetTxPackets(),
+			RxErrors:  network.GetRxErrors(),
+			TxErrors:  network.GetTxErrors(),
+			RxDropped: network.GetRxDropped(),
+			TxDropped: network.GetTxDropped(),
+		})
+	}
+
+	for _, process := range stats.GetProcesses() {
+		resp.Processes = append(resp.Processes, &serverv1.ProcessStats{
+			Pid:        process.GetPid(),
+			Name:       process.GetName(),
+			Command:    process.GetCommand(),
+			CpuPercent: process.GetCpuPercent(),
+			Rss:        process.GetRss(),
+		})
+	}
+
+	return resp
+}
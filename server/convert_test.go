@@ -1 +1,45 @@
 package server
+
+import (
+	"testing"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertPullProgressToProto(t *testing.T) {
+	layers := []layerProgress{
+		{Desc: ocispec.Descriptor{Digest: "sha256:aaa", MediaType: ocispec.MediaTypeImageLayerGzip}, Status: layerStatusDone, Offset: 10, Total: 10},
+		{Desc: ocispec.Descriptor{Digest: "sha256:bbb", MediaType: ocispec.MediaTypeImageLayerGzip}, Status: layerStatusDownloading, Offset: 5, Total: 20},
+	}
+
+	resp := convertPullProgressToProto(layers, true)
+	assert.Equal(t, serverv1.PullImageStatus_PULL_IMAGE_STATUS_DOWNLOADING, resp.Status)
+	assert.Len(t, resp.Layers, 2)
+	assert.Equal(t, serverv1.LayerStatus_LAYER_STATUS_DONE, resp.Layers[0].Status)
+	assert.Equal(t, int64(5), resp.Layers[1].Offset)
+
+	layers[1].Status = layerStatusDone
+	resp = convertPullProgressToProto(layers, true)
+	assert.Equal(t, serverv1.PullImageStatus_PULL_IMAGE_STATUS_UNPACKING, resp.Status)
+
+	resp = convertPullProgressToProto(nil, false)
+	assert.Equal(t, serverv1.PullImageStatus_PULL_IMAGE_STATUS_RESOLVING, resp.Status)
+}
+
+func TestConvertStatsToProto(t *testing.T) {
+	resp := convertStatsToProto(&agentv1.GetStatsResponse{
+		Memory:    &agentv1.MemoryStats{Total: 2048, Used: 512},
+		Disks:     []*agentv1.DiskStats{{Mountpoint: "/", Used: 40}},
+		Processes: []*agentv1.ProcessStats{{Pid: 42, Name: "Runner.Worker", CpuPercent: 99.5}},
+	})
+
+	assert.Equal(t, uint64(512), resp.Memory.Used)
+	assert.Equal(t, "/", resp.Disks[0].Mountpoint)
+	assert.Equal(t, int32(42), resp.Processes[0].Pid)
+	assert.Equal(t, 99.5, resp.Processes[0].CpuPercent)
+	assert.NotNil(t, resp.Cpu, "missing sections are zero")
+	assert.Empty(t, resp.Networks)
+}
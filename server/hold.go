@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errHoldLimit is returned when a pool holds as many machines as it may.
+var errHoldLimit = errors.New("too many held machines")
+
+// holdMachine holds a machine whose agent kept the VM after a failed job, so
+// that it can be debugged. The machine is replaced by a new one and deleted
+// once the hold expires. It returns when the hold expires. No machine is held
+// once the pool holds keep_on_failure.max_held machines.
+func (p *Pool) holdMachine(machine *Machine) (time.Time, error) {
+	if machine.isStopping() {
+		return time.Time{}, errMachineStopping
+	}
+
+	// Machines picked for deletion are removed from the pool first
+	p.machinesMu.Lock()
+	_, exists := p.machines[machine.Name]
+	heldUntil := machine.holdExpiry()
+	alreadyHeld := !heldUntil.IsZero()
+	limitReached := !alreadyHeld && p.heldCountLocked() >= p.config.KeepOnFailure.MaxHeld
+	if exists && !alreadyHeld && !limitReached {
+		heldUntil = time.Now().Add(p.config.KeepOnFailure.TTL)
+		machine.hold(heldUntil)
+	}
+	p.machinesMu.Unlock()
+
+	if !exists {
+		return time.Time{}, errMachineStopping
+	}
+
+	if limitReached {
+		return time.Time{}, fmt.Errorf("%w: pool holds %d machines, the maximum", errHoldLimit, p.config.KeepOnFailure.MaxHeld)
+	}
+
+	if alreadyHeld {
+		return heldUntil, nil
+	}
+
+	p.logger.Info().Msgf("Holding Firecracker VM %s after a failed job until %s", machine.Name, heldUntil.Format(time.RFC3339))
+	p.TriggerScale()
+
+	p.cleanupWg.Add(1)
+	go func() {
+		defer p.cleanupWg.Done()
+		p.expireHold(machine, heldUntil)
+	}()
+
+	return heldUntil, nil
+}
+
+// expireHold deletes a held machine once its hold expires, unless it exited
+// before.
+func (p *Pool) expireHold(machine *Machine, heldUntil time.Time) {
+	timer := time.NewTimer(time.Until(heldUntil))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-machine.vmmCtx.Done():
+		return
+	}
+
+	p.logger.Info().Msgf("Hold of Firecracker VM %s expired, deleting it", machine.Name)
+	if err := p.stopMachine(context.Background(), machine, "hold expired"); err != nil {
+		p.logger.Error().Err(err).Msgf("Failed to stop held Firecracker VM %s", machine.Name)
+	}
+}
+
+// heldCount returns the number of held machines of the pool.
+func (p *Pool) heldCount() int {
+	p.machinesMu.Lock()
+	defer p.machinesMu.Unlock()
+
+	return p.heldCountLocked()
+}
+
+// heldCountLocked returns the number of held machines of the pool. The caller
+// must hold machinesMu.
+func (p *Pool) heldCountLocked() int {
+	held := 0
+	for _, machine := range p.machines {
+		if machine.isHeld() {
+			held++
+		}
+	}
+
+	return held
+}
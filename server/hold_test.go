@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_HoldMachine(t *testing.T) {
+	logger := zerolog.Nop()
+
+	// The VMs already exited, so the holds are not expired by the pool
+	vmmCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	failed := &Machine{Name: "failed", vmmCtx: vmmCtx}
+	idle := &Machine{Name: "idle", vmmCtx: vmmCtx}
+	p := &Pool{
+		config:       &PoolConfig{Name: "test", KeepOnFailure: &KeepOnFailureConfig{TTL: time.Hour, MaxHeld: 1}},
+		logger:       &logger,
+		machinesMu:   &sync.Mutex{},
+		machines:     map[string]*Machine{"failed": failed, "idle": idle},
+		scaleTrigger: make(chan struct{}, 1),
+	}
+
+	heldUntil, err := p.holdMachine(failed)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), heldUntil, time.Minute)
+	assert.True(t, failed.isHeld())
+	assert.False(t, idle.isHeld())
+
+	assert.Equal(t, 1, p.GetCurrentSize(), "held machines are not counted")
+	assert.Equal(t, 1, p.heldCount())
+	assert.Len(t, p.scaleTrigger, 1, "a replacement is created")
+
+	again, err := p.holdMachine(failed)
+	require.NoError(t, err)
+	assert.Equal(t, heldUntil, again)
+
+	_, err = p.holdMachine(idle)
+	assert.ErrorIs(t, err, errHoldLimit)
+	assert.False(t, idle.isHeld())
+
+	_, err = p.holdMachine(&Machine{Name: "deleted", vmmCtx: vmmCtx})
+	assert.ErrorIs(t, err, errMachineStopping)
+
+	idle.markStopping()
+	_, err = p.holdMachine(idle)
+	assert.ErrorIs(t, err, errMachineStopping)
+
+	p.cleanupWg.Wait()
+}
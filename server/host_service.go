@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hostinger/fireactions/agent/runner"
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// hostPort is the vsock port agents connect to on the host. Firecracker
+	// forwards guest connections to it to the unix socket <vsock path>_<port>.
+	hostPort = 9002
+)
+
+// renewRunnerFunc registers the runner of a machine again and returns its
+// JIT config.
+type renewRunnerFunc func(ctx context.Context, machine *Machine) (string, error)
+
+// holdMachineFunc holds a machine after a failed job and returns when the
+// hold expires.
+type holdMachineFunc func(machine *Machine) (time.Time, error)
+
+// hostService receives the heartbeats, state transitions and config status
+// pushed by the agent of a single machine.
+type hostService struct {
+	agentv1.UnimplementedHostServiceServer
+
+	machine     *Machine
+	logger      *zerolog.Logger
+	renewRunner renewRunnerFunc // Nil unless the pool runs multiple jobs per machine
+	holdMachine holdMachineFunc // Nil unless the pool keeps machines after failed jobs
+}
+
+func (s *hostService) Heartbeat(ctx context.Context, req *agentv1.HeartbeatRequest) (*agentv1.HeartbeatResponse, error) {
+	s.machine.recordHeartbeat(req.GetState(), req.GetRunnerVersion(), time.Now())
+	s.machine.recordJob(req.GetJob())
+	s.machine.recordJobs(req.GetJobs())
+	return &agentv1.HeartbeatResponse{}, nil
+}
+
+func (s *hostService) ReportStateTransition(ctx context.Context, req *agentv1.ReportStateTransitionRequest) (*agentv1.ReportStateTransitionResponse, error) {
+	s.machine.recordHeartbeat(req.GetTo(), "", time.Now())
+	s.machine.recordJob(req.GetJob())
+	s.machine.recordJobs(req.GetJobs())
+	s.logger.Debug().Str("line", req.GetLine()).Msgf("Runner of Firecracker VM %s changed state: %s -> %s", s.machine.Name, req.GetFrom(), req.GetTo())
+	return &agentv1.ReportStateTransitionResponse{}, nil
+}
+
+func (s *hostService) ReportConfigStatus(ctx context.Context, req *agentv1.ReportConfigStatusRequest) (*agentv1.ReportConfigStatusResponse, error) {
+	s.machine.recordConfigStatus(req.GetLoaded(), req.GetAwaitingRunner())
+	return &agentv1.ReportConfigStatusResponse{}, nil
+}
+
+func (s *hostService) RenewRunner(ctx context.Context, req *agentv1.RenewRunnerRequest) (*agentv1.RenewRunnerResponse, error) {
+	if s.renewRunner == nil {
+		return nil, status.Error(codes.FailedPrecondition, "multi-job mode is disabled for the pool")
+	}
+
+	s.machine.recordJobs(req.GetJobs())
+
+	config, err := s.renewRunner(ctx, s.machine)
+	if errors.Is(err, errRenewalRefused) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "renew runner: %v", err)
+	}
+
+	s.logger.Info().Msgf("Renewed runner of Firecracker VM %s after %d jobs", s.machine.Name, req.GetJobs())
+	return &agentv1.RenewRunnerResponse{RunnerJitConfig: config}, nil
+}
+
+func (s *hostService) ReportFailedJob(ctx context.Context, req *agentv1.ReportFailedJobRequest) (*agentv1.ReportFailedJobResponse, error) {
+	if s.holdMachine == nil {
+		return nil, status.Error(codes.FailedPrecondition, "keep_on_failure is disabled for the pool")
+	}
+
+	// The job is taken from the request, as the state transition of the
+	// failure is pushed asynchronously and may arrive later. Like the state
+	// transitions, it comes from the guest, so a root process in the guest can
+	// force a hold, bounded by MaxHeld
+	job := req.GetJob()
+	if job.GetResult() != runner.JobFailed {
+		return nil, status.Errorf(codes.InvalidArgument, "job result is %q, not %q", job.GetResult(), runner.JobFailed)
+	}
+
+	s.machine.recordJob(job)
+
+	heldUntil, err := s.holdMachine(s.machine)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "hold machine: %v", err)
+	}
+
+	return &agentv1.ReportFailedJobResponse{HeldUntil: timestamppb.New(heldUntil)}, nil
+}
+
+// startHostListener serves the hostService of the machine on the unix socket
+// Firecracker forwards guest connections on hostPort to. renewRunner is nil
+// unless the pool runs multiple jobs per machine, holdMachine unless the pool
+// keeps machines after failed jobs.
+func (m *Machine) startHostListener(logger *zerolog.Logger, renewRunner renewRunnerFunc, holdMachine holdMachineFunc) error {
+	path := fmt.Sprintf("%s_%d", m.vsockPath, hostPort)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	agentv1.RegisterHostServiceServer(grpcServer, &hostService{machine: m, logger: logger, renewRunner: renewRunner, holdMachine: holdMachine})
+	m.hostServer = grpcServer
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Error().Err(err).Msgf("Host listener of Firecracker VM %s failed", m.Name)
+		}
+	}()
+
+	return nil
+}
+
+// stopHostListener stops the hostService of the machine and removes its socket.
+func (m *Machine) stopHostListener() {
+	if m.hostServer == nil {
+		return
+	}
+
+	m.hostServer.Stop()
+	_ = os.Remove(fmt.Sprintf("%s_%d", m.vsockPath, hostPort))
+}
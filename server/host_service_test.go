@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestMachine_HostListener(t *testing.T) {
+	logger := zerolog.Nop()
+	m := &Machine{Name: "test", vsockPath: filepath.Join(t.TempDir(), "vsock.sock")}
+
+	require.NoError(t, m.startHostListener(&logger, nil, nil))
+	defer m.stopHostListener()
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("unix://%s_%d", m.vsockPath, hostPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agentv1.NewHostServiceClient(conn)
+
+	_, err = client.Heartbeat(context.Background(), &agentv1.HeartbeatRequest{State: "Idle", RunnerVersion: "2.321.0", Jobs: 1})
+	require.NoError(t, err)
+
+	_, err = client.ReportStateTransition(context.Background(), &agentv1.ReportStateTransitionRequest{
+		From: "Idle",
+		To:   "Running",
+		Job:  &agentv1.Job{Name: "build", Repository: "hostinger/fireactions", RunId: 42},
+	})
+	require.NoError(t, err)
+
+	state, version, lastSeen := m.status()
+	assert.Equal(t, "Running", state)
+	assert.Equal(t, "2.321.0", version)
+	assert.False(t, lastSeen.IsZero())
+
+	// Heartbeats without a job keep the recorded one
+	_, err = client.Heartbeat(context.Background(), &agentv1.HeartbeatRequest{State: "Running"})
+	require.NoError(t, err)
+
+	job := m.currentJob()
+	require.NotNil(t, job)
+	assert.Equal(t, "build", job.GetName())
+	assert.Equal(t, "hostinger/fireactions", job.GetRepository())
+	assert.Equal(t, int64(42), job.GetRunId())
+	assert.Equal(t, int32(1), m.jobCount())
+
+	_, err = client.RenewRunner(context.Background(), &agentv1.RenewRunnerRequest{Jobs: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "multi-job mode is disabled")
+
+	_, err = client.ReportFailedJob(context.Background(), &agentv1.ReportFailedJobRequest{Job: &agentv1.Job{Name: "build", Result: "Failed"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "keep_on_failure is disabled")
+	assert.Empty(t, m.currentJob().GetResult(), "the job claimed by the request is not recorded")
+}
+
+func TestMachine_HostListener_ReportFailedJob(t *testing.T) {
+	logger := zerolog.Nop()
+	m := &Machine{Name: "test", vsockPath: filepath.Join(t.TempDir(), "vsock.sock")}
+
+	heldUntil := time.Now().Add(time.Hour).UTC()
+	holdMachine := func(machine *Machine) (time.Time, error) {
+		if machine.isStopping() {
+			return time.Time{}, errMachineStopping
+		}
+
+		machine.hold(heldUntil)
+		return heldUntil, nil
+	}
+
+	require.NoError(t, m.startHostListener(&logger, nil, holdMachine))
+	defer m.stopHostListener()
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("unix://%s_%d", m.vsockPath, hostPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agentv1.NewHostServiceClient(conn)
+
+	_, err = client.ReportFailedJob(context.Background(), &agentv1.ReportFailedJobRequest{Job: &agentv1.Job{Name: "build", Result: "Succeeded"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "only failed jobs are held")
+	assert.False(t, m.isHeld())
+
+	// The report may arrive before the transition to the failed job
+	m.recordHeartbeat("Running", "", time.Now())
+	m.recordJob(&agentv1.Job{Name: "build"})
+	resp, err := client.ReportFailedJob(context.Background(), &agentv1.ReportFailedJobRequest{Job: &agentv1.Job{Name: "build", Result: "Failed"}})
+	require.NoError(t, err)
+	assert.True(t, heldUntil.Equal(resp.GetHeldUntil().AsTime()))
+	assert.True(t, m.isHeld())
+	assert.Equal(t, "Failed", m.currentJob().GetResult(), "the failed job is recorded")
+
+	m.markStopping()
+	_, err = client.ReportFailedJob(context.Background(), &agentv1.ReportFailedJobRequest{Job: &agentv1.Job{Name: "build", Result: "Failed"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestMachine_HostListener_RenewRunner(t *testing.T) {
+	logger := zerolog.Nop()
+	m := &Machine{Name: "test", vsockPath: filepath.Join(t.TempDir(), "vsock.sock"), runnerID: 1}
+
+	renewErr := errors.New("github: 500")
+	renewRunner := func(ctx context.Context, machine *Machine) (string, error) {
+		if renewErr != nil {
+			return "", renewErr
+		}
+
+		machine.setRunnerID(2)
+		return "config2", nil
+	}
+
+	require.NoError(t, m.startHostListener(&logger, renewRunner, nil))
+	defer m.stopHostListener()
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("unix://%s_%d", m.vsockPath, hostPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agentv1.NewHostServiceClient(conn)
+
+	_, err = client.RenewRunner(context.Background(), &agentv1.RenewRunnerRequest{Jobs: 1})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, int64(1), m.getRunnerID())
+
+	renewErr = fmt.Errorf("%w: runner state is %q, not after a job", errRenewalRefused, "Running")
+	_, err = client.RenewRunner(context.Background(), &agentv1.RenewRunnerRequest{Jobs: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	renewErr = nil
+	resp, err := client.RenewRunner(context.Background(), &agentv1.RenewRunnerRequest{Jobs: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "config2", resp.GetRunnerJitConfig())
+	assert.Equal(t, int64(2), m.getRunnerID())
+	assert.Equal(t, int32(3), m.jobCount())
+}
+
+func TestMachine_HostListener_ReportConfigStatus(t *testing.T) {
+	logger := zerolog.Nop()
+	m := &Machine{Name: "test", vsockPath: filepath.Join(t.TempDir(), "vsock.sock")}
+
+	require.NoError(t, m.startHostListener(&logger, nil, nil))
+	defer m.stopHostListener()
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("unix://%s_%d", m.vsockPath, hostPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agentv1.NewHostServiceClient(conn)
+	loaded, awaiting := m.configLoadedCh(), m.awaitingRunnerCh()
+
+	_, err = client.ReportConfigStatus(context.Background(), &agentv1.ReportConfigStatusRequest{AwaitingRunner: true})
+	require.NoError(t, err)
+	assert.True(t, isClosed(awaiting))
+	assert.False(t, isClosed(loaded))
+
+	_, err = client.ReportConfigStatus(context.Background(), &agentv1.ReportConfigStatusRequest{Loaded: true})
+	require.NoError(t, err)
+	assert.True(t, isClosed(loaded))
+
+	// Reports are idempotent
+	_, err = client.ReportConfigStatus(context.Background(), &agentv1.ReportConfigStatusRequest{Loaded: true})
+	require.NoError(t, err)
+
+	m.resetConfigLoaded()
+	assert.False(t, isClosed(m.configLoadedCh()), "the restored VM loads its config again")
+	assert.True(t, isClosed(m.awaitingRunnerCh()))
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
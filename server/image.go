@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images"
 	"github.com/containerd/errdefs"
-	"github.com/containerd/nerdctl/pkg/imgutil/dockerconfigresolver"
-	"github.com/distribution/reference"
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/rs/zerolog"
 	"golang.org/x/sync/singleflight"
 )
@@ -16,26 +18,96 @@ import (
 // imageManager manages container images.
 // Containerd client is thread-safe and handles concurrent operations internally.
 type imageManager struct {
-	containerd *containerd.Client
-	logger     *zerolog.Logger
-	pullGroup  singleflight.Group
+	containerd  *containerd.Client
+	snapshotter string
+	kernelDir   string
+	registries  map[string]*RegistryConfig
+	logger      *zerolog.Logger
+	pullGroup   *singleflight.Group
+	pulls       *activePulls // Progress of the pulls of pullGroup
+
+	// registriesKey identifies the registry configuration in the keys of
+	// pullGroup, see withRegistries.
+	registriesKey string
 }
 
 // newImageManager creates a new imageManager.
-func newImageManager(logger *zerolog.Logger, containerdClient *containerd.Client) *imageManager {
+func newImageManager(logger *zerolog.Logger, containerdClient *containerd.Client, snapshotter, kernelDir string, registries map[string]*RegistryConfig) *imageManager {
 	iM := &imageManager{
-		containerd: containerdClient,
-		logger:     logger,
+		containerd:  containerdClient,
+		snapshotter: snapshotter,
+		kernelDir:   kernelDir,
+		registries:  registries,
+		logger:      logger,
+		pullGroup:   &singleflight.Group{},
+		pulls:       newActivePulls(),
+
+		registriesKey: newRegistriesKey(registries),
 	}
 
 	return iM
 }
 
-// ensureImage ensures the image is available according to the pull policy.
+// ensureImage ensures the rootfs image is available according to the pull policy.
 func (im *imageManager) ensureImage(ctx context.Context, imageRef, pullPolicy string) (containerd.Image, error) {
+	return im.ensure(ctx, imageRef, imageTypeRootfs, pullPolicy)
+}
+
+// pullOpts returns the pull options for images of the type.
+func (im *imageManager) pullOpts(imageType string) []containerd.RemoteOpt {
+	if imageType == imageTypeKernel {
+		return im.kernelPullOpts()
+	}
+
+	return im.rootfsPullOpts()
+}
+
+// pullKey returns the key of pulls of the image reference as the type in
+// pullGroup. Pulls of the same reference as another type or with another
+// registry configuration are not shared.
+func (im *imageManager) pullKey(ref, imageType string) string {
+	return imageType + "/" + im.registriesKey + "/" + ref
+}
+
+// rootfsPullOpts returns the pull options for rootfs images. Rootfs images
+// are not unpacked while pulling, so that pools can verify them first, see
+// unpackImage.
+func (im *imageManager) rootfsPullOpts() []containerd.RemoteOpt {
+	opts := []containerd.RemoteOpt{
+		containerd.WithPullLabel(imageTypeLabel, imageTypeRootfs),
+		containerd.WithPullSnapshotter(im.snapshotter),
+	}
+
+	return opts
+}
+
+// unpackImage unpacks the rootfs image into the snapshotter, unless it is
+// already unpacked.
+func (im *imageManager) unpackImage(ctx context.Context, image containerd.Image) error {
+	unpacked, err := image.IsUnpacked(ctx, im.snapshotter)
+	if err != nil {
+		return fmt.Errorf("checking unpack status: %w", err)
+	}
+
+	if unpacked {
+		return nil
+	}
+
+	start := time.Now()
+	if err := image.Unpack(ctx, im.snapshotter); err != nil {
+		return err
+	}
+
+	im.logger.Info().Str("image", image.Name()).Dur("duration", time.Since(start)).Msg("Image unpacked")
+	return nil
+}
+
+// ensure ensures the image is available according to the pull policy, pulling
+// it as the given type when needed.
+func (im *imageManager) ensure(ctx context.Context, imageRef, imageType, pullPolicy string) (containerd.Image, error) {
 	switch pullPolicy {
 	case "Always":
-		return im.pullImage(ctx, imageRef, true)
+		return im.pullImage(ctx, imageRef, imageType, true)
 	case "Never":
 		return im.getLocalImage(ctx, imageRef)
 	case "IfNotPresent":
@@ -48,7 +120,7 @@ func (im *imageManager) ensureImage(ctx context.Context, imageRef, pullPolicy st
 			return nil, fmt.Errorf("checking local image: %w", err)
 		}
 
-		return im.pullImage(ctx, imageRef, false)
+		return im.pullImage(ctx, imageRef, imageType, false)
 	default:
 		return nil, fmt.Errorf("invalid image pull policy: %s", pullPolicy)
 	}
@@ -58,7 +130,9 @@ func (im *imageManager) getLocalImage(ctx context.Context, imageRef string) (con
 	return im.containerd.GetImage(ctx, imageRef)
 }
 
-func (im *imageManager) pullImage(ctx context.Context, ref string, isAlways bool) (containerd.Image, error) {
+// pullImage pulls the image as the given type, unless it is available and
+// isAlways is false.
+func (im *imageManager) pullImage(ctx context.Context, ref, imageType string, isAlways bool) (containerd.Image, error) {
 	if !isAlways {
 		image, err := im.containerd.GetImage(ctx, ref)
 		if err != nil && !errdefs.IsNotFound(err) {
@@ -68,9 +142,14 @@ func (im *imageManager) pullImage(ctx context.Context, ref string, isAlways bool
 		}
 	}
 
+	key := im.pullKey(ref, imageType)
+	progress := im.pulls.acquire(key)
+	defer im.pulls.release(key)
+
 	// Use singleflight to ensure only one goroutine pulls a given image
-	// All other concurrent requests for the same image will wait and share the result
-	result, err, _ := im.pullGroup.Do(ref, func() (interface{}, error) {
+	// All other concurrent requests for the same image will wait and share the result,
+	// unless they pull with another registry configuration
+	result, err, _ := im.pullGroup.Do(key, func() (interface{}, error) {
 		start := time.Now()
 		if isAlways {
 			im.logger.Info().Str("image", ref).Msg("Pulling image (policy: always)")
@@ -78,21 +157,13 @@ func (im *imageManager) pullImage(ctx context.Context, ref string, isAlways bool
 			im.logger.Info().Str("image", ref).Msg("Pulling image")
 		}
 
-		dockerRef, err := reference.ParseDockerRef(ref)
-		if err != nil {
-			return nil, fmt.Errorf("parsing image ref: %w", err)
-		}
-
-		refDomain := reference.Domain(dockerRef)
-		resolver, err := dockerconfigresolver.New(ctx, refDomain)
+		resolver, err := im.newResolver(ctx, ref)
 		if err != nil {
-			return nil, fmt.Errorf("creating docker config resolver: %w", err)
+			return nil, err
 		}
 
-		image, err := im.containerd.Pull(ctx, ref,
-			containerd.WithPullUnpack,
-			containerd.WithResolver(resolver),
-			containerd.WithPullSnapshotter(defaultSnapshotter))
+		opts := append(im.pullOpts(imageType), containerd.WithResolver(resolver), containerd.WithImageHandler(progress.handler()))
+		image, err := im.containerd.Pull(ctx, ref, opts...)
 		if err != nil {
 			im.logger.Error().Err(err).Str("image", ref).Msg("Failed to pull image")
 			return nil, err
@@ -109,6 +180,57 @@ func (im *imageManager) pullImage(ctx context.Context, ref string, isAlways bool
 	return result.(containerd.Image), nil
 }
 
+// resolveDigest resolves the image reference to the digest it currently points
+// to in the registry, without pulling it.
+func (im *imageManager) resolveDigest(ctx context.Context, ref string) (digest.Digest, error) {
+	resolver, err := im.newResolver(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", ref, err)
+	}
+
+	return desc.Digest, nil
+}
+
+// imageDetails holds the details of an image returned by inspectImage.
+type imageDetails struct {
+	Platforms []ocispec.Platform
+	Layers    []ocispec.Descriptor
+	Unpacked  bool
+}
+
+// inspectImage returns the platforms, layers and unpack status of the image.
+func (im *imageManager) inspectImage(ctx context.Context, image containerd.Image) (*imageDetails, error) {
+	store := image.ContentStore()
+
+	details := &imageDetails{}
+	manifest, err := images.Manifest(ctx, store, image.Target(), platforms.Default())
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	details.Layers = manifest.Layers
+
+	// Artifacts such as kernel images don't have a platform.
+	imagePlatforms, err := images.Platforms(ctx, store, image.Target())
+	if err == nil {
+		details.Platforms = imagePlatforms
+	}
+
+	if image.Labels()[imageTypeLabel] != imageTypeKernel {
+		unpacked, err := image.IsUnpacked(ctx, im.snapshotter)
+		if err != nil {
+			return nil, fmt.Errorf("checking unpack status: %w", err)
+		}
+		details.Unpacked = unpacked
+	}
+
+	return details, nil
+}
+
 // listImages returns all images in containerd.
 func (im *imageManager) listImages(ctx context.Context) ([]containerd.Image, error) {
 	return im.containerd.ListImages(ctx)
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+)
+
+// imageController pre-pulls the images of all pools and keeps pools with the
+// Always pull policy pinned to the latest digest of their image tag.
+type imageController struct {
+	pools    []*Pool
+	interval time.Duration
+	logger   *zerolog.Logger
+}
+
+// newImageController creates a new imageController.
+func newImageController(logger *zerolog.Logger, pools []*Pool, interval time.Duration) *imageController {
+	l := logger.With().Str("component", "image-controller").Logger()
+
+	c := &imageController{
+		pools:    pools,
+		interval: interval,
+		logger:   &l,
+	}
+
+	return c
+}
+
+// prePull makes the image of every pool available according to its pull
+// policy and pins the pool to the resulting digest. Failures are logged and
+// left to be retried when the pool creates its first machine.
+func (c *imageController) prePull(ctx context.Context) {
+	start := time.Now()
+	c.logger.Info().Msgf("Pre-pulling images for %d pools", len(c.pools))
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	for _, pool := range c.pools {
+		errGroup.Go(func() error {
+			image, err := pool.imageManager.ensureImage(ctx, pool.config.Runner.Image, pool.config.Runner.ImagePullPolicy)
+			if err != nil {
+				c.logger.Error().Err(err).Str("pool", pool.config.Name).Str("image", pool.config.Runner.Image).Msg("Failed to pre-pull image")
+				return nil
+			}
+
+			if err := pool.useImage(ctx, image); err != nil {
+				c.logger.Error().Err(err).Str("pool", pool.config.Name).Str("image", pool.config.Runner.Image).Msg("Failed to pin image")
+			}
+
+			return nil
+		})
+	}
+
+	_ = errGroup.Wait()
+	c.logger.Info().Dur("duration", time.Since(start)).Msg("Images pre-pulled")
+}
+
+// Run periodically refreshes pool images until the context is canceled.
+func (c *imageController) Run(ctx context.Context) {
+	if c.interval <= 0 {
+		c.logger.Debug().Msg("Image refresh is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+// refreshKey identifies an image refreshed for pools: pools pulling the same
+// reference with different registry settings may get different images, pools
+// with the same registry settings share the refresh, see withRegistries.
+type refreshKey struct {
+	registriesKey string
+	ref           string
+}
+
+// refresh resolves the image tag of every pool with the Always pull policy and
+// switches the pool to the new digest once it has been pulled.
+func (c *imageController) refresh(ctx context.Context) {
+	pulled := make(map[refreshKey]containerd.Image)
+
+	for _, pool := range c.pools {
+		if pool.config.Runner.ImagePullPolicy != "Always" {
+			continue
+		}
+
+		ref := pool.config.Runner.Image
+		key := refreshKey{registriesKey: pool.imageManager.registriesKey, ref: ref}
+		image, ok := pulled[key]
+		if !ok {
+			var err error
+			image, err = c.refreshImage(ctx, pool.imageManager, ref, pool.getImage())
+			if err != nil {
+				c.logger.Error().Err(err).Str("pool", pool.config.Name).Str("image", ref).Msg("Failed to refresh image")
+				continue
+			}
+
+			pulled[key] = image
+		}
+
+		current := pool.getImage()
+		if current != nil && current.Target().Digest == image.Target().Digest {
+			continue
+		}
+
+		if err := pool.useImage(ctx, image); err != nil {
+			c.logger.Error().Err(err).Str("pool", pool.config.Name).Str("image", ref).Msg("Keeping current image digest")
+			continue
+		}
+
+		c.logger.Info().Str("pool", pool.config.Name).Str("image", ref).Str("digest", image.Target().Digest.String()).Msg("Pool switched to new image digest")
+	}
+}
+
+// refreshImage pulls the image if its tag points to a digest other than the
+// current one. It returns the current image if it is already up to date.
+func (c *imageController) refreshImage(ctx context.Context, im *imageManager, ref string, current containerd.Image) (containerd.Image, error) {
+	digest, err := im.resolveDigest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && current.Target().Digest == digest {
+		return current, nil
+	}
+
+	c.logger.Info().Str("image", ref).Str("digest", digest.String()).Msg("New image digest found")
+	return im.pullImage(ctx, ref, imageTypeRootfs, true)
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images"
+	"github.com/rs/zerolog"
+	"golang.org/x/sys/unix"
+)
+
+// imageGC removes images that are no longer referenced by any pool nor used
+// by any live machine.
+//
+// An unreferenced image is removed once it has been unreferenced for the grace
+// period. When the disk usage of the containerd root is above the high-water
+// mark, unreferenced images are removed immediately, oldest first, until the
+// usage drops below it.
+//
+// The time an image was first seen unreferenced is kept in memory only, so the
+// grace period starts over when the server restarts.
+type imageGC struct {
+	containerd   *containerd.Client
+	imageManager *imageManager
+	rootfs       rootfsBackend
+	pools        []*Pool
+	config       *ImageGCConfig
+	logger       *zerolog.Logger
+	diskUsage    func(path string) (float64, error)
+	deleteImage  func(ctx context.Context, name string) error
+
+	// unreferencedSince tracks when an image was first seen unreferenced.
+	unreferencedSince map[string]time.Time
+
+	// wouldRemove holds the images already logged as removable in dry-run
+	// mode, so that each is logged once.
+	wouldRemove map[string]bool
+}
+
+// newImageGC creates a new imageGC.
+func newImageGC(logger *zerolog.Logger, containerdClient *containerd.Client, imageManager *imageManager, rootfs rootfsBackend, pools []*Pool, config *ImageGCConfig) *imageGC {
+	l := logger.With().Str("component", "image-gc").Bool("dry_run", config.DryRun).Logger()
+
+	gc := &imageGC{
+		containerd:        containerdClient,
+		imageManager:      imageManager,
+		rootfs:            rootfs,
+		pools:             pools,
+		config:            config,
+		logger:            &l,
+		diskUsage:         diskUsagePercent,
+		unreferencedSince: make(map[string]time.Time),
+		wouldRemove:       make(map[string]bool),
+	}
+
+	gc.deleteImage = func(ctx context.Context, name string) error {
+		return containerdClient.ImageService().Delete(ctx, name, images.SynchronousDelete())
+	}
+
+	return gc
+}
+
+// Run periodically collects unused images until the context is canceled.
+func (gc *imageGC) Run(ctx context.Context) {
+	ticker := time.NewTicker(gc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := gc.collect(ctx); err != nil && ctx.Err() == nil {
+				gc.logger.Error().Err(err).Msg("Failed to collect images")
+			}
+		}
+	}
+}
+
+// gcCandidate is an image that is not referenced by any pool nor machine.
+type gcCandidate struct {
+	image              containerd.Image
+	unreferencedSince  time.Time
+	gracePeriodExpired bool
+}
+
+func (gc *imageGC) collect(ctx context.Context) error {
+	imageList, err := gc.imageManager.listImages(ctx)
+	if err != nil {
+		return fmt.Errorf("list images: %w", err)
+	}
+
+	inUse, err := gc.inUseDigests(ctx)
+	if err != nil {
+		return fmt.Errorf("list leases: %w", err)
+	}
+
+	return gc.sweep(ctx, imageList, gc.referencedImages(), inUse, time.Now())
+}
+
+// sweep removes the images that are neither referenced by a pool nor in use
+// by a machine once their grace period expired, or right away, oldest first,
+// while the disk usage is above the high-water mark.
+func (gc *imageGC) sweep(ctx context.Context, imageList []containerd.Image, referenced, inUse map[string]bool, now time.Time) error {
+	seen := make(map[string]bool, len(imageList))
+	candidates := make([]gcCandidate, 0)
+	for _, image := range imageList {
+		name := image.Name()
+		seen[name] = true
+
+		if referenced[name] || referenced[image.Target().Digest.String()] || inUse[image.Target().Digest.String()] {
+			delete(gc.unreferencedSince, name)
+			delete(gc.wouldRemove, name)
+			continue
+		}
+
+		since, ok := gc.unreferencedSince[name]
+		if !ok {
+			since = now
+			gc.unreferencedSince[name] = since
+			gc.logger.Debug().Str("image", name).Msg("Image is no longer referenced")
+		}
+
+		candidates = append(candidates, gcCandidate{
+			image:              image,
+			unreferencedSince:  since,
+			gracePeriodExpired: now.Sub(since) >= gc.config.GracePeriod,
+		})
+	}
+
+	for name := range gc.unreferencedSince {
+		if !seen[name] {
+			delete(gc.unreferencedSince, name)
+		}
+	}
+
+	for name := range gc.wouldRemove {
+		if !seen[name] {
+			delete(gc.wouldRemove, name)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].unreferencedSince.Before(candidates[j].unreferencedSince)
+	})
+
+	usage, err := gc.diskUsage(gc.config.Path)
+	if err != nil {
+		return fmt.Errorf("disk usage: %w", err)
+	}
+	metricImageGCDiskUsage.Set(usage)
+
+	var reclaimableImages, reclaimableBytes int64
+	for _, candidate := range candidates {
+		overHighWaterMark := gc.config.HighWaterMarkPercent > 0 && usage >= float64(gc.config.HighWaterMarkPercent)
+		if !candidate.gracePeriodExpired && !overHighWaterMark {
+			continue
+		}
+
+		reason := "grace period expired"
+		if !candidate.gracePeriodExpired {
+			reason = "disk usage above high-water mark"
+		}
+
+		size, err := gc.remove(ctx, candidate.image, reason)
+		if err != nil {
+			gc.logger.Error().Err(err).Str("image", candidate.image.Name()).Msg("Failed to remove image")
+			continue
+		}
+
+		reclaimableImages++
+		reclaimableBytes += size
+
+		if overHighWaterMark && !gc.config.DryRun {
+			usage, err = gc.diskUsage(gc.config.Path)
+			if err != nil {
+				return fmt.Errorf("disk usage: %w", err)
+			}
+			metricImageGCDiskUsage.Set(usage)
+		}
+	}
+
+	if gc.config.DryRun {
+		metricImageGCReclaimableImages.Set(float64(reclaimableImages))
+		metricImageGCReclaimableBytes.Set(float64(reclaimableBytes))
+	}
+
+	return nil
+}
+
+// remove removes the image and the files built for it outside of containerd,
+// and returns the space reclaimed. That is the compressed size of the image
+// content plus the space the files take up; the unpacked snapshots are not
+// included. In dry-run mode nothing is removed and the image is only logged the
+// first time it is found removable.
+func (gc *imageGC) remove(ctx context.Context, image containerd.Image, reason string) (int64, error) {
+	name := image.Name()
+	compressedSize, _ := image.Size(ctx)
+
+	var files []string
+	if gc.rootfs != nil {
+		files = gc.rootfs.imageFiles(image.Target().Digest)
+	}
+
+	var filesSize int64
+	for _, file := range files {
+		filesSize += allocatedSize(file)
+	}
+
+	if gc.config.DryRun {
+		if !gc.wouldRemove[name] {
+			gc.wouldRemove[name] = true
+			gc.logger.Info().Str("image", name).Int64("compressed_size", compressedSize).Int64("files_size", filesSize).Str("reason", reason).Msg("Would remove image")
+		}
+
+		return compressedSize + filesSize, nil
+	}
+
+	if err := gc.deleteImage(ctx, name); err != nil {
+		return 0, err
+	}
+
+	delete(gc.unreferencedSince, name)
+
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			gc.logger.Warn().Err(err).Str("image", name).Msgf("Failed to remove %s", file)
+		}
+	}
+
+	if image.Labels()[imageTypeLabel] == imageTypeKernel {
+		kernelDir := filepath.Join(gc.imageManager.kernelDir, image.Target().Digest.Encoded())
+		if err := os.RemoveAll(kernelDir); err != nil {
+			gc.logger.Warn().Err(err).Str("image", name).Msg("Failed to remove extracted kernel")
+		}
+	}
+
+	gc.logger.Info().Str("image", name).Int64("compressed_size", compressedSize).Int64("files_size", filesSize).Str("reason", reason).Msg("Image removed")
+	metricImageGCRemovedImages.Inc()
+	metricImageGCReclaimedBytes.Add(float64(compressedSize + filesSize))
+	return compressedSize + filesSize, nil
+}
+
+// referencedImages returns the names and pinned digests of the images used
+// by the pools.
+func (gc *imageGC) referencedImages() map[string]bool {
+	referenced := make(map[string]bool)
+	for _, pool := range gc.pools {
+		referenced[pool.config.Runner.Image] = true
+		if pool.config.Firecracker.KernelImage != "" {
+			referenced[pool.config.Firecracker.KernelImage] = true
+		}
+
+		if image := pool.getImage(); image != nil {
+			referenced[image.Target().Digest.String()] = true
+		}
+	}
+
+	return referenced
+}
+
+// inUseDigests returns the digests of the content referenced by the leases of
+// live machines.
+func (gc *imageGC) inUseDigests(ctx context.Context) (map[string]bool, error) {
+	leaseService := gc.containerd.LeasesService()
+	leaseList, err := leaseService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := make(map[string]bool)
+	for _, lease := range leaseList {
+		if !strings.HasPrefix(lease.ID, machineLeasePrefix) {
+			continue
+		}
+
+		resources, err := leaseService.ListResources(ctx, lease)
+		if err != nil {
+			return nil, fmt.Errorf("list resources of lease %s: %w", lease.ID, err)
+		}
+
+		for _, resource := range resources {
+			if resource.Type == "content" {
+				inUse[resource.ID] = true
+			}
+		}
+	}
+
+	return inUse, nil
+}
+
+// allocatedSize returns the disk space taken up by the file at path, 0 if it
+// does not exist. Sparse files take up less than their size.
+func allocatedSize(path string) int64 {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return 0
+	}
+
+	return stat.Blocks * 512
+}
+
+// diskUsagePercent returns the used space of the filesystem containing path in percent.
+func diskUsagePercent(path string) (float64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	used := stat.Blocks - stat.Bfree
+	total := used + stat.Bavail
+	if total == 0 {
+		return 0, nil
+	}
+
+	return float64(used) * 100 / float64(total), nil
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeImage is a containerd image with only a name, digest and size.
+type fakeImage struct {
+	containerd.Image
+
+	name string
+	size int64
+}
+
+func (i *fakeImage) Name() string { return i.name }
+
+func (i *fakeImage) Target() ocispec.Descriptor {
+	return ocispec.Descriptor{Digest: digest.FromString(i.name)}
+}
+
+func (i *fakeImage) Labels() map[string]string { return nil }
+
+func (i *fakeImage) Size(context.Context) (int64, error) { return i.size, nil }
+
+// newTestImageGC returns an imageGC whose disk usage is read from usage and
+// which records the images it deletes.
+func newTestImageGC(t *testing.T, config *ImageGCConfig, usage *float64) (*imageGC, *[]string) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	deleted := &[]string{}
+	gc := &imageGC{
+		config:            config,
+		logger:            &logger,
+		unreferencedSince: make(map[string]time.Time),
+		wouldRemove:       make(map[string]bool),
+		diskUsage:         func(string) (float64, error) { return *usage, nil },
+	}
+
+	gc.deleteImage = func(_ context.Context, name string) error {
+		*deleted = append(*deleted, name)
+		*usage -= 10
+		return nil
+	}
+
+	return gc, deleted
+}
+
+func TestDiskUsagePercent(t *testing.T) {
+	usage, err := diskUsagePercent(t.TempDir())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, usage, 0.0)
+	assert.LessOrEqual(t, usage, 100.0)
+
+	_, err = diskUsagePercent("/nonexistent/path")
+	assert.Error(t, err)
+}
+
+func TestImageGC_ReferencedImages(t *testing.T) {
+	gc := &imageGC{pools: []*Pool{
+		{config: &PoolConfig{
+			Runner:      &RunnerConfig{Image: "ghcr.io/example/runner:latest"},
+			Firecracker: &FirecrackerConfig{KernelImage: "ghcr.io/example/kernel:6.1"},
+		}},
+		{config: &PoolConfig{
+			Runner:      &RunnerConfig{Image: "ghcr.io/example/runner:2.310.2"},
+			Firecracker: &FirecrackerConfig{KernelImagePath: "/var/lib/fireactions/vmlinux"},
+		}},
+	}}
+
+	referenced := gc.referencedImages()
+	assert.Equal(t, map[string]bool{
+		"ghcr.io/example/runner:latest":  true,
+		"ghcr.io/example/kernel:6.1":     true,
+		"ghcr.io/example/runner:2.310.2": true,
+	}, referenced)
+}
+
+func TestMachineLeaseID(t *testing.T) {
+	assert.Equal(t, "fireactions/pools/pool1/runner-abc", machineLeaseID("pool1", "runner-abc"))
+}
+
+func TestImageGC_SweepGracePeriod(t *testing.T) {
+	usage := 50.0
+	gc, deleted := newTestImageGC(t, &ImageGCConfig{GracePeriod: time.Hour, HighWaterMarkPercent: 85}, &usage)
+
+	old := &fakeImage{name: "runner:old"}
+	current := &fakeImage{name: "runner:latest"}
+	leased := &fakeImage{name: "runner:leased"}
+	imageList := []containerd.Image{old, current, leased}
+	referenced := map[string]bool{"runner:latest": true}
+	inUse := map[string]bool{leased.Target().Digest.String(): true}
+
+	now := time.Now()
+	require.NoError(t, gc.sweep(context.Background(), imageList, referenced, inUse, now))
+	assert.Empty(t, *deleted, "images are kept within the grace period")
+	assert.Equal(t, map[string]time.Time{"runner:old": now}, gc.unreferencedSince)
+
+	require.NoError(t, gc.sweep(context.Background(), imageList, referenced, inUse, now.Add(time.Hour)))
+	assert.Equal(t, []string{"runner:old"}, *deleted)
+	assert.Empty(t, gc.unreferencedSince)
+}
+
+func TestImageGC_SweepReferencedAgain(t *testing.T) {
+	usage := 50.0
+	gc, deleted := newTestImageGC(t, &ImageGCConfig{GracePeriod: time.Hour}, &usage)
+
+	image := &fakeImage{name: "runner:old"}
+	now := time.Now()
+	require.NoError(t, gc.sweep(context.Background(), []containerd.Image{image}, nil, nil, now))
+	require.NoError(t, gc.sweep(context.Background(), []containerd.Image{image}, map[string]bool{"runner:old": true}, nil, now.Add(30*time.Minute)))
+	require.NoError(t, gc.sweep(context.Background(), []containerd.Image{image}, nil, nil, now.Add(time.Hour)))
+	assert.Empty(t, *deleted, "the grace period starts again once the image is no longer referenced")
+}
+
+func TestImageGC_SweepHighWaterMark(t *testing.T) {
+	usage := 92.0
+	gc, deleted := newTestImageGC(t, &ImageGCConfig{GracePeriod: 24 * time.Hour, HighWaterMarkPercent: 85}, &usage)
+
+	now := time.Now()
+	gc.unreferencedSince["runner:oldest"] = now.Add(-2 * time.Hour)
+	gc.unreferencedSince["runner:older"] = now.Add(-time.Hour)
+	imageList := []containerd.Image{&fakeImage{name: "runner:new"}, &fakeImage{name: "runner:older"}, &fakeImage{name: "runner:oldest"}}
+
+	require.NoError(t, gc.sweep(context.Background(), imageList, nil, nil, now))
+	assert.Equal(t, []string{"runner:oldest"}, *deleted, "images are removed oldest first until the usage is below the mark")
+
+	usage = 80
+	gc.config.HighWaterMarkPercent = 0
+	require.NoError(t, gc.sweep(context.Background(), imageList, nil, nil, now))
+	assert.Len(t, *deleted, 1, "the high-water mark is disabled")
+}
+
+func TestImageGC_SweepDryRun(t *testing.T) {
+	usage := 92.0
+	gc, deleted := newTestImageGC(t, &ImageGCConfig{GracePeriod: time.Hour, HighWaterMarkPercent: 85, DryRun: true}, &usage)
+
+	imageList := []containerd.Image{&fakeImage{name: "runner:old", size: 100}, &fakeImage{name: "runner:older", size: 200}}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, gc.sweep(context.Background(), imageList, nil, nil, time.Now()))
+	}
+
+	assert.Empty(t, *deleted)
+	assert.Equal(t, map[string]bool{"runner:old": true, "runner:older": true}, gc.wouldRemove)
+	assert.Equal(t, 2.0, testutil.ToFloat64(metricImageGCReclaimableImages), "the images are not counted again on every run")
+	assert.Equal(t, 300.0, testutil.ToFloat64(metricImageGCReclaimableBytes))
+
+	require.NoError(t, gc.sweep(context.Background(), imageList[:1], nil, nil, time.Now()))
+	assert.Equal(t, map[string]bool{"runner:old": true}, gc.wouldRemove)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricImageGCReclaimableImages))
+}
+
+func TestImageGC_RemoveRootfsImage(t *testing.T) {
+	usage := 50.0
+	gc, deleted := newTestImageGC(t, &ImageGCConfig{}, &usage)
+
+	rootfs := &fileRootfs{dir: t.TempDir()}
+	gc.rootfs = rootfs
+
+	image := &fakeImage{name: "runner:old", size: 100}
+	path := rootfs.digestImagePath(image.Target().Digest)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, 8192), 0644))
+	other := rootfs.digestImagePath(digest.FromString("runner:latest"))
+	require.NoError(t, os.WriteFile(other, nil, 0644))
+
+	size, err := gc.remove(context.Background(), image, "grace period expired")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100+8192), size)
+	assert.Equal(t, []string{"runner:old"}, *deleted)
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, other)
+}
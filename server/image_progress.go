@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// layerStatus is the download status of a single blob of an image.
+type layerStatus string
+
+const (
+	layerStatusWaiting     layerStatus = "Waiting"
+	layerStatusDownloading layerStatus = "Downloading"
+	layerStatusDone        layerStatus = "Done"
+)
+
+// layerProgress is the download progress of a single blob of an image.
+type layerProgress struct {
+	Desc   ocispec.Descriptor
+	Status layerStatus
+	Offset int64
+	Total  int64
+}
+
+// pullProgress tracks the blobs fetched during a pull.
+type pullProgress struct {
+	mu       sync.Mutex
+	descs    []ocispec.Descriptor
+	seen     map[string]bool
+	resolved bool
+}
+
+func newPullProgress() *pullProgress {
+	return &pullProgress{seen: make(map[string]bool)}
+}
+
+// handler returns an image handler that records every blob of the image as
+// it is discovered.
+func (pp *pullProgress) handler() images.Handler {
+	return images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		pp.mu.Lock()
+		defer pp.mu.Unlock()
+
+		pp.resolved = true
+		if images.IsManifestType(desc.MediaType) || images.IsIndexType(desc.MediaType) {
+			return nil, nil
+		}
+
+		if !pp.seen[desc.Digest.String()] {
+			pp.seen[desc.Digest.String()] = true
+			pp.descs = append(pp.descs, desc)
+		}
+
+		return nil, nil
+	})
+}
+
+// snapshot returns the current progress of all blobs discovered so far and
+// whether the image reference has been resolved.
+func (pp *pullProgress) snapshot(ctx context.Context, store content.Store) ([]layerProgress, bool, error) {
+	pp.mu.Lock()
+	descs := append([]ocispec.Descriptor(nil), pp.descs...)
+	resolved := pp.resolved
+	pp.mu.Unlock()
+
+	statuses, err := store.ListStatuses(ctx)
+	if err != nil {
+		return nil, resolved, err
+	}
+
+	active := make(map[string]content.Status, len(statuses))
+	for _, status := range statuses {
+		active[status.Ref] = status
+	}
+
+	layers := make([]layerProgress, 0, len(descs))
+	for _, desc := range descs {
+		layer := layerProgress{Desc: desc, Status: layerStatusWaiting, Total: desc.Size}
+		if status, ok := active[remotes.MakeRefKey(ctx, desc)]; ok {
+			layer.Status = layerStatusDownloading
+			layer.Offset = status.Offset
+		} else if _, err := store.Info(ctx, desc.Digest); err == nil {
+			layer.Status = layerStatusDone
+			layer.Offset = desc.Size
+		} else if !errdefs.IsNotFound(err) {
+			return nil, resolved, err
+		}
+
+		layers = append(layers, layer)
+	}
+
+	return layers, resolved, nil
+}
+
+// activePulls tracks the progress of the pulls in flight by pull key, so that
+// every caller waiting for a shared pull can follow its progress.
+type activePulls struct {
+	mu    sync.Mutex
+	pulls map[string]*activePull
+}
+
+// activePull is the progress of a pull in flight and the number of callers
+// waiting for it.
+type activePull struct {
+	progress *pullProgress
+	refs     int
+}
+
+func newActivePulls() *activePulls {
+	return &activePulls{pulls: make(map[string]*activePull)}
+}
+
+// acquire returns the progress of the pull with the key, tracking a new pull
+// if none is in flight. Every acquire must be followed by a release.
+func (a *activePulls) acquire(key string) *pullProgress {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pull, ok := a.pulls[key]
+	if !ok {
+		pull = &activePull{progress: newPullProgress()}
+		a.pulls[key] = pull
+	}
+
+	pull.refs++
+	return pull.progress
+}
+
+// release stops tracking the pull with the key once no caller waits for it.
+func (a *activePulls) release(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pull, ok := a.pulls[key]
+	if !ok {
+		return
+	}
+
+	pull.refs--
+	if pull.refs == 0 {
+		delete(a.pulls, key)
+	}
+}
+
+// pullImageWithProgress pulls the image as the given type and reports the
+// progress of its blobs every interval until the pull completes. Callers
+// joining a pull in flight follow the progress of that pull.
+func (im *imageManager) pullImageWithProgress(ctx context.Context, ref, imageType string, interval time.Duration, report func([]layerProgress, bool)) (containerd.Image, error) {
+	key := im.pullKey(ref, imageType)
+	progress := im.pulls.acquire(key)
+	defer im.pulls.release(key)
+
+	type result struct {
+		image containerd.Image
+		err   error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		image, err := im.pullImage(ctx, ref, imageType, true)
+		done <- result{image: image, err: err}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case r := <-done:
+			return r.image, r.err
+		case <-ticker.C:
+			layers, resolved, err := progress.snapshot(ctx, im.containerd.ContentStore())
+			if err != nil {
+				im.logger.Debug().Err(err).Str("image", ref).Msg("Failed to get pull progress")
+				continue
+			}
+
+			report(layers, resolved)
+		}
+	}
+}
@@ -1 +1,31 @@
 package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageManager_PullKey(t *testing.T) {
+	im := newImageManager(nil, nil, "devmapper", "", nil)
+	poolIM := im.withRegistries(map[string]*RegistryConfig{"ghcr.io": {Username: "pool", PasswordEnv: "POOL_PASSWORD"}})
+
+	ref := "ghcr.io/example/runner:latest"
+	assert.NotEqual(t, im.pullKey(ref, imageTypeRootfs), im.pullKey(ref, imageTypeKernel))
+	assert.NotEqual(t, im.pullKey(ref, imageTypeRootfs), poolIM.pullKey(ref, imageTypeRootfs))
+}
+
+func TestActivePulls(t *testing.T) {
+	pulls := newActivePulls()
+
+	progress := pulls.acquire("rootfs//image")
+	assert.Same(t, progress, pulls.acquire("rootfs//image"), "callers joining a pull share its progress")
+	assert.NotSame(t, progress, pulls.acquire("kernel//image"))
+
+	pulls.release("rootfs//image")
+	assert.Same(t, progress, pulls.acquire("rootfs//image"))
+
+	pulls.release("rootfs//image")
+	pulls.release("rootfs//image")
+	assert.NotSame(t, progress, pulls.acquire("rootfs//image"), "finished pulls are not tracked")
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// cosignSignatureMediaType is the media type of cosign simple signing payloads.
+	cosignSignatureMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// cosignSignatureAnnotation holds the base64 encoded signature of the payload.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	maxSignatureManifestSize = 4 << 20
+	maxSignaturePayloadSize  = 1 << 20
+
+	// defaultRejectionTTL is how long rejected digests are cached when the
+	// image refresh is disabled.
+	defaultRejectionTTL = 5 * time.Minute
+)
+
+var (
+	// errImageUnsigned is returned when no signature exists for the image digest.
+	errImageUnsigned = errors.New("image is not signed")
+
+	// errSignatureMismatch is returned when none of the signatures of the image
+	// is valid for the configured keys and the resolved digest.
+	errSignatureMismatch = errors.New("no signature matches the configured public keys and image digest")
+)
+
+// imageVerifier verifies cosign signatures of images before machines are
+// booted from them. Signatures are looked up in the image repository under the
+// tag "sha256-<digest>.sig", like cosign stores them.
+type imageVerifier struct {
+	imageManager *imageManager
+	keys         []crypto.PublicKey
+	logger       *zerolog.Logger
+	rejectionTTL time.Duration // How long unsigned and mismatching digests are cached
+
+	mu    sync.Mutex
+	cache map[digest.Digest]verification
+}
+
+// verification is a cached verification result. Verified digests never
+// expire, as their signature cannot change; rejected digests expire, as a
+// signature may be pushed later.
+type verification struct {
+	err     error
+	expires time.Time // Zero if the result never expires
+}
+
+// newImageVerifier creates a new imageVerifier that trusts the PEM encoded
+// public keys at the given paths. Rejected digests are verified again once
+// rejectionTTL has passed, or defaultRejectionTTL if it is not positive.
+func newImageVerifier(logger *zerolog.Logger, imageManager *imageManager, keyPaths []string, rejectionTTL time.Duration) (*imageVerifier, error) {
+	keys := make([]crypto.PublicKey, 0, len(keyPaths))
+	for _, path := range keyPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading public key: %w", err)
+		}
+
+		key, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key %s: %w", path, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	v := &imageVerifier{
+		imageManager: imageManager,
+		keys:         keys,
+		logger:       logger,
+		rejectionTTL: rejectionTTL,
+		cache:        make(map[digest.Digest]verification),
+	}
+
+	if v.rejectionTTL <= 0 {
+		v.rejectionTTL = defaultRejectionTTL
+	}
+
+	return v, nil
+}
+
+// verify verifies that the image ref resolved to dgst is signed by one of the
+// trusted keys. Failures other than a missing or mismatching signature, e.g.
+// registry errors, are not cached.
+func (v *imageVerifier) verify(ctx context.Context, ref string, dgst digest.Digest) error {
+	v.mu.Lock()
+	cached, ok := v.cache[dgst]
+	v.mu.Unlock()
+	if ok && (cached.expires.IsZero() || time.Now().Before(cached.expires)) {
+		return cached.err
+	}
+
+	err := v.verifySignatures(ctx, ref, dgst)
+	switch {
+	case err == nil:
+		v.mu.Lock()
+		v.cache[dgst] = verification{}
+		v.mu.Unlock()
+	case errors.Is(err, errImageUnsigned), errors.Is(err, errSignatureMismatch):
+		v.mu.Lock()
+		v.cache[dgst] = verification{err: err, expires: time.Now().Add(v.rejectionTTL)}
+		v.mu.Unlock()
+	}
+
+	if err != nil {
+		v.logger.Warn().Err(err).Str("image", ref).Str("digest", dgst.String()).Msg("Image signature verification failed")
+		return err
+	}
+
+	v.logger.Debug().Str("image", ref).Str("digest", dgst.String()).Msg("Image signature verified")
+	return nil
+}
+
+func (v *imageVerifier) verifySignatures(ctx context.Context, ref string, dgst digest.Digest) error {
+	named, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return fmt.Errorf("parsing image ref: %w", err)
+	}
+
+	sigRef := fmt.Sprintf("%s:%s-%s.sig", reference.TrimNamed(named).String(), dgst.Algorithm(), dgst.Encoded())
+	resolver, err := v.imageManager.newResolver(ctx, sigRef)
+	if err != nil {
+		return err
+	}
+
+	name, desc, err := resolver.Resolve(ctx, sigRef)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return errImageUnsigned
+		}
+
+		return fmt.Errorf("resolving signature: %w", err)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return fmt.Errorf("creating fetcher: %w", err)
+	}
+
+	fetch := func(desc ocispec.Descriptor, limit int64) ([]byte, error) {
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(io.LimitReader(rc, limit))
+		if err != nil {
+			return nil, err
+		}
+
+		if desc.Digest.Validate() == nil && desc.Digest.Algorithm().FromBytes(data) != desc.Digest {
+			return nil, fmt.Errorf("digest mismatch for %s", desc.Digest)
+		}
+
+		return data, nil
+	}
+
+	manifestData, err := fetch(desc, maxSignatureManifestSize)
+	if err != nil {
+		return fmt.Errorf("fetching signature manifest: %w", err)
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("parsing signature manifest: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != cosignSignatureMediaType {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil {
+			continue
+		}
+
+		payload, err := fetch(layer, maxSignaturePayloadSize)
+		if err != nil {
+			return fmt.Errorf("fetching signature payload: %w", err)
+		}
+
+		if verifySimpleSigning(v.keys, payload, signature, dgst) {
+			return nil
+		}
+	}
+
+	return errSignatureMismatch
+}
+
+// verificationResult returns the metric label for a verification error.
+func verificationResult(err error) string {
+	switch {
+	case err == nil:
+		return "verified"
+	case errors.Is(err, errImageUnsigned):
+		return "unsigned"
+	case errors.Is(err, errSignatureMismatch):
+		return "mismatch"
+	default:
+		return "error"
+	}
+}
+
+// simpleSigningPayload is the part of a cosign simple signing payload that is
+// relevant for verification.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// verifySimpleSigning reports whether the signature of the payload is valid
+// for one of the keys and the payload refers to the expected digest.
+func verifySimpleSigning(keys []crypto.PublicKey, payload, signature []byte, dgst digest.Digest) bool {
+	valid := false
+	for _, key := range keys {
+		if verifySignature(key, payload, signature) {
+			valid = true
+			break
+		}
+	}
+
+	if !valid {
+		return false
+	}
+
+	var p simpleSigningPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return false
+	}
+
+	return p.Critical.Image.DockerManifestDigest == dgst.String()
+}
+
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	default:
+		return false
+	}
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func simpleSigningPayloadFor(dgst digest.Digest) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"ghcr.io/example/runner"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, dgst))
+}
+
+func TestVerifySimpleSigning(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	dgst := digest.FromString("image")
+	payload := simpleSigningPayloadFor(dgst)
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	keys := []crypto.PublicKey{&otherKey.PublicKey, &key.PublicKey}
+	assert.True(t, verifySimpleSigning(keys, payload, signature, dgst))
+	assert.False(t, verifySimpleSigning(keys, payload, signature, digest.FromString("other")), "digest mismatch")
+	assert.False(t, verifySimpleSigning([]crypto.PublicKey{&otherKey.PublicKey}, payload, signature, dgst), "untrusted key")
+	assert.False(t, verifySimpleSigning(keys, append(payload, ' '), signature, dgst), "tampered payload")
+}
+
+func TestVerifySimpleSigning_Ed25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dgst := digest.FromString("image")
+	payload := simpleSigningPayloadFor(dgst)
+	signature := ed25519.Sign(priv, payload)
+
+	assert.True(t, verifySimpleSigning([]crypto.PublicKey{pub}, payload, signature, dgst))
+}
+
+func TestNewImageVerifier(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "cosign.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	invalidPath := filepath.Join(dir, "invalid.pub")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("not a key"), 0644))
+
+	logger := zerolog.Nop()
+	v, err := newImageVerifier(&logger, nil, []string{keyPath}, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, v.keys, 1)
+	assert.Equal(t, time.Minute, v.rejectionTTL)
+
+	v, err = newImageVerifier(&logger, nil, []string{keyPath}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, defaultRejectionTTL, v.rejectionTTL, "the image refresh is disabled")
+
+	_, err = newImageVerifier(&logger, nil, []string{invalidPath}, time.Minute)
+	assert.Error(t, err)
+
+	_, err = newImageVerifier(&logger, nil, []string{filepath.Join(dir, "missing.pub")}, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestImageVerifier_Cache(t *testing.T) {
+	logger := zerolog.Nop()
+	v, err := newImageVerifier(&logger, nil, nil, time.Minute)
+	require.NoError(t, err)
+
+	verified := digest.FromString("verified")
+	v.cache[verified] = verification{}
+	assert.NoError(t, v.verify(t.Context(), "ghcr.io/example/runner:latest", verified))
+
+	rejected := digest.FromString("rejected")
+	v.cache[rejected] = verification{err: errSignatureMismatch, expires: time.Now().Add(time.Minute)}
+	err = v.verify(t.Context(), "ghcr.io/example/runner:latest", rejected)
+	assert.ErrorIs(t, err, errSignatureMismatch)
+
+	// Expired rejections are verified again, which fails on the invalid reference
+	v.cache[rejected] = verification{err: errSignatureMismatch, expires: time.Now().Add(-time.Second)}
+	err = v.verify(t.Context(), "INVALID", rejected)
+	assert.NotErrorIs(t, err, errSignatureMismatch)
+	assert.Equal(t, errSignatureMismatch, v.cache[rejected].err, "errors other than rejections are not cached")
+}
+
+func TestVerificationResult(t *testing.T) {
+	assert.Equal(t, "verified", verificationResult(nil))
+	assert.Equal(t, "unsigned", verificationResult(fmt.Errorf("wrapped: %w", errImageUnsigned)))
+	assert.Equal(t, "mismatch", verificationResult(errSignatureMismatch))
+	assert.Equal(t, "error", verificationResult(fmt.Errorf("network")))
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// kernelFileName is the name of the kernel file inside a kernel image.
+	kernelFileName = "vmlinux"
+
+	// imageTypeLabel is the containerd image label that marks what an image is used for.
+	imageTypeLabel = "fireactions.hostinger.com/image-type"
+
+	imageTypeRootfs = "rootfs"
+	imageTypeKernel = "kernel"
+)
+
+var (
+	errKernelNotFound = errors.New("vmlinux not found in image")
+)
+
+// kernelImage is a guest kernel extracted from an OCI image or artifact.
+type kernelImage struct {
+	Path   string // Path to the extracted vmlinux on the host
+	Digest string // Digest of the image the kernel was extracted from
+}
+
+// ensureKernel ensures the kernel image is available according to the pull
+// policy and returns the vmlinux extracted from it. Kernels are cached by image
+// digest and never overwritten, so a machine keeps booting the kernel it was
+// created with even if the tag moves.
+func (im *imageManager) ensureKernel(ctx context.Context, imageRef, pullPolicy string) (*kernelImage, error) {
+	image, err := im.ensure(ctx, imageRef, imageTypeKernel, pullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := image.Target().Digest
+	kernel := &kernelImage{
+		Path:   filepath.Join(im.kernelDir, digest.Encoded(), kernelFileName),
+		Digest: digest.String(),
+	}
+
+	if _, err := os.Stat(kernel.Path); err == nil {
+		return kernel, nil
+	}
+
+	_, err, _ = im.pullGroup.Do(kernel.Path, func() (interface{}, error) {
+		if _, err := os.Stat(kernel.Path); err == nil {
+			return nil, nil
+		}
+
+		if err := os.MkdirAll(filepath.Dir(kernel.Path), 0755); err != nil {
+			return nil, fmt.Errorf("creating kernel directory: %w", err)
+		}
+
+		tmpPath := kernel.Path + ".tmp"
+		if err := im.extractKernel(ctx, image, tmpPath); err != nil {
+			_ = os.Remove(tmpPath)
+			return nil, fmt.Errorf("extracting kernel from %s: %w", imageRef, err)
+		}
+
+		if err := os.Rename(tmpPath, kernel.Path); err != nil {
+			_ = os.Remove(tmpPath)
+			return nil, err
+		}
+
+		im.logger.Info().Str("image", imageRef).Str("digest", kernel.Digest).Msg("Kernel extracted successfully")
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return kernel, nil
+}
+
+// kernelPullOpts returns the pull options for kernel images. Kernel images
+// are never unpacked, the kernel is extracted from the content store instead.
+func (im *imageManager) kernelPullOpts() []containerd.RemoteOpt {
+	opts := []containerd.RemoteOpt{
+		containerd.WithPullLabel(imageTypeLabel, imageTypeKernel),
+	}
+
+	return opts
+}
+
+// extractKernel writes the vmlinux contained in the image to path. The kernel
+// is either a layer annotated with the title "vmlinux" (e.g. pushed with oras)
+// or a file named vmlinux inside one of the tar layers.
+func (im *imageManager) extractKernel(ctx context.Context, image containerd.Image, path string) error {
+	store := image.ContentStore()
+	manifest, err := images.Manifest(ctx, store, image.Target(), platforms.Default())
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for _, layer := range manifest.Layers {
+		err := copyKernelFromLayer(ctx, store, layer, file)
+		if errors.Is(err, errKernelNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return file.Sync()
+	}
+
+	return errKernelNotFound
+}
+
+func copyKernelFromLayer(ctx context.Context, store content.Provider, layer ocispec.Descriptor, w io.Writer) error {
+	isKernelBlob := layer.Annotations[ocispec.AnnotationTitle] == kernelFileName
+	if !isKernelBlob && !strings.Contains(layer.MediaType, "tar") {
+		return errKernelNotFound
+	}
+
+	ra, err := store.ReaderAt(ctx, layer)
+	if err != nil {
+		return fmt.Errorf("reading layer %s: %w", layer.Digest, err)
+	}
+	defer ra.Close()
+
+	stream, err := compression.DecompressStream(content.NewReader(ra))
+	if err != nil {
+		return fmt.Errorf("decompressing layer %s: %w", layer.Digest, err)
+	}
+	defer stream.Close()
+
+	if isKernelBlob {
+		_, err := io.Copy(w, stream)
+		return err
+	}
+
+	return copyKernelFromTar(stream, w)
+}
+
+// copyKernelFromTar copies the first regular file named vmlinux from the tar stream.
+func copyKernelFromTar(r io.Reader, w io.Writer) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return errKernelNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != kernelFileName {
+			continue
+		}
+
+		_, err = io.Copy(w, tr)
+		return err
+	}
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyKernelFromTar(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for name, body := range map[string]string{"boot/config": "CONFIG_PRINTK=y", "boot/vmlinux": "kernel"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(body))}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	out := new(bytes.Buffer)
+	require.NoError(t, copyKernelFromTar(buf, out))
+	assert.Equal(t, "kernel", out.String())
+}
+
+func TestCopyKernelFromTar_NotFound(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.Close())
+
+	err := copyKernelFromTar(buf, new(bytes.Buffer))
+	assert.ErrorIs(t, err, errKernelNotFound)
+}
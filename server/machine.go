@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/firecracker-microvm/firecracker-go-sdk"
@@ -11,22 +12,316 @@ import (
 	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Machine holds metadata about a Firecracker machine and its associated resources.
 type Machine struct {
+	// Machine is the Firecracker VM. It is replaced when the VM is restored
+	// from its snapshot, use the methods of Machine instead of its fields.
 	*firecracker.Machine
 
 	Name      string
-	RunnerID  int64
 	Pool      string
 	CreatedAt time.Time
 
+	// ImageDigest is the digest of the rootfs image the machine booted from.
+	ImageDigest string
+
+	// KernelDigest is the digest of the kernel image the machine booted
+	// from, or empty if the kernel was loaded from kernel_image_path.
+	KernelDigest string
+
+	// Index is the lowest index that was not used by another live machine
+	// of the pool when the machine was created.
+	Index int
+
 	vsockCID    uint32
 	vsockPath   string
 	leaseCancel func(context.Context) error // containerd lease cancel function
 	vmmCtx      context.Context
 	vmmCancel   context.CancelFunc
+	hostServer  *grpc.Server
+	network     *machineNetwork        // Set up by the server, nil if set up by the SDK
+	rootfsLink  string                 // Symlink to the root drive, for machines restored between jobs
+	metadata    map[string]interface{} // Metadata of restored VMs, without the secrets
+
+	vmMu     sync.RWMutex // Guards Machine and stopping
+	stopping bool         // Whether the machine is stopped, so it must not be restored
+
+	// Status pushed by the agent, see hostService
+	statusMu         sync.RWMutex
+	runnerState      string
+	runnerVersion    string
+	lastSeen         time.Time
+	job              *agentv1.Job // Job the runner is running or last ran
+	jobs             int32        // Number of jobs the runner started
+	jobsBefore       int32        // Number of jobs before the VM was last restored
+	renewals         int32        // Number of times the runner was renewed for a next job
+	runnerID         int64        // GitHub runner ID, changes when the runner is renewed
+	heartbeatTimeout time.Duration
+	unhealthy        bool          // Whether the machine was last reported as unhealthy
+	heldUntil        time.Time     // Set while the machine is held after a failed job
+	configLoaded     chan struct{} // Closed once the agent reports it loaded its config, see configLoadedCh
+	awaitingRunner   chan struct{} // Closed once the agent reports it waits for a runner JIT config
+}
+
+// recordHeartbeat records the status pushed by the agent.
+func (m *Machine) recordHeartbeat(state, runnerVersion string, t time.Time) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	if state != "" {
+		m.runnerState = state
+	}
+
+	if runnerVersion != "" {
+		m.runnerVersion = runnerVersion
+	}
+
+	m.lastSeen = t
+}
+
+// recordJob records the job pushed by the agent. Agents that did not run a
+// job yet push none, which keeps the recorded job.
+func (m *Machine) recordJob(job *agentv1.Job) {
+	if job == nil {
+		return
+	}
+
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.job = job
+}
+
+// recordJobs records the number of jobs the runner started, as pushed by the
+// agent. The recorded number never decreases: zero, pushed before the first
+// job, or a lower number keeps it.
+func (m *Machine) recordJobs(jobs int32) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.jobs = max(m.jobs, m.jobsBefore+jobs)
+}
+
+// recordRenewal records that the runner was renewed for a next job.
+func (m *Machine) recordRenewal() {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.renewals++
+}
+
+// recordRestore records that the VM was restored from its snapshot, after
+// which the agent counts jobs from zero again.
+func (m *Machine) recordRestore() {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.jobsBefore = m.jobs
+}
+
+// recordConfigStatus records the config status pushed by the agent. The
+// status is only ever recorded as reached, until resetConfigLoaded.
+func (m *Machine) recordConfigStatus(loaded, awaitingRunner bool) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	if loaded {
+		closeSignal(&m.configLoaded)
+	}
+
+	if awaitingRunner {
+		closeSignal(&m.awaitingRunner)
+	}
+}
+
+// configLoadedCh returns a channel closed once the agent reports that it
+// loaded its config from MMDS.
+func (m *Machine) configLoadedCh() <-chan struct{} {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	return signal(&m.configLoaded)
+}
+
+// awaitingRunnerCh returns a channel closed once the agent reports that it
+// waits for a runner JIT config in MMDS.
+func (m *Machine) awaitingRunnerCh() <-chan struct{} {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	return signal(&m.awaitingRunner)
+}
+
+// resetConfigLoaded forgets that the agent loaded its config, before the VM
+// is restored from its snapshot and its agent loads a new runner JIT config.
+func (m *Machine) resetConfigLoaded() {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.configLoaded = nil
+}
+
+// signal returns the channel at ch, making it first if it is nil.
+func signal(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+
+	return *ch
+}
+
+// closeSignal closes the channel at ch unless it is closed already.
+func closeSignal(ch *chan struct{}) {
+	c := signal(ch)
+	select {
+	case <-c:
+	default:
+		close(c)
+	}
+}
+
+// jobCount returns the number of jobs the runner started.
+func (m *Machine) jobCount() int32 {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.jobs
+}
+
+// renewalCount returns the number of times the runner was renewed for a next
+// job.
+func (m *Machine) renewalCount() int32 {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.renewals
+}
+
+// hold marks the machine as held after a failed job, until it is deleted.
+func (m *Machine) hold(until time.Time) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.heldUntil = until
+}
+
+// holdExpiry returns when the hold of the machine expires, zero if the
+// machine is not held.
+func (m *Machine) holdExpiry() time.Time {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.heldUntil
+}
+
+// isHeld reports whether the machine is held after a failed job. Held
+// machines are not counted toward the replicas of the pool.
+func (m *Machine) isHeld() bool {
+	return !m.holdExpiry().IsZero()
+}
+
+// getRunnerID returns the ID of the GitHub runner of the machine.
+func (m *Machine) getRunnerID() int64 {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.runnerID
+}
+
+// setRunnerID sets the ID of the GitHub runner of the machine after it was
+// registered again.
+func (m *Machine) setRunnerID(id int64) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	m.runnerID = id
+}
+
+// currentJob returns the job the runner is running or last ran, nil if none.
+func (m *Machine) currentJob() *agentv1.Job {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.job
+}
+
+// status returns the last runner state and version pushed by the agent and
+// when the agent was last seen. The time is zero if the agent was never seen.
+func (m *Machine) status() (string, string, time.Time) {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+
+	return m.runnerState, m.runnerVersion, m.lastSeen
+}
+
+// isHealthy reports whether the agent was seen within the heartbeat timeout.
+// Machines whose agent was never seen are healthy for the timeout after
+// creation, so they have time to boot.
+func (m *Machine) isHealthy(now time.Time) bool {
+	_, _, lastSeen := m.status()
+	if lastSeen.IsZero() {
+		lastSeen = m.CreatedAt
+	}
+
+	return now.Sub(lastSeen) <= m.heartbeatTimeout
+}
+
+// vm returns the current Firecracker VM of the machine.
+func (m *Machine) vm() *firecracker.Machine {
+	m.vmMu.RLock()
+	defer m.vmMu.RUnlock()
+
+	return m.Machine
+}
+
+// replaceVM replaces the Firecracker VM of the machine with one restored from
+// its snapshot. It reports false, leaving the machine as is, if the machine
+// is being stopped.
+func (m *Machine) replaceVM(vm *firecracker.Machine) bool {
+	m.vmMu.Lock()
+	defer m.vmMu.Unlock()
+
+	if m.stopping {
+		return false
+	}
+
+	m.Machine = vm
+	return true
+}
+
+// markStopping marks the machine as being stopped, so it is not restored
+// once its VM exits.
+func (m *Machine) markStopping() {
+	m.vmMu.Lock()
+	defer m.vmMu.Unlock()
+
+	m.stopping = true
+}
+
+// isStopping reports whether the machine is being stopped.
+func (m *Machine) isStopping() bool {
+	m.vmMu.RLock()
+	defer m.vmMu.RUnlock()
+
+	return m.stopping
+}
+
+// Wait waits until the current Firecracker VM of the machine exits.
+func (m *Machine) Wait(ctx context.Context) error {
+	return m.vm().Wait(ctx)
+}
+
+// StopVMM stops the current Firecracker VM of the machine.
+func (m *Machine) StopVMM() error {
+	return m.vm().StopVMM()
+}
+
+// UpdateMetadata patches the MMDS of the current Firecracker VM of the machine.
+func (m *Machine) UpdateMetadata(ctx context.Context, metadata interface{}) error {
+	return m.vm().UpdateMetadata(ctx, metadata)
 }
 
 func (m *Machine) ConnectToGuestAgent(ctx context.Context) (*grpc.ClientConn, agentv1.AgentServiceClient, error) {
@@ -49,10 +344,37 @@ func (m *Machine) ConnectToGuestAgent(ctx context.Context) (*grpc.ClientConn, ag
 	return conn, client, nil
 }
 
+// getStats returns the resource usage of the VM from the guest agent.
+func (m *Machine) getStats(ctx context.Context, topProcesses int) (*agentv1.GetStatsResponse, error) {
+	conn, client, err := m.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return client.GetStats(ctx, &agentv1.GetStatsRequest{TopProcesses: int32(topProcesses)})
+}
+
+// shutdown asks the guest agent to stop the runner and power the VM off,
+// within the grace period. It does not wait for the VM to exit.
+func (m *Machine) shutdown(ctx context.Context, reason string, gracePeriod time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	conn, client, err := m.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = client.Shutdown(ctx, &agentv1.ShutdownRequest{Reason: reason, GracePeriod: durationpb.New(gracePeriod)})
+	return err
+}
+
 func (m *Machine) GetAddr() string {
 	addr := ""
-	if len(m.Cfg.NetworkInterfaces) > 0 {
-		addr = m.Cfg.NetworkInterfaces[0].StaticConfiguration.IPConfiguration.IPAddr.IP.String()
+	if vm := m.vm(); len(vm.Cfg.NetworkInterfaces) > 0 {
+		addr = vm.Cfg.NetworkInterfaces[0].StaticConfiguration.IPConfiguration.IPAddr.IP.String()
 	}
 
 	return addr
@@ -1 +1,59 @@
 package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachine_IsHealthy(t *testing.T) {
+	now := time.Now()
+	m := &Machine{CreatedAt: now.Add(-time.Minute), heartbeatTimeout: 30 * time.Second}
+
+	assert.False(t, m.isHealthy(now), "never seen after the timeout")
+	assert.True(t, m.isHealthy(now.Add(-45*time.Second)), "never seen within the timeout")
+
+	m.recordHeartbeat("Idle", "2.321.0", now.Add(-10*time.Second))
+	assert.True(t, m.isHealthy(now))
+	assert.False(t, m.isHealthy(now.Add(time.Minute)))
+}
+
+func TestMachine_RecordHeartbeat(t *testing.T) {
+	m := &Machine{}
+
+	now := time.Now()
+	m.recordHeartbeat("Idle", "2.321.0", now)
+	m.recordHeartbeat("Active", "", now.Add(time.Second))
+
+	state, version, lastSeen := m.status()
+	assert.Equal(t, "Active", state)
+	assert.Equal(t, "2.321.0", version, "empty versions keep the last one")
+	assert.Equal(t, now.Add(time.Second), lastSeen)
+}
+
+func TestMachine_RecordRestore(t *testing.T) {
+	m := &Machine{}
+
+	m.recordJobs(1)
+	m.recordRestore()
+	assert.Equal(t, int32(1), m.jobCount())
+
+	// The agent of the restored VM counts from zero again
+	m.recordJobs(1)
+	assert.Equal(t, int32(2), m.jobCount())
+}
+
+func TestMachine_ReplaceVM(t *testing.T) {
+	m := &Machine{}
+	vm := &firecracker.Machine{}
+
+	assert.True(t, m.replaceVM(vm))
+	assert.Same(t, vm, m.vm())
+
+	m.markStopping()
+	assert.True(t, m.isStopping())
+	assert.False(t, m.replaceVM(&firecracker.Machine{}), "stopping machines are not restored")
+	assert.Same(t, vm, m.vm())
+}
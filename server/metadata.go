@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hostinger/fireactions/helper/deepcopy"
+)
+
+const (
+	// metadataScrubTimeout is how long to wait for the agent to load its
+	// config before giving up on scrubbing MMDS.
+	metadataScrubTimeout = 10 * time.Minute
+
+	// metadataEnvPrefix is the prefix of the environment variables of the
+	// server that are available to metadata templates.
+	metadataEnvPrefix = "FIREACTIONS_META_"
+)
+
+// metadataTemplateData is the per-machine data available to templates in
+// metadata values, e.g. "{{ .Pool }}-{{ .Index }}".
+type metadataTemplateData struct {
+	RunnerName  string            // Name of the GitHub runner and the VM
+	Pool        string            // Name of the pool
+	Index       int               // Lowest index not used by another live machine of the pool
+	Host        string            // Host name of the server
+	VsockCID    uint32            // Vsock CID of the VM
+	ImageDigest string            // Digest of the rootfs image
+	Env         map[string]string // Environment variables of the server prefixed with metadataEnvPrefix, without the prefix
+}
+
+// newMetadataTemplateData returns the template data for a machine.
+func newMetadataTemplateData(runnerName, pool string, index int, vsockCID uint32, imageDigest string) *metadataTemplateData {
+	host, _ := os.Hostname()
+
+	data := &metadataTemplateData{
+		RunnerName:  runnerName,
+		Pool:        pool,
+		Index:       index,
+		Host:        host,
+		VsockCID:    vsockCID,
+		ImageDigest: imageDigest,
+		Env:         metadataEnv(os.Environ()),
+	}
+
+	return data
+}
+
+// metadataEnv returns the variables of the environment prefixed with
+// metadataEnvPrefix, without the prefix. Other variables, e.g. credentials of
+// the server, are never exposed to the VMs.
+func metadataEnv(environ []string) map[string]string {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		k, v, _ := strings.Cut(kv, "=")
+		name, ok := strings.CutPrefix(k, metadataEnvPrefix)
+		if !ok || name == "" {
+			continue
+		}
+
+		env[name] = v
+	}
+
+	return env
+}
+
+// machineMetadata returns a copy of the metadata of the pool with its
+// templates rendered for a machine.
+func (p *Pool) machineMetadata(data *metadataTemplateData) (map[string]interface{}, error) {
+	metadata := deepcopy.Map(p.config.Firecracker.Metadata)
+	if err := renderMetadata(metadata, data); err != nil {
+		return nil, fmt.Errorf("rendering metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// renderMetadata renders the templates in all string values of the metadata,
+// including values nested in maps and lists. The metadata is modified in
+// place. Values without template actions are left untouched.
+func renderMetadata(metadata map[string]interface{}, data *metadataTemplateData) error {
+	for key, value := range metadata {
+		rendered, err := renderMetadataValue(value, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		metadata[key] = rendered
+	}
+
+	return nil
+}
+
+func renderMetadataValue(value interface{}, data *metadataTemplateData) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+
+		tmpl, err := parseMetadataTemplate(v)
+		if err != nil {
+			return nil, err
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, err
+		}
+
+		return sb.String(), nil
+	case map[string]interface{}:
+		if err := renderMetadata(v, data); err != nil {
+			return nil, err
+		}
+
+		return v, nil
+	case []interface{}:
+		for i := range v {
+			rendered, err := renderMetadataValue(v[i], data)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+
+			v[i] = rendered
+		}
+
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// validateMetadata checks that all templates in the metadata parse.
+func validateMetadata(metadata map[string]interface{}) error {
+	for key, value := range metadata {
+		if err := validateMetadataValue(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func validateMetadataValue(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return nil
+		}
+
+		_, err := parseMetadataTemplate(v)
+		return err
+	case map[string]interface{}:
+		return validateMetadata(v)
+	case []interface{}:
+		for i := range v {
+			if err := validateMetadataValue(v[i]); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseMetadataTemplate(text string) (*template.Template, error) {
+	return template.New("metadata").Option("missingkey=error").Parse(text)
+}
+
+// readSecrets reads the values of the pool secrets for delivery via MMDS.
+// Errors only name the secret, never its value.
+func (p *Pool) readSecrets() ([]interface{}, error) {
+	secrets := make([]interface{}, 0, len(p.config.Secrets))
+	for _, secret := range p.config.Secrets {
+		value, err := readSecret(secret.File, secret.Env)
+		if err != nil {
+			return nil, fmt.Errorf("reading secret %s: %w", secret.Name, err)
+		}
+
+		secrets = append(secrets, map[string]interface{}{
+			"name":   secret.Name,
+			"value":  value,
+			"target": secret.Target,
+		})
+	}
+
+	return secrets, nil
+}
+
+// scrubMetadata removes the given keys of the fireactions metadata from MMDS
+// once the agent reports that it has loaded its configuration. The keys stay
+// in MMDS if the agent does not report back within metadataScrubTimeout.
+func (p *Pool) scrubMetadata(machine *Machine, keys ...string) {
+	ctx, cancel := context.WithTimeout(machine.vmmCtx, metadataScrubTimeout)
+	defer cancel()
+
+	select {
+	case <-machine.configLoadedCh():
+	case <-ctx.Done():
+		if machine.vmmCtx.Err() == nil {
+			p.logger.Warn().Strs("keys", keys).Msgf("Timeout waiting for agent of Firecracker VM %s to load its config, keys remain in MMDS", machine.Name)
+		}
+		return
+	}
+
+	patch := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		patch[key] = nil
+	}
+
+	err := machine.UpdateMetadata(ctx, map[string]interface{}{
+		"latest": map[string]interface{}{"meta-data": map[string]interface{}{"fireactions": patch}},
+	})
+	if err != nil {
+		p.logger.Error().Err(err).Strs("keys", keys).Msgf("Failed to scrub MMDS of Firecracker VM %s", machine.Name)
+		return
+	}
+
+	p.logger.Debug().Strs("keys", keys).Msgf("Scrubbed MMDS of Firecracker VM %s", machine.Name)
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderMetadata(t *testing.T) {
+	metadata := map[string]interface{}{
+		"plain":    "value",
+		"number":   42,
+		"hostname": "{{ .Pool }}-{{ .Index }}",
+		"cache": map[string]interface{}{
+			"prefix": "{{ .Host }}/{{ .ImageDigest }}",
+		},
+		"tags":   []interface{}{"{{ .RunnerName }}", "static"},
+		"region": "{{ .Env.REGION }}",
+		"cid":    "{{ .VsockCID }}",
+	}
+
+	data := &metadataTemplateData{
+		RunnerName:  "runner-abc",
+		Pool:        "default",
+		Index:       3,
+		Host:        "host1",
+		VsockCID:    42,
+		ImageDigest: "sha256:1234",
+		Env:         map[string]string{"REGION": "eu"},
+	}
+
+	require.NoError(t, renderMetadata(metadata, data))
+	assert.Equal(t, map[string]interface{}{
+		"plain":    "value",
+		"number":   42,
+		"hostname": "default-3",
+		"cache": map[string]interface{}{
+			"prefix": "host1/sha256:1234",
+		},
+		"tags":   []interface{}{"runner-abc", "static"},
+		"region": "eu",
+		"cid":    "42",
+	}, metadata)
+}
+
+func TestRenderMetadata_MissingEnv(t *testing.T) {
+	metadata := map[string]interface{}{"region": "{{ .Env.REGION }}"}
+	err := renderMetadata(metadata, &metadataTemplateData{Env: map[string]string{}})
+	assert.Error(t, err)
+}
+
+func TestPool_MachineMetadata(t *testing.T) {
+	var config FirecrackerConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+metadata:
+  tags: ["{{ .RunnerName }}", static]
+  cache:
+    mirrors:
+      - url: https://{{ .Pool }}.example.com
+  since: 2024-01-01
+`), &config))
+
+	p := &Pool{config: &PoolConfig{Firecracker: &config}}
+	require.NoError(t, validateMetadata(config.Metadata))
+
+	metadata, err := p.machineMetadata(&metadataTemplateData{RunnerName: "runner-abc", Pool: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"runner-abc", "static"}, metadata["tags"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://default.example.com"}}, metadata["cache"].(map[string]interface{})["mirrors"])
+	assert.Equal(t, []interface{}{"{{ .RunnerName }}", "static"}, config.Metadata["tags"])
+}
+
+func TestMetadataEnv(t *testing.T) {
+	env := metadataEnv([]string{
+		"FIREACTIONS_META_REGION=eu",
+		"FIREACTIONS_META_URL=https://cache?a=b",
+		"FIREACTIONS_META_=empty",
+		"GITHUB_TOKEN=secret",
+		"FIREACTIONS_REGION=us",
+	})
+	assert.Equal(t, map[string]string{"REGION": "eu", "URL": "https://cache?a=b"}, env)
+}
+
+func TestValidateMetadata(t *testing.T) {
+	assert.NoError(t, validateMetadata(map[string]interface{}{"a": "{{ .Pool }}", "b": []interface{}{"plain"}}))
+	assert.Error(t, validateMetadata(map[string]interface{}{"a": map[string]interface{}{"b": "{{ .Pool"}}))
+}
+
+func TestPool_ReadSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache-key")
+	require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0600))
+	t.Setenv("FIREACTIONS_TEST_MIRROR_TOKEN", "token")
+
+	p := &Pool{config: &PoolConfig{Secrets: []*SecretConfig{
+		{Name: "cache-key", File: path, Target: "file"},
+		{Name: "MIRROR_TOKEN", Env: "FIREACTIONS_TEST_MIRROR_TOKEN", Target: "env"},
+	}}}
+
+	secrets, err := p.readSecrets()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "cache-key", "value": "s3cret", "target": "file"},
+		map[string]interface{}{"name": "MIRROR_TOKEN", "value": "token", "target": "env"},
+	}, secrets)
+
+	p.config.Secrets = append(p.config.Secrets, &SecretConfig{Name: "missing", Env: "FIREACTIONS_TEST_MISSING", Target: "env"})
+	_, err = p.readSecrets()
+	assert.ErrorContains(t, err, "missing")
+}
+
+func TestSecretConfig_UnmarshalYAML(t *testing.T) {
+	var secret SecretConfig
+	require.NoError(t, yaml.Unmarshal([]byte("name: CACHE_KEY\nenv: CACHE_KEY\n"), &secret))
+	assert.Equal(t, "file", secret.Target)
+	assert.NoError(t, validator.New().Struct(secret))
+
+	secret = SecretConfig{Name: "a/b", File: "/etc/secret", Target: "file"}
+	assert.Error(t, validator.New().Struct(secret))
+
+	secret = SecretConfig{Name: "CACHE_KEY", File: "/etc/secret", Env: "CACHE_KEY", Target: "env"}
+	assert.Error(t, validator.New().Struct(secret))
+}
+
+// newTestMMDS returns a machine whose Firecracker API is served by a fake that
+// records the MMDS patches.
+func newTestMMDS(t *testing.T, vmmCtx context.Context) (*Machine, <-chan map[string]interface{}) {
+	t.Helper()
+
+	socketPath := filepath.Join(t.TempDir(), "firecracker.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	patches := make(chan map[string]interface{}, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/mmds" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var patch map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		patches <- patch
+		w.WriteHeader(http.StatusNoContent)
+	})}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	vm, err := firecracker.NewMachine(vmmCtx, firecracker.Config{SocketPath: socketPath, DisableValidation: true}, firecracker.WithLogger(logrus.NewEntry(logger)))
+	require.NoError(t, err)
+
+	return &Machine{Machine: vm, Name: "vm1", vmmCtx: vmmCtx}, patches
+}
+
+func TestPool_ScrubMetadata(t *testing.T) {
+	logger := zerolog.Nop()
+	p := &Pool{logger: &logger}
+	machine, patches := newTestMMDS(t, t.Context())
+
+	machine.recordConfigStatus(true, false)
+	p.scrubMetadata(machine, "runner_jit_config", "secrets")
+
+	select {
+	case patch := <-patches:
+		assert.Equal(t, map[string]interface{}{
+			"latest": map[string]interface{}{"meta-data": map[string]interface{}{"fireactions": map[string]interface{}{
+				"runner_jit_config": nil,
+				"secrets":           nil,
+			}}},
+		}, patch, "the keys are removed by patching them to null")
+	default:
+		t.Fatal("MMDS was not scrubbed")
+	}
+}
+
+func TestPool_ScrubMetadata_VMStopped(t *testing.T) {
+	logger := zerolog.Nop()
+	p := &Pool{logger: &logger}
+
+	vmmCtx, cancel := context.WithCancel(t.Context())
+	machine, patches := newTestMMDS(t, vmmCtx)
+	cancel()
+
+	p.scrubMetadata(machine, "runner_jit_config")
+	assert.Empty(t, patches, "MMDS is not scrubbed before the agent loaded its config")
+}
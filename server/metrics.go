@@ -40,6 +40,18 @@ var (
 		Help:      "Number of pending VM create/delete operations in a pool",
 	}, []string{"pool", "organization"})
 
+	metricPoolRunnersUnhealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "pool_runners_unhealthy",
+		Namespace: namespace,
+		Help:      "Number of VMs in a pool that missed their heartbeats",
+	}, []string{"pool", "organization"})
+
+	metricPoolRunnersHeld = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "pool_runners_held",
+		Namespace: namespace,
+		Help:      "Number of VMs in a pool kept after a failed job, not counted as current runners",
+	}, []string{"pool", "organization"})
+
 	metricPoolScaleRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name:      "pool_scale_requests_total",
 		Namespace: namespace,
@@ -64,4 +76,124 @@ var (
 		Namespace: namespace,
 		Help:      "Status of a pool. 0 is paused, 1 is active.",
 	}, []string{"pool"})
+
+	metricImageGCRemovedImages = promauto.NewCounter(prometheus.CounterOpts{
+		Name:      "image_gc_removed_images_total",
+		Namespace: namespace,
+		Help:      "Total number of images removed by the image garbage collector",
+	})
+
+	metricImageGCReclaimedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name:      "image_gc_reclaimed_bytes_total",
+		Namespace: namespace,
+		Help:      "Total compressed size in bytes of the images removed by the image garbage collector, plus the files built from them",
+	})
+
+	metricImageGCReclaimableImages = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:      "image_gc_reclaimable_images",
+		Namespace: namespace,
+		Help:      "Number of images the image garbage collector would remove in dry-run mode",
+	})
+
+	metricImageGCReclaimableBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:      "image_gc_reclaimable_bytes",
+		Namespace: namespace,
+		Help:      "Compressed size in bytes of the images the image garbage collector would remove in dry-run mode, plus the files built from them",
+	})
+
+	metricImageGCDiskUsage = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:      "image_gc_disk_usage_percent",
+		Namespace: namespace,
+		Help:      "Disk usage of the filesystem watched by the image garbage collector in percent",
+	})
+
+	metricImageVerifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "image_verifications_total",
+		Namespace: namespace,
+		Help:      "Total number of image signature verifications",
+	}, []string{"pool", "result"})
+
+	metricMachineCPUs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_cpus",
+		Namespace: namespace,
+		Help:      "Number of CPUs seen by the guest of a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineCPUSeconds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "machine_cpu_seconds_total",
+		Namespace: namespace,
+		Help:      "CPU time a machine spent not idle, summed over all its CPUs",
+	}, []string{"pool", "machine"})
+
+	metricMachineLoad1 = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_load1",
+		Namespace: namespace,
+		Help:      "1m load average of a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineLoad5 = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_load5",
+		Namespace: namespace,
+		Help:      "5m load average of a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineLoad15 = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_load15",
+		Namespace: namespace,
+		Help:      "15m load average of a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineMemoryTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_memory_total_bytes",
+		Namespace: namespace,
+		Help:      "Total memory of a machine as seen by the guest",
+	}, []string{"pool", "machine"})
+
+	metricMachineMemoryUsed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_memory_used_bytes",
+		Namespace: namespace,
+		Help:      "Memory used by a machine, excluding reclaimable caches",
+	}, []string{"pool", "machine"})
+
+	metricMachineMemoryAvailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_memory_available_bytes",
+		Namespace: namespace,
+		Help:      "Memory available to new processes of a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineSwapUsed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_swap_used_bytes",
+		Namespace: namespace,
+		Help:      "Swap used by a machine",
+	}, []string{"pool", "machine"})
+
+	metricMachineDiskTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_disk_total_bytes",
+		Namespace: namespace,
+		Help:      "Size of a filesystem mounted in a machine",
+	}, []string{"pool", "machine", "mountpoint"})
+
+	metricMachineDiskUsed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      "machine_disk_used_bytes",
+		Namespace: namespace,
+		Help:      "Used space of a filesystem mounted in a machine",
+	}, []string{"pool", "machine", "mountpoint"})
+
+	metricMachineNetworkReceive = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "machine_network_receive_bytes_total",
+		Namespace: namespace,
+		Help:      "Bytes received by a network interface of a machine",
+	}, []string{"pool", "machine", "interface"})
+
+	metricMachineNetworkTransmit = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "machine_network_transmit_bytes_total",
+		Namespace: namespace,
+		Help:      "Bytes transmitted by a network interface of a machine",
+	}, []string{"pool", "machine", "interface"})
+
+	metricMachineStatsErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      "machine_stats_errors_total",
+		Namespace: namespace,
+		Help:      "Total number of failed machine stats scrapes",
+	}, []string{"pool"})
 )
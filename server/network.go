@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/firecracker-microvm/firecracker-go-sdk/cni/vmconf"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	cniNetworkName = "fireactions"
+	cniIfName      = "eth0"
+	cniConfDir     = "/etc/cni/net.d"
+	cniBinDir      = "/opt/cni/bin"
+	cniCacheDir    = "/var/lib/cni"
+	netNSDir       = "/var/run/netns"
+)
+
+// machineNetwork is the CNI network of a machine set up by the server instead
+// of the Firecracker SDK. The SDK tears the network down when the Firecracker
+// process exits, but machines restored between jobs need the same network
+// namespace, tap device and IP address for all their Firecracker processes.
+type machineNetwork struct {
+	netNS   string
+	cni     *libcni.CNIConfig
+	config  *libcni.NetworkConfigList
+	runtime *libcni.RuntimeConf
+	iface   firecracker.NetworkInterface
+}
+
+// setupMachineNetwork creates a network namespace for the machine identified
+// by id and adds it to the CNI network, like the SDK does.
+func setupMachineNetwork(ctx context.Context, id string) (*machineNetwork, error) {
+	config, err := libcni.LoadConfList(cniConfDir, cniNetworkName)
+	if err != nil {
+		return nil, fmt.Errorf("loading CNI configuration: %w", err)
+	}
+
+	n := &machineNetwork{
+		netNS:   filepath.Join(netNSDir, id),
+		cni:     libcni.NewCNIConfigWithCacheDir([]string{cniBinDir}, filepath.Join(cniCacheDir, id), nil),
+		config:  config,
+		runtime: &libcni.RuntimeConf{ContainerID: id, NetNS: filepath.Join(netNSDir, id), IfName: cniIfName},
+	}
+
+	if err := createNetNS(n.netNS); err != nil {
+		return nil, fmt.Errorf("creating network namespace: %w", err)
+	}
+
+	result, err := n.cni.AddNetworkList(ctx, config, n.runtime)
+	if err != nil {
+		_ = n.teardown(context.Background())
+		return nil, fmt.Errorf("adding CNI network: %w", err)
+	}
+
+	conf, err := vmconf.StaticNetworkConfFrom(result, id)
+	if err != nil {
+		_ = n.teardown(context.Background())
+		return nil, fmt.Errorf("parsing CNI result: %w", err)
+	}
+
+	n.iface = firecracker.NetworkInterface{
+		AllowMMDS: true,
+		StaticConfiguration: &firecracker.StaticNetworkConfiguration{
+			HostDevName: conf.TapName,
+			MacAddress:  conf.VMMacAddr,
+		},
+	}
+
+	if conf.VMIPConfig != nil {
+		nameservers := conf.VMNameservers
+		if len(nameservers) > 2 {
+			nameservers = nameservers[:2]
+		}
+
+		n.iface.StaticConfiguration.IPConfiguration = &firecracker.IPConfiguration{
+			IPAddr:      conf.VMIPConfig.Address,
+			Gateway:     conf.VMIPConfig.Gateway,
+			Nameservers: nameservers,
+		}
+	}
+
+	return n, nil
+}
+
+// teardown removes the machine from the CNI network and deletes its network
+// namespace.
+func (n *machineNetwork) teardown(ctx context.Context) error {
+	if err := n.cni.DelNetworkList(ctx, n.config, n.runtime); err != nil {
+		return fmt.Errorf("deleting CNI network: %w", err)
+	}
+
+	if err := removeNetNS(n.netNS); err != nil {
+		return fmt.Errorf("removing network namespace: %w", err)
+	}
+
+	return nil
+}
+
+// createNetNS creates a network namespace and bind mounts it at path.
+func createNetNS(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_ = file.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		// The thread is left locked so that it is thrown away with the
+		// namespace instead of being reused by other goroutines
+		runtime.LockOSThread()
+
+		if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+			errCh <- fmt.Errorf("unshare: %w", err)
+			return
+		}
+
+		if err := unix.Mount("/proc/thread-self/ns/net", path, "none", unix.MS_BIND, ""); err != nil {
+			errCh <- fmt.Errorf("mount: %w", err)
+			return
+		}
+
+		errCh <- nil
+	}()
+
+	if err := <-errCh; err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+// removeNetNS unmounts and removes the network namespace at path.
+func removeNetNS(path string) error {
+	if err := unix.Unmount(path, unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
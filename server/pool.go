@@ -2,26 +2,26 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/leases"
-	"github.com/containerd/containerd/mount"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/firecracker-microvm/firecracker-go-sdk"
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
-	"github.com/hostinger/fireactions/helper/deepcopy"
+	"github.com/hostinger/fireactions/agent/runner"
 	"github.com/hostinger/fireactions/helper/github"
 	"github.com/hostinger/fireactions/helper/stringid"
-	"github.com/opencontainers/image-spec/identity"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
 
@@ -29,7 +29,16 @@ import (
 )
 
 const (
-	defaultSnapshotter = "devmapper"
+	// machineLeasePrefix is the prefix of the containerd leases created for machines.
+	machineLeasePrefix = "fireactions/pools/"
+
+	// shutdownTimeoutMargin is how long VMs get to power off after the
+	// shutdown grace period before their VMM is stopped.
+	shutdownTimeoutMargin = 30 * time.Second
+
+	// stopCleanupTimeout is how much of the time given to stop a pool is
+	// kept to clean up its machines once they exited.
+	stopCleanupTimeout = 20 * time.Second
 )
 
 // Pool represents a pool of Firecracker VMs that are used to run GitHub Actions jobs.
@@ -38,10 +47,16 @@ type Pool struct {
 	containerd     *containerd.Client
 	github         *github.Client
 	imageManager   *imageManager
+	image          atomic.Value // containerd.Image used for new machines
+	pullAlways     bool         // Whether the image is pulled for every machine rather than refreshed
+	verifier       *imageVerifier
+	heartbeat      *HeartbeatConfig
+	rootfs         rootfsBackend
 	pendingCreates atomic.Int32
 	pendingDeletes atomic.Int32
 	machinesMu     *sync.Mutex
 	machines       map[string]*Machine
+	indexes        map[int]bool // Machine indexes in use, guarded by machinesMu
 	installationID atomic.Int64
 	logger         *zerolog.Logger
 	replicas       atomic.Int32
@@ -50,26 +65,46 @@ type Pool struct {
 	stopCh         chan struct{}
 	doneCh         chan struct{}
 	cleanupWg      sync.WaitGroup
+	createWg       sync.WaitGroup // Machines being created
 	ctx            context.Context
 	cancel         context.CancelFunc
+	createCtx      context.Context // Cancelled first when the pool stops
+	createCancel   context.CancelFunc
 	nextCID        *atomic.Uint32
 	l              *sync.Mutex
 }
 
 // PoolConfig represents the configuration of a Pool.
 type PoolConfig struct {
-	Name           string             `yaml:"name" validate:"required"`
-	ShutdownOnExit *bool              `yaml:"shutdown_on_exit"`
-	Replicas       int                `yaml:"replicas" validate:"min=0"`
-	Runner         *RunnerConfig      `yaml:"runner" validate:"required"`
-	Firecracker    *FirecrackerConfig `yaml:"firecracker" validate:"required"`
+	Name           string                     `yaml:"name" validate:"required"`
+	ShutdownOnExit *bool                      `yaml:"shutdown_on_exit"`
+	MMDSRootOnly   bool                       `yaml:"mmds_root_only"`
+	Replicas       int                        `yaml:"replicas" validate:"min=0"`
+	Runner         *RunnerConfig              `yaml:"runner" validate:"required"`
+	Firecracker    *FirecrackerConfig         `yaml:"firecracker" validate:"required"`
+	Registries     map[string]*RegistryConfig `yaml:"registries" validate:"dive"`
+	Secrets        []*SecretConfig            `yaml:"secrets" validate:"dive"`
+	Hooks          *HooksConfig               `yaml:"hooks"`
+	MultiJob       *MultiJobConfig            `yaml:"multi_job"`
+	KeepOnFailure  *KeepOnFailureConfig       `yaml:"keep_on_failure"`
+	Agent          *AgentConfig               `yaml:"agent"`
+
+	// RestoreBetweenJobs makes VMs run one job after the other, restoring
+	// them from a snapshot taken after boot before every job.
+	RestoreBetweenJobs bool `yaml:"restore_between_jobs" validate:"excluded_with=MultiJob"`
+
+	// ShutdownGracePeriod is how long VMs that are scaled down or stopped
+	// with the pool get to complete their current job before they are
+	// powered off. VMs that did not exit shortly after are stopped.
+	ShutdownGracePeriod time.Duration `yaml:"shutdown_grace_period" validate:"min=0"`
 }
 
 // UnmarshalYAML implements custom unmarshaling to set defaults.
 func (p *PoolConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type poolConfigAlias PoolConfig
 	defaults := poolConfigAlias{
-		ShutdownOnExit: func() *bool { b := true; return &b }(),
+		ShutdownOnExit:      func() *bool { b := true; return &b }(),
+		ShutdownGracePeriod: 5 * time.Minute,
 	}
 
 	if err := unmarshal(&defaults); err != nil {
@@ -81,31 +116,54 @@ func (p *PoolConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 // NewPool creates a new Pool.
-func NewPool(logger *zerolog.Logger, config *PoolConfig, github *github.Client, imageManager *imageManager, containerdClient *containerd.Client, nextCID *atomic.Uint32) (*Pool, error) {
+func NewPool(logger *zerolog.Logger, config *PoolConfig, github *github.Client, imageManager *imageManager, rootfs rootfsBackend, containerdClient *containerd.Client, nextCID *atomic.Uint32, heartbeat *HeartbeatConfig, imageRefreshInterval time.Duration) (*Pool, error) {
 	l := logger.With().Str("pool", config.Name).Logger()
 
 	ctx, cancel := context.WithCancel(context.Background())
+	createCtx, createCancel := context.WithCancel(ctx)
 
 	p := &Pool{
 		config:       config,
 		l:            &sync.Mutex{},
 		machinesMu:   &sync.Mutex{},
 		machines:     make(map[string]*Machine),
+		indexes:      make(map[int]bool),
 		isActive:     true,
 		containerd:   containerdClient,
 		github:       github,
-		imageManager: imageManager,
+		imageManager: imageManager.withRegistries(config.Registries),
+		rootfs:       rootfs,
 		logger:       &l,
 		scaleTrigger: make(chan struct{}, 1),
 		stopCh:       make(chan struct{}, 1),
 		doneCh:       make(chan struct{}),
 		ctx:          ctx,
 		cancel:       cancel,
+		createCtx:    createCtx,
+		createCancel: createCancel,
 		nextCID:      nextCID,
+		heartbeat:    heartbeat,
 	}
 
 	p.replicas.Store(int32(config.Replicas))
 
+	// The image controller keeps the image of the pool up to date unless
+	// the refresh is disabled
+	p.pullAlways = config.Runner.ImagePullPolicy == "Always" && imageRefreshInterval <= 0
+
+	if err := validateMetadata(config.Firecracker.Metadata); err != nil {
+		return nil, fmt.Errorf("metadata: %w", err)
+	}
+
+	if config.Runner.ImageVerification != nil {
+		verifier, err := newImageVerifier(&l, p.imageManager, config.Runner.ImageVerification.PublicKeys, imageRefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("image verification: %w", err)
+		}
+
+		p.verifier = verifier
+	}
+
 	if _, err := os.Stat(p.GetDir()); os.IsNotExist(err) {
 		if err := os.MkdirAll(p.GetDir(), 0755); err != nil {
 			return nil, fmt.Errorf("creating pool directory: %w", err)
@@ -163,6 +221,10 @@ func (p *Pool) Run() {
 			WithLabelValues(p.config.Name, p.config.Runner.Organization).Set(float64(desiredReplicas))
 		metricPoolRunnersPending.
 			WithLabelValues(p.config.Name, p.config.Runner.Organization).Set(float64(netPending))
+		metricPoolRunnersHeld.
+			WithLabelValues(p.config.Name, p.config.Runner.Organization).Set(float64(p.heldCount()))
+
+		p.checkHealth(time.Now())
 
 		if !p.isActive {
 			p.logger.Debug().Msgf("Pool %s is paused, skipping scaling", p.config.Name)
@@ -170,7 +232,7 @@ func (p *Pool) Run() {
 		}
 
 		// Scale to desired replicas
-		if err := p.Scale(p.ctx, desiredReplicas); err != nil {
+		if err := p.Scale(p.createCtx, desiredReplicas); err != nil {
 			// Don't log errors if context was cancelled (pool is stopping)
 			if p.ctx.Err() == nil {
 				p.logger.Error().Err(err).Msg("Failed to scale pool")
@@ -179,10 +241,11 @@ func (p *Pool) Run() {
 	}
 }
 
-// Stop stops the pool. Stopping the pool will stop all the VMs in the pool.
-func (p *Pool) Stop() {
+// Stop stops the pool. Stopping the pool cancels the machines being created
+// and stops all the VMs in the pool, gracefully within the shutdown grace
+// period. VMs still running when ctx is done are stopped.
+func (p *Pool) Stop(ctx context.Context) {
 	p.logger.Debug().Msgf("Stopping pool %s", p.config.Name)
-	p.cancel()
 
 	// Signal the Start() loop to exit (non-blocking)
 	select {
@@ -198,7 +261,20 @@ func (p *Pool) Stop() {
 		p.logger.Warn().Msg("Timeout waiting for Run() to exit")
 	}
 
-	p.logger.Debug().Msgf("Stopping %d machines in pool %s", len(p.machines), p.config.Name)
+	// Leave time to clean up the machines once they exited
+	stopCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		stopCtx, cancel = context.WithDeadline(ctx, deadline.Add(-stopCleanupTimeout))
+		defer cancel()
+	}
+
+	// Cancel machines that are being created, so that they don't delay the
+	// shutdown nor start after the running machines were listed
+	p.createCancel()
+	if !waitGroupWait(stopCtx, &p.createWg) {
+		p.logger.Warn().Msg("Timeout waiting for machines being created to be cancelled")
+	}
 
 	p.machinesMu.Lock()
 	machines := make([]*Machine, 0, len(p.machines))
@@ -207,31 +283,86 @@ func (p *Pool) Stop() {
 	}
 	p.machinesMu.Unlock()
 
-	// Stop all machines - cleanup goroutines will handle the rest
+	p.logger.Debug().Msgf("Stopping %d machines in pool %s", len(machines), p.config.Name)
+
+	var wg sync.WaitGroup
 	for _, machine := range machines {
-		runnerName := machine.Cfg.VMID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-		err := machine.StopVMM()
-		if err != nil {
-			p.logger.Error().Err(err).Msgf("Failed to stop Firecracker VM %s", runnerName)
-		}
+			if err := p.stopMachine(stopCtx, machine, "pool stopped"); err != nil {
+				p.logger.Error().Err(err).Msgf("Failed to stop Firecracker VM %s", machine.Name)
+				return
+			}
+
+			p.logger.Debug().Msgf("Stopped Firecracker VM %s", machine.Name)
+		}()
+	}
+	wg.Wait()
 
-		p.logger.Debug().Msgf("Stopped Firecracker VM %s", runnerName)
+	p.cancel()
+
+	if !waitGroupWait(ctx, &p.cleanupWg) {
+		p.logger.Warn().Msg("Timeout waiting for cleanup goroutines to finish")
 	}
 
-	cleanupDone := make(chan struct{})
+	p.logger.Debug().Msgf("Pool %s stopped", p.config.Name)
+}
+
+// waitGroupWait waits for wg until ctx is done and reports whether wg is
+// done.
+func waitGroupWait(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
 	go func() {
-		p.cleanupWg.Wait()
-		close(cleanupDone)
+		wg.Wait()
+		close(done)
 	}()
 
 	select {
-	case <-cleanupDone:
-	case <-time.After(35 * time.Second):
-		p.logger.Warn().Msg("Timeout waiting for cleanup goroutines to finish")
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
 	}
+}
 
-	p.logger.Debug().Msgf("Pool %s stopped", p.config.Name)
+// checkHealth logs machines whose health changed and updates the number of
+// unhealthy machines of the pool.
+func (p *Pool) checkHealth(now time.Time) {
+	p.machinesMu.Lock()
+	machines := make([]*Machine, 0, len(p.machines))
+	for _, machine := range p.machines {
+		machines = append(machines, machine)
+	}
+	p.machinesMu.Unlock()
+
+	unhealthy := 0
+	for _, machine := range machines {
+		healthy := machine.isHealthy(now)
+		if !healthy {
+			unhealthy++
+		}
+
+		machine.statusMu.Lock()
+		changed := machine.unhealthy == healthy
+		machine.unhealthy = !healthy
+		machine.statusMu.Unlock()
+
+		if !changed {
+			continue
+		}
+
+		_, _, lastSeen := machine.status()
+		if healthy {
+			p.logger.Info().Msgf("Firecracker VM %s is healthy again", machine.Name)
+		} else {
+			p.logger.Warn().Time("last_seen", lastSeen).Msgf("Firecracker VM %s is unhealthy, no heartbeat within %s", machine.Name, p.heartbeat.Timeout)
+		}
+	}
+
+	metricPoolRunnersUnhealthy.
+		WithLabelValues(p.config.Name, p.config.Runner.Organization).Set(float64(unhealthy))
 }
 
 // GetDir returns the directory where the pool sockets and logs are stored.
@@ -239,6 +370,15 @@ func (p *Pool) GetDir() string {
 	return fmt.Sprintf("/var/lib/fireactions/pools/%s", p.config.Name)
 }
 
+// runnerUser returns the user the runner of the pool runs as.
+func (p *Pool) runnerUser() string {
+	if p.config.Runner.User != "" {
+		return p.config.Runner.User
+	}
+
+	return runner.DefaultOwner
+}
+
 // Scale scales the pool to the desired size.
 func (p *Pool) Scale(ctx context.Context, desiredReplicas int) error {
 	p.l.Lock()
@@ -250,9 +390,7 @@ func (p *Pool) Scale(ctx context.Context, desiredReplicas int) error {
 	default:
 	}
 
-	curSize := p.GetCurrentSize()
-	pendingCreates := int(p.pendingCreates.Load())
-	pendingDeletes := int(p.pendingDeletes.Load())
+	curSize, pendingCreates, pendingDeletes := p.sizes()
 
 	// Calculate effective size accounting for in-flight operations
 	effectiveSize := curSize + pendingCreates - pendingDeletes
@@ -279,18 +417,21 @@ func (p *Pool) scaleUp(ctx context.Context, count, desiredReplicas, curSize, pen
 
 	for i := 0; i < count; i++ {
 		p.pendingCreates.Add(1)
+		p.createWg.Add(1)
 
 		go func() {
-			defer p.pendingCreates.Add(-1)
+			defer p.createWg.Done()
 
 			select {
-			case <-p.ctx.Done():
+			case <-ctx.Done():
+				p.pendingCreates.Add(-1)
 				return
 			default:
 			}
 
 			start := time.Now()
 			if err := p.createMachine(ctx); err != nil {
+				p.pendingCreates.Add(-1)
 				metricScaleOperations.WithLabelValues(p.config.Name, p.config.Runner.Organization, "up", "failure").Inc()
 				p.logger.Error().Err(err).Msg("Failed to create machine")
 				return
@@ -315,10 +456,9 @@ func (p *Pool) scaleDown(ctx context.Context, count, desiredReplicas, curSize, p
 		p.pendingDeletes.Add(1)
 
 		go func() {
-			defer p.pendingDeletes.Add(-1)
-
 			select {
 			case <-p.ctx.Done():
+				p.pendingDeletes.Add(-1)
 				return
 			default:
 			}
@@ -376,11 +516,36 @@ func (p *Pool) GetReplicas() int {
 	return int(p.replicas.Load())
 }
 
-// GetCurrentSize returns the current size of the pool.
+// GetCurrentSize returns the current size of the pool. Held machines are not
+// counted, they are replaced by new ones.
 func (p *Pool) GetCurrentSize() int {
 	p.machinesMu.Lock()
 	defer p.machinesMu.Unlock()
-	return len(p.machines)
+
+	return p.currentSize()
+}
+
+// sizes returns the current size of the pool and its pending creates and
+// deletes at once. Machines leave the pending creates and deletes as they
+// enter and leave the pool, so none is counted twice.
+func (p *Pool) sizes() (curSize, pendingCreates, pendingDeletes int) {
+	p.machinesMu.Lock()
+	defer p.machinesMu.Unlock()
+
+	return p.currentSize(), int(p.pendingCreates.Load()), int(p.pendingDeletes.Load())
+}
+
+// currentSize returns the number of machines of the pool that are not held.
+// The caller must hold machinesMu.
+func (p *Pool) currentSize() int {
+	size := 0
+	for _, machine := range p.machines {
+		if !machine.isHeld() {
+			size++
+		}
+	}
+
+	return size
 }
 
 func (p *Pool) ListMachines(ctx context.Context) ([]*Machine, error) {
@@ -407,20 +572,112 @@ func (p *Pool) GetMachine(name string) (*Machine, error) {
 	return machine, nil
 }
 
-func (p *Pool) createMachine(ctx context.Context) error {
+// getImage returns the image new machines are created from, or nil if the
+// image has not been pulled yet.
+func (p *Pool) getImage() containerd.Image {
+	image, _ := p.image.Load().(containerd.Image)
+	return image
+}
+
+// setImage atomically switches the image new machines are created from.
+func (p *Pool) setImage(image containerd.Image) {
+	p.image.Store(image)
+}
+
+// acquireIndex reserves the lowest machine index not used by another machine
+// of the pool.
+func (p *Pool) acquireIndex() int {
+	p.machinesMu.Lock()
+	defer p.machinesMu.Unlock()
+
+	index := 0
+	for p.indexes[index] {
+		index++
+	}
+
+	p.indexes[index] = true
+	return index
+}
+
+// releaseIndex makes the machine index available to new machines.
+func (p *Pool) releaseIndex(index int) {
+	p.machinesMu.Lock()
+	defer p.machinesMu.Unlock()
+
+	delete(p.indexes, index)
+}
+
+// useImage verifies the image signature, if the pool requires one, unpacks
+// the image and switches new machines to it. Unverified images are never
+// unpacked nor pinned.
+func (p *Pool) useImage(ctx context.Context, image containerd.Image) error {
+	if p.verifier != nil {
+		err := p.verifier.verify(ctx, image.Name(), image.Target().Digest)
+		metricImageVerifications.WithLabelValues(p.config.Name, verificationResult(err)).Inc()
+		if err != nil {
+			return fmt.Errorf("verifying image %s (%s): %w", image.Name(), image.Target().Digest, err)
+		}
+	}
+
+	if err := p.imageManager.unpackImage(ctx, image); err != nil {
+		return fmt.Errorf("unpacking image %s: %w", image.Name(), err)
+	}
+
+	p.setImage(image)
+	return nil
+}
+
+// ensureImage returns the image pinned by the image controller, falling back
+// to pulling it according to the pull policy if it is not available yet. With
+// the Always pull policy and the image refresh disabled, the image is pulled
+// for every machine instead.
+func (p *Pool) ensureImage(ctx context.Context) (containerd.Image, error) {
+	if image := p.getImage(); image != nil && !p.pullAlways {
+		return image, nil
+	}
+
 	image, err := p.imageManager.ensureImage(
 		ctx,
 		p.config.Runner.Image,
 		p.config.Runner.ImagePullPolicy,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.useImage(ctx, image); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (p *Pool) createMachine(ctx context.Context) error {
+	image, err := p.ensureImage(ctx)
 	if err != nil {
 		return fmt.Errorf("ensuring image: %w", err)
 	}
 
+	kernelImagePath := p.config.Firecracker.KernelImagePath
+	kernelDigest := ""
+	if p.config.Firecracker.KernelImage != "" {
+		kernel, err := p.imageManager.ensureKernel(
+			ctx,
+			p.config.Firecracker.KernelImage,
+			p.config.Firecracker.KernelImagePullPolicy,
+		)
+		if err != nil {
+			return fmt.Errorf("ensuring kernel: %w", err)
+		}
+
+		kernelImagePath = kernel.Path
+		kernelDigest = kernel.Digest
+	}
+
 	runnerName := fmt.Sprintf("%s-%s", p.config.Runner.Name, stringid.New())
 
 	leaseCtx, leaseCtxCancel, err := p.containerd.WithLease(ctx,
-		leases.WithID(fmt.Sprintf("fireactions/pools/%s/%s", p.config.Name, runnerName)))
+		leases.WithID(machineLeaseID(p.config.Name, runnerName)))
 	if err != nil {
 		return fmt.Errorf("containerd: creating lease: %w", err)
 	}
@@ -436,9 +693,54 @@ func (p *Pool) createMachine(ctx context.Context) error {
 		}
 	}()
 
-	snapshotMounts, err := p.createSnapshot(leaseCtx, image, runnerName)
+	// Reference the images from the lease, so they are not garbage collected
+	// while the machine is alive.
+	err = p.addLeaseResources(leaseCtx, machineLeaseID(p.config.Name, runnerName), image.Target().Digest.String(), kernelDigest)
 	if err != nil {
-		return fmt.Errorf("containerd: creating snapshot: %w", err)
+		return fmt.Errorf("containerd: %w", err)
+	}
+
+	rootfsPath, err := p.rootfs.prepare(leaseCtx, image, runnerName)
+	if err != nil {
+		return fmt.Errorf("rootfs: %w", err)
+	}
+	defer func() {
+		if !machineCreated {
+			_ = p.rootfs.release(context.Background(), runnerName)
+		}
+	}()
+
+	drivePath := rootfsPath
+	networkInterface := firecracker.NetworkInterface{
+		AllowMMDS:        true,
+		CNIConfiguration: &firecracker.CNIConfiguration{NetworkName: cniNetworkName, IfName: cniIfName, ConfDir: cniConfDir, BinPath: []string{cniBinDir}},
+	}
+
+	var network *machineNetwork
+	var netNS string
+	if p.config.RestoreBetweenJobs {
+		// The root drive is replaced for every job, the snapshot refers to it
+		// by a symlink that stays the same
+		drivePath = filepath.Join(p.GetDir(), fmt.Sprintf("%s.rootfs", runnerName))
+		if err := replaceSymlink(rootfsPath, drivePath); err != nil {
+			return fmt.Errorf("rootfs: %w", err)
+		}
+
+		network, err = setupMachineNetwork(ctx, runnerName)
+		if err != nil {
+			_ = os.Remove(drivePath)
+			return fmt.Errorf("network: %w", err)
+		}
+
+		defer func() {
+			if !machineCreated {
+				_ = network.teardown(context.Background())
+				_ = os.Remove(drivePath)
+			}
+		}()
+
+		networkInterface = network.iface
+		netNS = network.netNS
 	}
 
 	machineLogFile, err := os.Create(filepath.Join(p.GetDir(), fmt.Sprintf("%s.log", runnerName)))
@@ -447,12 +749,20 @@ func (p *Pool) createMachine(ctx context.Context) error {
 	}
 	defer machineLogFile.Close()
 
+	// The VMM outlives ctx, which is cancelled first when the pool stops
+	vmmCtx, vmmCancel := context.WithCancel(p.ctx)
+	defer func() {
+		if !machineCreated {
+			vmmCancel()
+		}
+	}()
+
 	machineCmd := firecracker.VMCommandBuilder{}.
 		WithSocketPath(filepath.Join(p.GetDir(), fmt.Sprintf("%s.sock", runnerName))).
 		WithStderr(machineLogFile).
 		WithStdout(machineLogFile).
 		WithBin(p.config.Firecracker.BinaryPath).
-		Build(ctx)
+		Build(vmmCtx)
 
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
@@ -461,10 +771,17 @@ func (p *Pool) createMachine(ctx context.Context) error {
 	vsockPath := filepath.Join(p.GetDir(), fmt.Sprintf("%s.vsock", runnerName))
 	vsockCID := p.nextCID.Add(1)
 
+	index := p.acquireIndex()
+	defer func() {
+		if !machineCreated {
+			p.releaseIndex(index)
+		}
+	}()
+
 	fcMachine, err := firecracker.NewMachine(ctx, firecracker.Config{
 		VMID:            runnerName,
 		SocketPath:      filepath.Join(p.GetDir(), fmt.Sprintf("%s.sock", runnerName)),
-		KernelImagePath: p.config.Firecracker.KernelImagePath,
+		KernelImagePath: kernelImagePath,
 		KernelArgs:      p.config.Firecracker.KernelArgs,
 		MachineCfg: models.MachineConfiguration{
 			VcpuCount:  &p.config.Firecracker.MachineConfig.VcpuCount,
@@ -472,14 +789,14 @@ func (p *Pool) createMachine(ctx context.Context) error {
 		},
 		Drives: []models.Drive{{
 			DriveID:      firecracker.String("rootfs"),
-			PathOnHost:   &snapshotMounts[0].Source,
+			PathOnHost:   &drivePath,
 			IsRootDevice: firecracker.Bool(true),
 			IsReadOnly:   firecracker.Bool(false),
 		}},
-		NetworkInterfaces: []firecracker.NetworkInterface{{
-			AllowMMDS:        true,
-			CNIConfiguration: &firecracker.CNIConfiguration{NetworkName: "fireactions", IfName: "eth0", ConfDir: "/etc/cni/net.d", BinPath: []string{"/opt/cni/bin"}},
-		}},
+		NetworkInterfaces: []firecracker.NetworkInterface{
+			networkInterface,
+		},
+		NetNS:          netNS,
 		VsockDevices:   []firecracker.VsockDevice{{Path: vsockPath, CID: vsockCID}},
 		MmdsAddress:    net.IPv4(169, 254, 169, 254),
 		MmdsVersion:    firecracker.MMDSv2,
@@ -491,6 +808,17 @@ func (p *Pool) createMachine(ctx context.Context) error {
 		return fmt.Errorf("firecracker: creating machine: %w", err)
 	}
 
+	secrets, err := p.readSecrets()
+	if err != nil {
+		return err
+	}
+
+	templateData := newMetadataTemplateData(runnerName, p.config.Name, index, vsockCID, image.Target().Digest.String())
+	userMetadata, err := p.machineMetadata(templateData)
+	if err != nil {
+		return err
+	}
+
 	installationID := p.installationID.Load()
 	if installationID == 0 {
 		installation, _, err := p.github.Apps.FindOrganizationInstallation(ctx, p.config.Runner.Organization)
@@ -502,29 +830,76 @@ func (p *Pool) createMachine(ctx context.Context) error {
 		p.installationID.Store(installationID)
 	}
 
-	client := p.github.Installation(installationID)
-	jitConfig, _, err := client.Actions.GenerateOrgJITConfig(ctx, p.config.Runner.Organization, &githubv63.GenerateJITConfigRequest{
-		Name:          runnerName,
-		RunnerGroupID: p.config.Runner.GroupID,
-		Labels:        p.config.Runner.Labels,
-	})
-	if err != nil {
-		return fmt.Errorf("github: %w", err)
+	// Machines restored between jobs are registered when they are restored
+	var jitConfig *githubv63.JITRunnerConfig
+	if !p.config.RestoreBetweenJobs {
+		client := p.github.Installation(installationID)
+		jitConfig, _, err = client.Actions.GenerateOrgJITConfig(ctx, p.config.Runner.Organization, &githubv63.GenerateJITConfigRequest{
+			Name:          runnerName,
+			RunnerGroupID: p.config.Runner.GroupID,
+			Labels:        p.config.Runner.Labels,
+		})
+		if err != nil {
+			return fmt.Errorf("github: %w", err)
+		}
+
+		// Don't leave the runner registered if the VM does not start
+		defer func() {
+			if !machineCreated {
+				p.deleteGitHubRunner(runnerName, jitConfig.GetRunner().GetID())
+			}
+		}()
+	}
+
+	fireactionsMetadata := map[string]interface{}{
+		"runner_id":          runnerName,
+		"runner_jit_config":  jitConfig.GetEncodedJITConfig(),
+		"hostname":           runnerName,
+		"shutdown_on_exit":   *p.config.ShutdownOnExit,
+		"mmds_root_only":     p.config.MMDSRootOnly,
+		"heartbeat_interval": p.heartbeat.Interval.Seconds(),
 	}
 
-	metadata := map[string]interface{}{"latest": map[string]interface{}{"meta-data": deepcopy.Map(p.config.Firecracker.Metadata)}}
-	metadata["latest"].(map[string]interface{})["meta-data"].(map[string]interface{})["fireactions"] = map[string]interface{}{
-		"runner_id":         runnerName,
-		"runner_jit_config": jitConfig.GetEncodedJITConfig(),
-		"hostname":          runnerName,
-		"shutdown_on_exit":  *p.config.ShutdownOnExit,
+	if len(secrets) > 0 {
+		fireactionsMetadata["secrets"] = secrets
 	}
 
+	if p.config.Hooks != nil {
+		fireactionsMetadata["hooks"] = p.config.Hooks.metadata()
+	}
+
+	if p.config.MultiJob != nil {
+		fireactionsMetadata["multi_job"] = p.config.MultiJob.metadata()
+	}
+
+	if p.config.KeepOnFailure != nil {
+		fireactionsMetadata["keep_on_failure"] = true
+	}
+
+	if runner := p.config.Runner.metadata(); runner != nil {
+		fireactionsMetadata["runner"] = runner
+	}
+
+	if p.config.Agent != nil {
+		if agent := p.config.Agent.metadata(); agent != nil {
+			fireactionsMetadata["agent"] = agent
+		}
+	}
+
+	if p.config.RestoreBetweenJobs {
+		// The agent waits for the JIT config, which is put into MMDS when
+		// the VM is restored, and the VM exits after every job
+		delete(fireactionsMetadata, "runner_jit_config")
+		fireactionsMetadata["restore"] = true
+		fireactionsMetadata["shutdown_on_exit"] = true
+	}
+
+	userMetadata["fireactions"] = fireactionsMetadata
+	metadata := map[string]interface{}{"latest": map[string]interface{}{"meta-data": userMetadata}}
+
 	fcMachine.Handlers.FcInit = fcMachine.Handlers.FcInit.Append(firecracker.NewSetMetadataHandler(metadata))
 
-	vmmCtx, vmmCancel := context.WithCancel(p.ctx)
 	if err := fcMachine.Start(vmmCtx); err != nil {
-		vmmCancel()
 		return fmt.Errorf("firecracker: starting machine: %w", err)
 	}
 
@@ -534,42 +909,72 @@ func (p *Pool) createMachine(ctx context.Context) error {
 	p.logger.Info().Msgf("Successfully created Firecracker VM %s", runnerName)
 
 	machine := &Machine{
-		Machine:     fcMachine,
-		Name:        jitConfig.GetRunner().GetName(),
-		RunnerID:    jitConfig.GetRunner().GetID(),
-		Pool:        p.config.Name,
-		CreatedAt:   time.Now().UTC(),
-		vsockCID:    vsockCID,
-		vsockPath:   vsockPath,
-		leaseCancel: leaseCtxCancel,
-		vmmCtx:      vmmCtx,
-		vmmCancel:   vmmCancel,
+		Machine:          fcMachine,
+		Name:             runnerName,
+		Pool:             p.config.Name,
+		CreatedAt:        time.Now().UTC(),
+		ImageDigest:      image.Target().Digest.String(),
+		KernelDigest:     kernelDigest,
+		Index:            index,
+		heartbeatTimeout: p.heartbeat.Timeout,
+		vsockCID:         vsockCID,
+		vsockPath:        vsockPath,
+		leaseCancel:      leaseCtxCancel,
+		vmmCtx:           vmmCtx,
+		vmmCancel:        vmmCancel,
+		runnerID:         jitConfig.GetRunner().GetID(),
+		network:          network,
+	}
+
+	if p.config.RestoreBetweenJobs {
+		machine.rootfsLink = drivePath
+		machine.metadata = restoreMetadata(userMetadata)
+	}
+
+	var renewRunner renewRunnerFunc
+	if p.config.MultiJob != nil {
+		renewRunner = p.renewNextJob
+	}
+
+	var holdMachine holdMachineFunc
+	if p.config.KeepOnFailure != nil {
+		holdMachine = p.holdMachine
+	}
+
+	if err := machine.startHostListener(p.logger, renewRunner, holdMachine); err != nil {
+		p.logger.Error().Err(err).Msgf("Failed to start host listener for Firecracker VM %s, its health will not be tracked", runnerName)
 	}
 
 	p.machinesMu.Lock()
 	p.machines[runnerName] = machine
+	p.pendingCreates.Add(-1)
 	p.machinesMu.Unlock()
 
+	// Nothing in the guest needs the JIT config nor the secrets once the agent
+	// has loaded them, so don't leave them readable from MMDS for the job. The
+	// MMDS of machines restored between jobs is gone with the booted VM.
+	if !p.config.RestoreBetweenJobs {
+		scrubKeys := []string{"runner_jit_config"}
+		if len(secrets) > 0 {
+			scrubKeys = append(scrubKeys, "secrets")
+		}
+
+		p.cleanupWg.Add(1)
+		go func() {
+			defer p.cleanupWg.Done()
+			p.scrubMetadata(machine, scrubKeys...)
+		}()
+	}
+
 	// Start cleanup goroutine
 	p.cleanupWg.Add(1)
 	go func() {
 		defer p.cleanupWg.Done()
 
-		waitDone := make(chan error, 1)
-		go func() {
-			waitDone <- machine.Wait(context.Background())
-		}()
-
-		select {
-		case <-waitDone:
-			// Machine exited normally
-		case <-p.ctx.Done():
-			// Pool is stopping, wait up to 30s for machine to fully exit
-			select {
-			case <-waitDone:
-			case <-time.After(30 * time.Second):
-				p.logger.Warn().Msgf("Timeout waiting for machine %s to exit during pool shutdown", runnerName)
-			}
+		if p.config.RestoreBetweenJobs {
+			p.runRestoredMachine(machine)
+		} else {
+			p.waitMachine(machine)
 		}
 
 		p.machinesMu.Lock()
@@ -580,8 +985,9 @@ func (p *Pool) createMachine(ctx context.Context) error {
 		p.machinesMu.Unlock()
 
 		machine.vmmCancel()
+		machine.stopHostListener()
 
-		p.deleteGitHubRunner(runnerName, machine.RunnerID)
+		p.deleteGitHubRunner(runnerName, machine.getRunnerID())
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -590,35 +996,68 @@ func (p *Pool) createMachine(ctx context.Context) error {
 			p.logger.Error().Err(err).Msgf("Failed to remove Containerd lease for Firecracker VM %s", runnerName)
 		}
 
+		if err := p.rootfs.release(ctx, runnerName); err != nil {
+			p.logger.Error().Err(err).Msgf("Failed to remove rootfs for Firecracker VM %s", runnerName)
+		}
+
+		if machine.network != nil {
+			if err := machine.network.teardown(context.Background()); err != nil {
+				p.logger.Error().Err(err).Msgf("Failed to remove network of Firecracker VM %s", runnerName)
+			}
+		}
+
+		if p.config.RestoreBetweenJobs {
+			p.removeSnapshot(machine)
+		}
+
+		p.releaseIndex(machine.Index)
+
 		p.logger.Info().Msgf("Successfully cleaned up exited Firecracker VM %s", runnerName)
 	}()
 
 	return nil
 }
 
-// removeMachine removes a single machine from the pool.
-func (p *Pool) deleteMachine(_ context.Context) error {
-	p.machinesMu.Lock()
+// waitMachine waits until the VM of the machine exits or, if the pool is
+// stopping, up to 30s.
+func (p *Pool) waitMachine(machine *Machine) {
+	waitDone := make(chan error, 1)
+	go func() {
+		waitDone <- machine.Wait(context.Background())
+	}()
 
-	// Find a machine to remove (pick the first one)
-	var targetMachine *Machine
-	var targetName string
-	for name, machine := range p.machines {
-		targetMachine = machine
-		targetName = name
-		break
+	select {
+	case <-waitDone:
+		// Machine exited normally
+	case <-p.ctx.Done():
+		// Pool is stopping, wait up to 30s for machine to fully exit
+		select {
+		case <-waitDone:
+		case <-time.After(30 * time.Second):
+			p.logger.Warn().Msgf("Timeout waiting for machine %s to exit during pool shutdown", machine.Name)
+		}
 	}
+}
+
+// deleteMachine removes a single machine from the pool and stops it. The
+// pending delete is done once the machine left the pool, it is not counted
+// again while the machine shuts down.
+func (p *Pool) deleteMachine(_ context.Context) error {
+	p.machinesMu.Lock()
+	p.pendingDeletes.Add(-1)
 
+	targetMachine := p.pickMachineToDelete()
 	if targetMachine == nil {
 		p.machinesMu.Unlock()
 		return fmt.Errorf("no machines available to scale down")
 	}
 
 	// Remove from map immediately to prevent selecting the same machine multiple times
+	targetName := targetMachine.Name
 	delete(p.machines, targetName)
 	p.machinesMu.Unlock()
 
-	err := targetMachine.StopVMM()
+	err := p.stopMachine(context.Background(), targetMachine, "scale down")
 	if err != nil {
 		p.logger.Warn().Err(err).Msgf("Failed to stop VM %s", targetName)
 		return err
@@ -628,37 +1067,136 @@ func (p *Pool) deleteMachine(_ context.Context) error {
 	return nil
 }
 
-// createSnapshot creates a snapshot of the specified image.
-func (p *Pool) createSnapshot(ctx context.Context, image containerd.Image, snapshotID string) ([]mount.Mount, error) {
-	snapshotService := p.containerd.SnapshotService(defaultSnapshotter)
-	snapshotExists := true
-	_, err := snapshotService.Stat(ctx, snapshotID)
-	if err != nil {
-		if !errdefs.IsNotFound(err) {
-			return nil, err
+// pickMachineToDelete returns the machine to delete when scaling down, nil if
+// there is none. Machines whose runner did not take a job yet are preferred,
+// as they are stopped right away while busy ones complete their job first.
+// Held machines are only deleted once their hold expires. The caller must
+// hold machinesMu.
+func (p *Pool) pickMachineToDelete() *Machine {
+	var busy *Machine
+	for _, machine := range p.machines {
+		if machine.isHeld() {
+			continue
+		}
+
+		state, _, _ := machine.status()
+		switch state {
+		case "", "Starting", "Idle":
+			return machine
+		}
+
+		if busy == nil {
+			busy = machine
 		}
+	}
+
+	return busy
+}
 
-		snapshotExists = false
+// stopMachine shuts a machine down gracefully: its agent stops the runner
+// once the current job completes and powers the VM off. The VMM is stopped
+// if the agent cannot be reached or the VM did not exit in time, or once ctx
+// is done.
+func (p *Pool) stopMachine(ctx context.Context, machine *Machine, reason string) error {
+	machine.markStopping()
+
+	gracePeriod := p.config.ShutdownGracePeriod
+	ctx, cancel := context.WithTimeout(ctx, gracePeriod+shutdownTimeoutMargin)
+	defer cancel()
+
+	if err := machine.shutdown(ctx, reason, gracePeriod); err != nil {
+		p.logger.Warn().Err(err).Msgf("Failed to shut down Firecracker VM %s gracefully, stopping it", machine.Name)
+		return machine.StopVMM()
 	}
 
-	if !snapshotExists {
-		imageContent, err := image.RootFS(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("image: rootfs: %w", err)
+	p.logger.Info().Msgf("Shutting down Firecracker VM %s (%s), grace period %s", machine.Name, reason, gracePeriod)
+	if err := machine.Wait(ctx); err != nil && ctx.Err() != nil {
+		p.logger.Warn().Msgf("Firecracker VM %s did not shut down within %s, stopping it", machine.Name, gracePeriod)
+		return machine.StopVMM()
+	}
+
+	return nil
+}
+
+// machineLeaseID returns the ID of the containerd lease that holds the resources of a machine.
+func machineLeaseID(pool, runnerName string) string {
+	return fmt.Sprintf("%s%s/%s", machineLeasePrefix, pool, runnerName)
+}
+
+// addLeaseResources adds the content with the given digests to the lease.
+func (p *Pool) addLeaseResources(ctx context.Context, leaseID string, digests ...string) error {
+	for _, digest := range digests {
+		if digest == "" {
+			continue
 		}
 
-		_, err = snapshotService.Prepare(ctx, snapshotID, identity.ChainID(imageContent).String())
+		err := p.containerd.LeasesService().AddResource(ctx, leases.Lease{ID: leaseID}, leases.Resource{ID: digest, Type: "content"})
 		if err != nil {
-			return nil, fmt.Errorf("prepare: %w", err)
+			return fmt.Errorf("adding %s to lease: %w", digest, err)
 		}
 	}
 
-	mounts, err := snapshotService.Mounts(ctx, snapshotID)
+	return nil
+}
+
+// errRenewalRefused is returned when the runner of a machine may not be
+// renewed for a next job.
+var errRenewalRefused = errors.New("renewal refused")
+
+// finishedRunnerStates are the runner states after a job, in which the runner
+// of a multi-job machine may be renewed.
+var finishedRunnerStates = []string{"Completed", "Exited", "Resetting"}
+
+// renewNextJob renews the runner of a multi-job machine for its next job, if
+// the machine may run another one. The agent enforces the job limits of the
+// pool too, but the job runs next to it: the server must not let a job keep
+// the VM and collect runner registrations.
+func (p *Pool) renewNextJob(ctx context.Context, machine *Machine) (string, error) {
+	cfg := p.config.MultiJob
+
+	// Every renewal follows a job, whatever the agent reports
+	jobs := max(int(machine.jobCount()), int(machine.renewalCount())+1)
+
+	state, _, _ := machine.status()
+	switch {
+	case machine.isStopping():
+		return "", fmt.Errorf("%w: machine is stopping", errRenewalRefused)
+	case !slices.Contains(finishedRunnerStates, state):
+		return "", fmt.Errorf("%w: runner state is %q, not after a job", errRenewalRefused, state)
+	case jobs >= cfg.MaxJobs:
+		return "", fmt.Errorf("%w: runner ran %d jobs, the maximum", errRenewalRefused, jobs)
+	case cfg.MaxLifetime > 0 && time.Since(machine.CreatedAt) >= cfg.MaxLifetime:
+		return "", fmt.Errorf("%w: machine ran for %s, the maximum lifetime", errRenewalRefused, cfg.MaxLifetime)
+	}
+
+	config, err := p.renewRunner(ctx, machine)
+	if err != nil {
+		return "", err
+	}
+
+	machine.recordRenewal()
+	return config, nil
+}
+
+// renewRunner registers the runner of a multi-job machine again for its next
+// job, under the same name, and returns its JIT config.
+func (p *Pool) renewRunner(ctx context.Context, machine *Machine) (string, error) {
+	// Ephemeral runners are usually removed by GitHub once their job
+	// completed, but the name must be free to be registered again
+	p.deleteGitHubRunner(machine.Name, machine.getRunnerID())
+
+	client := p.github.Installation(p.installationID.Load())
+	jitConfig, _, err := client.Actions.GenerateOrgJITConfig(ctx, p.config.Runner.Organization, &githubv63.GenerateJITConfigRequest{
+		Name:          machine.Name,
+		RunnerGroupID: p.config.Runner.GroupID,
+		Labels:        p.config.Runner.Labels,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("mounts: %w", err)
+		return "", fmt.Errorf("github: %w", err)
 	}
 
-	return mounts, nil
+	machine.setRunnerID(jitConfig.GetRunner().GetID())
+	return jitConfig.GetEncodedJITConfig(), nil
 }
 
 // deleteGitHubRunner removes a runner from GitHub Actions
@@ -1 +1,153 @@
 package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_AcquireIndex(t *testing.T) {
+	p := &Pool{machinesMu: &sync.Mutex{}, indexes: make(map[int]bool)}
+
+	assert.Equal(t, 0, p.acquireIndex())
+	assert.Equal(t, 1, p.acquireIndex())
+	assert.Equal(t, 2, p.acquireIndex())
+
+	p.releaseIndex(1)
+	assert.Equal(t, 1, p.acquireIndex())
+	assert.Equal(t, 3, p.acquireIndex())
+}
+
+func TestPool_CheckHealth(t *testing.T) {
+	logger := zerolog.Nop()
+	now := time.Now()
+	healthy := &Machine{Name: "healthy", CreatedAt: now, heartbeatTimeout: 30 * time.Second}
+	unhealthy := &Machine{Name: "unhealthy", CreatedAt: now.Add(-time.Minute), heartbeatTimeout: 30 * time.Second}
+
+	p := &Pool{
+		config:     &PoolConfig{Name: "test", Runner: &RunnerConfig{Organization: "org"}},
+		logger:     &logger,
+		machinesMu: &sync.Mutex{},
+		machines:   map[string]*Machine{"healthy": healthy, "unhealthy": unhealthy},
+		heartbeat:  &HeartbeatConfig{Interval: 10 * time.Second, Timeout: 30 * time.Second},
+	}
+
+	p.checkHealth(now)
+	assert.False(t, healthy.unhealthy)
+	assert.True(t, unhealthy.unhealthy)
+
+	unhealthy.recordHeartbeat("Idle", "", now)
+	p.checkHealth(now)
+	assert.False(t, unhealthy.unhealthy)
+}
+
+func TestPool_PickMachineToDelete(t *testing.T) {
+	now := time.Now()
+	running := &Machine{Name: "running"}
+	running.recordHeartbeat("Running", "", now)
+	idle := &Machine{Name: "idle"}
+	idle.recordHeartbeat("Idle", "", now)
+	held := &Machine{Name: "held"}
+	held.recordHeartbeat("Completed", "", now)
+	held.hold(now.Add(time.Hour))
+
+	p := &Pool{machinesMu: &sync.Mutex{}, machines: map[string]*Machine{"running": running, "idle": idle, "held": held}}
+	for i := 0; i < 10; i++ {
+		assert.Same(t, idle, p.pickMachineToDelete(), "idle machines are preferred")
+	}
+
+	delete(p.machines, "idle")
+	assert.Same(t, running, p.pickMachineToDelete(), "busy machines are deleted when no machine is idle")
+
+	delete(p.machines, "running")
+	assert.Nil(t, p.pickMachineToDelete(), "held machines are not deleted")
+}
+
+func TestPool_Sizes(t *testing.T) {
+	now := time.Now()
+	held := &Machine{Name: "held"}
+	held.hold(now.Add(time.Hour))
+
+	p := &Pool{machinesMu: &sync.Mutex{}, machines: map[string]*Machine{"held": held}}
+	p.pendingCreates.Store(2)
+	p.pendingDeletes.Store(1)
+
+	// The pending delete is done even if no machine is left to delete
+	assert.Error(t, p.deleteMachine(context.Background()))
+
+	curSize, pendingCreates, pendingDeletes := p.sizes()
+	assert.Equal(t, 0, curSize, "held machines are not counted")
+	assert.Equal(t, 2, pendingCreates)
+	assert.Equal(t, 0, pendingDeletes)
+}
+
+func TestPool_RenewNextJob(t *testing.T) {
+	now := time.Now()
+	p := &Pool{config: &PoolConfig{MultiJob: &MultiJobConfig{MaxJobs: 3, MaxLifetime: time.Hour}}}
+
+	tests := []struct {
+		name    string
+		machine func() *Machine
+	}{
+		{"running", func() *Machine {
+			m := &Machine{CreatedAt: now}
+			m.recordHeartbeat("Running", "", now)
+			return m
+		}},
+		{"never seen", func() *Machine {
+			return &Machine{CreatedAt: now}
+		}},
+		{"max jobs", func() *Machine {
+			m := &Machine{CreatedAt: now}
+			m.recordHeartbeat("Resetting", "", now)
+			m.recordJobs(3)
+			return m
+		}},
+		{"max jobs renewed", func() *Machine {
+			m := &Machine{CreatedAt: now, renewals: 2}
+			m.recordHeartbeat("Resetting", "", now)
+			m.recordJobs(1)
+			return m
+		}},
+		{"max lifetime", func() *Machine {
+			m := &Machine{CreatedAt: now.Add(-2 * time.Hour)}
+			m.recordHeartbeat("Resetting", "", now)
+			return m
+		}},
+		{"stopping", func() *Machine {
+			m := &Machine{CreatedAt: now}
+			m.recordHeartbeat("Resetting", "", now)
+			m.markStopping()
+			return m
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.renewNextJob(context.Background(), tt.machine())
+			assert.ErrorIs(t, err, errRenewalRefused)
+		})
+	}
+}
+
+func TestWaitGroupWait(t *testing.T) {
+	var wg sync.WaitGroup
+	assert.True(t, waitGroupWait(context.Background(), &wg))
+
+	wg.Add(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, waitGroupWait(ctx, &wg))
+}
+
+func TestPool_RunnerUser(t *testing.T) {
+	p := &Pool{config: &PoolConfig{Runner: &RunnerConfig{}}}
+	assert.Equal(t, "runner", p.runnerUser())
+
+	p.config.Runner.User = "ci"
+	assert.Equal(t, "ci", p.runnerUser())
+}
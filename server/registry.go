@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	dockerconfig "github.com/containerd/containerd/remotes/docker/config"
+	"github.com/containerd/nerdctl/pkg/imgutil/dockerconfigresolver"
+	"github.com/distribution/reference"
+)
+
+const (
+	dockerHubDomain = "docker.io"
+	dockerHubHost   = "registry-1.docker.io"
+)
+
+// withRegistries returns a copy of the imageManager that uses the given
+// registry configuration in addition to its own. Entries in overrides replace
+// the entries for the same registry. The copy shares pulls with the original
+// and other copies with the same registry configuration.
+func (im *imageManager) withRegistries(overrides map[string]*RegistryConfig) *imageManager {
+	if len(overrides) == 0 {
+		return im
+	}
+
+	registries := make(map[string]*RegistryConfig, len(im.registries)+len(overrides))
+	for host, config := range im.registries {
+		registries[host] = config
+	}
+
+	for host, config := range overrides {
+		registries[host] = config
+	}
+
+	c := *im
+	c.registries = registries
+	c.registriesKey = newRegistriesKey(registries)
+	return &c
+}
+
+// newRegistriesKey returns a key that identifies the registry configuration,
+// so that pulls with different registry configurations are not shared.
+func newRegistriesKey(registries map[string]*RegistryConfig) string {
+	if len(registries) == 0 {
+		return ""
+	}
+
+	// Maps are marshaled with sorted keys
+	data, _ := json.Marshal(registries)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newResolver returns a resolver for the registry of the image reference. The
+// configured registries take precedence over the docker config of the user
+// running the server.
+func (im *imageManager) newResolver(ctx context.Context, ref string) (remotes.Resolver, error) {
+	dockerRef, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image ref: %w", err)
+	}
+
+	hosts, err := im.registryHosts(ctx, reference.Domain(dockerRef))
+	if err != nil {
+		return nil, fmt.Errorf("configuring registry hosts: %w", err)
+	}
+
+	return docker.NewResolver(docker.ResolverOptions{Hosts: hosts}), nil
+}
+
+// registryHosts returns the hosts to pull from for the registry domain. Mirrors
+// are tried in order before the registry itself.
+func (im *imageManager) registryHosts(ctx context.Context, domain string) (docker.RegistryHosts, error) {
+	opts := []dockerconfigresolver.Opt{dockerconfigresolver.WithAuthCreds(im.credentials)}
+
+	config := im.registries[domain]
+	if config != nil {
+		opts = append(opts,
+			dockerconfigresolver.WithSkipVerifyCerts(config.Insecure),
+			dockerconfigresolver.WithPlainHTTP(config.PlainHTTP))
+	}
+
+	hostOptions, err := dockerconfigresolver.NewHostOptions(ctx, domain, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream := dockerconfig.ConfigureHosts(ctx, *hostOptions)
+	if config == nil || len(config.Mirrors) == 0 {
+		return upstream, nil
+	}
+
+	return func(host string) ([]docker.RegistryHost, error) {
+		hosts, err := upstream(host)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(hosts) == 0 {
+			return hosts, nil
+		}
+
+		mirrors := make([]docker.RegistryHost, 0, len(config.Mirrors)+len(hosts))
+		for _, mirror := range config.Mirrors {
+			mirrorHost, err := newMirrorHost(mirror, hosts[0])
+			if err != nil {
+				return nil, err
+			}
+
+			mirrors = append(mirrors, mirrorHost)
+		}
+
+		return append(mirrors, hosts...), nil
+	}, nil
+}
+
+// newMirrorHost returns a pull-only registry host for the mirror URL. The
+// mirror uses the HTTP client and authorizer of the registry it mirrors.
+func newMirrorHost(mirror string, upstream docker.RegistryHost) (docker.RegistryHost, error) {
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return docker.RegistryHost{}, fmt.Errorf("parsing mirror %s: %w", mirror, err)
+	}
+
+	host := upstream
+	host.Host = u.Host
+	host.Scheme = u.Scheme
+	host.Path = strings.TrimSuffix(u.Path, "/") + "/v2"
+	host.Capabilities = docker.HostCapabilityPull | docker.HostCapabilityResolve
+
+	return host, nil
+}
+
+// credentials returns the credentials for the registry host, falling back to
+// the docker config if the registry has no credentials configured.
+func (im *imageManager) credentials(host string) (string, string, error) {
+	config, ok := im.registries[host]
+	if !ok && host == dockerHubHost {
+		config, ok = im.registries[dockerHubDomain]
+	}
+
+	if ok && config.hasCredentials() {
+		return config.credentials()
+	}
+
+	authCreds, err := dockerconfigresolver.NewAuthCreds(host)
+	if err != nil {
+		return "", "", err
+	}
+
+	return authCreds(host)
+}
+
+// hasCredentials reports whether credentials are configured for the registry.
+func (r *RegistryConfig) hasCredentials() bool {
+	return r.PasswordFile != "" || r.PasswordEnv != "" || r.IdentityTokenFile != "" || r.IdentityTokenEnv != ""
+}
+
+// credentials returns the username and secret of the registry. Secrets are
+// read on every call, so rotated files and tokens are picked up without a
+// restart. An identity token is returned with an empty username, which
+// containerd exchanges for an access token at the token server of the
+// registry.
+func (r *RegistryConfig) credentials() (string, string, error) {
+	if r.IdentityTokenFile != "" || r.IdentityTokenEnv != "" {
+		token, err := readSecret(r.IdentityTokenFile, r.IdentityTokenEnv)
+		if err != nil {
+			return "", "", fmt.Errorf("reading registry identity token: %w", err)
+		}
+
+		return "", token, nil
+	}
+
+	password, err := readSecret(r.PasswordFile, r.PasswordEnv)
+	if err != nil {
+		return "", "", fmt.Errorf("reading registry password: %w", err)
+	}
+
+	return r.Username, password, nil
+}
+
+// readSecret reads a secret from the file, or from the environment variable
+// if no file is given.
+func readSecret(file, env string) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	value, ok := os.LookupEnv(env)
+	if !ok || value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", env)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageManager_WithRegistries(t *testing.T) {
+	global := &RegistryConfig{Username: "global", PasswordEnv: "GLOBAL_PASSWORD"}
+	docker := &RegistryConfig{Mirrors: []string{"https://mirror.example.com"}}
+	im := newImageManager(nil, nil, "devmapper", "", map[string]*RegistryConfig{"ghcr.io": global, "docker.io": docker})
+
+	assert.Same(t, im, im.withRegistries(nil))
+
+	pool := &RegistryConfig{Username: "pool", PasswordEnv: "POOL_PASSWORD"}
+	poolIM := im.withRegistries(map[string]*RegistryConfig{"ghcr.io": pool})
+	assert.Same(t, pool, poolIM.registries["ghcr.io"])
+	assert.Same(t, docker, poolIM.registries["docker.io"])
+	assert.Same(t, global, im.registries["ghcr.io"], "original must not be modified")
+	assert.Same(t, im.pullGroup, poolIM.pullGroup)
+	assert.NotEqual(t, im.registriesKey, poolIM.registriesKey, "pulls are not shared across registry configurations")
+
+	samePool := &RegistryConfig{Username: "pool", PasswordEnv: "POOL_PASSWORD"}
+	assert.Equal(t, poolIM.registriesKey, im.withRegistries(map[string]*RegistryConfig{"ghcr.io": samePool}).registriesKey)
+}
+
+func TestImageManager_Credentials(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cret\n"), 0600))
+	t.Setenv("FIREACTIONS_TEST_TOKEN", "token")
+
+	im := newImageManager(nil, nil, "devmapper", "", map[string]*RegistryConfig{
+		"ghcr.io":   {Username: "user", PasswordFile: passwordFile},
+		"docker.io": {IdentityTokenEnv: "FIREACTIONS_TEST_TOKEN"},
+		"quay.io":   {IdentityTokenEnv: "FIREACTIONS_TEST_MISSING"},
+	})
+
+	username, secret, err := im.credentials("ghcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "s3cret", secret)
+
+	username, secret, err = im.credentials(dockerHubHost)
+	require.NoError(t, err)
+	assert.Empty(t, username)
+	assert.Equal(t, "token", secret)
+
+	_, _, err = im.credentials("quay.io")
+	assert.Error(t, err)
+}
+
+func TestNewMirrorHost(t *testing.T) {
+	upstream := docker.RegistryHost{
+		Host:         "registry-1.docker.io",
+		Scheme:       "https",
+		Path:         "/v2",
+		Capabilities: docker.HostCapabilityPull | docker.HostCapabilityResolve | docker.HostCapabilityPush,
+	}
+
+	host, err := newMirrorHost("http://mirror.example.com:5000/cache/", upstream)
+	require.NoError(t, err)
+	assert.Equal(t, "mirror.example.com:5000", host.Host)
+	assert.Equal(t, "http", host.Scheme)
+	assert.Equal(t, "/cache/v2", host.Path)
+	assert.Equal(t, docker.HostCapabilityPull|docker.HostCapabilityResolve, host.Capabilities)
+}
+
+func TestRegistryConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RegistryConfig
+		wantErr bool
+	}{
+		{name: "password file", config: RegistryConfig{Username: "user", PasswordFile: "/etc/fireactions/password"}},
+		{name: "identity token env", config: RegistryConfig{IdentityTokenEnv: "REGISTRY_TOKEN"}},
+		{name: "mirrors only", config: RegistryConfig{Mirrors: []string{"https://mirror.example.com"}}},
+		{name: "password without username", config: RegistryConfig{PasswordEnv: "REGISTRY_PASSWORD"}, wantErr: true},
+		{name: "password and identity token", config: RegistryConfig{Username: "user", PasswordEnv: "REGISTRY_PASSWORD", IdentityTokenEnv: "REGISTRY_TOKEN"}, wantErr: true},
+		{name: "invalid mirror", config: RegistryConfig{Mirrors: []string{"not a url"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.New().Struct(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/containerd/leases"
+	"github.com/firecracker-microvm/firecracker-go-sdk"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// restoreBootTimeout is how long a new machine restored between jobs
+	// gets to boot until its agent waits for a runner JIT config.
+	restoreBootTimeout = 5 * time.Minute
+
+	// restoreRenewTimeout is how long registering the runner of a restored
+	// machine with GitHub may take.
+	restoreRenewTimeout = 30 * time.Second
+
+	// restoreEntropySize is the number of random bytes put in MMDS for the
+	// agent to reseed the random number generator of a restored VM with.
+	restoreEntropySize = 64
+)
+
+// errMachineStopping is returned when a machine is stopped while it is
+// restored.
+var errMachineStopping = errors.New("machine is stopping")
+
+// runRestoredMachine snapshots the VM of a new machine once its agent waits
+// for a runner JIT config, then restores the VM from the snapshot with a new
+// root drive and runner registration every time it exits after a job. It
+// returns once the machine is stopped or could not be restored.
+func (p *Pool) runRestoredMachine(machine *Machine) {
+	if err := p.snapshotMachine(machine); err != nil {
+		p.logger.Error().Err(err).Msgf("Failed to snapshot Firecracker VM %s", machine.Name)
+		_ = machine.StopVMM()
+		p.waitMachine(machine)
+		return
+	}
+
+	for !machine.isStopping() && machine.vmmCtx.Err() == nil {
+		start := time.Now()
+		if err := p.restoreMachine(machine); err != nil {
+			if !errors.Is(err, errMachineStopping) && machine.vmmCtx.Err() == nil {
+				p.logger.Error().Err(err).Msgf("Failed to restore Firecracker VM %s", machine.Name)
+			}
+			return
+		}
+
+		p.logger.Info().Msgf("Restored Firecracker VM %s in %s", machine.Name, time.Since(start))
+		p.waitMachine(machine)
+	}
+}
+
+// snapshotMachine pauses the VM of a new machine once its agent waits for a
+// runner JIT config, snapshots it and stops it. Its root drive becomes the
+// restore point of the machine.
+func (p *Pool) snapshotMachine(machine *Machine) error {
+	ctx, cancel := context.WithTimeout(machine.vmmCtx, restoreBootTimeout)
+	defer cancel()
+
+	exited := make(chan struct{})
+	go func() {
+		_ = machine.Wait(ctx)
+		close(exited)
+	}()
+
+	select {
+	case <-machine.awaitingRunnerCh():
+	case <-ctx.Done():
+		return fmt.Errorf("agent not ready within %s", restoreBootTimeout)
+	case <-exited:
+		return fmt.Errorf("VM exited before the agent was ready")
+	}
+
+	vm := machine.vm()
+	memPath, statePath := p.snapshotPaths(machine.Name)
+	if err := vm.PauseVM(ctx); err != nil {
+		return fmt.Errorf("firecracker: pausing: %w", err)
+	}
+
+	if err := vm.CreateSnapshot(ctx, memPath, statePath); err != nil {
+		return fmt.Errorf("firecracker: creating snapshot: %w", err)
+	}
+
+	if err := vm.StopVMM(); err != nil {
+		return fmt.Errorf("firecracker: stopping: %w", err)
+	}
+
+	select {
+	case <-exited:
+	case <-ctx.Done():
+		return fmt.Errorf("firecracker: VM did not stop")
+	}
+
+	leaseCtx := leases.WithLease(ctx, machineLeaseID(p.config.Name, machine.Name))
+	if err := p.rootfs.commit(leaseCtx, machine.Name); err != nil {
+		return fmt.Errorf("rootfs: %w", err)
+	}
+
+	p.logger.Info().Msgf("Snapshotted Firecracker VM %s", machine.Name)
+	return nil
+}
+
+// restoreMachine starts a new Firecracker VM for the machine from its
+// snapshot, with a new root drive and a new runner registration in MMDS. The
+// network and vsock of the machine stay the same.
+func (p *Pool) restoreMachine(machine *Machine) error {
+	ctx := machine.vmmCtx
+	leaseCtx := leases.WithLease(ctx, machineLeaseID(p.config.Name, machine.Name))
+	rootfsPath, err := p.rootfs.reset(leaseCtx, machine.Name)
+	if err != nil {
+		return fmt.Errorf("rootfs: %w", err)
+	}
+
+	if err := replaceSymlink(rootfsPath, machine.rootfsLink); err != nil {
+		return fmt.Errorf("rootfs: %w", err)
+	}
+
+	renewCtx, cancel := context.WithTimeout(ctx, restoreRenewTimeout)
+	defer cancel()
+
+	jitConfig, err := p.renewRunner(renewCtx, machine)
+	if err != nil {
+		return fmt.Errorf("renewing runner: %w", err)
+	}
+
+	// Firecracker listens on the vsock socket of the snapshot again
+	if err := os.Remove(machine.vsockPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing vsock socket: %w", err)
+	}
+
+	logFile, err := os.OpenFile(filepath.Join(p.GetDir(), fmt.Sprintf("%s.log", machine.Name)), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cfg := machine.vm().Cfg
+	cfg.KernelArgs = p.config.Firecracker.KernelArgs // Set up with the IP address at boot, unused when restoring
+
+	cmd := firecracker.VMCommandBuilder{}.
+		WithSocketPath(cfg.SocketPath).
+		WithStderr(logFile).
+		WithStdout(logFile).
+		WithBin(p.config.Firecracker.BinaryPath).
+		Build(ctx)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	memPath, statePath := p.snapshotPaths(machine.Name)
+	vm, err := firecracker.NewMachine(ctx, cfg,
+		firecracker.WithProcessRunner(cmd),
+		firecracker.WithLogger(logrus.NewEntry(logger)),
+		firecracker.WithSnapshot(memPath, statePath))
+	if err != nil {
+		return fmt.Errorf("firecracker: creating machine: %w", err)
+	}
+
+	// The agent reports that it loaded the JIT config of the restored VM
+	machine.resetConfigLoaded()
+
+	// The vsock device is part of the snapshot and cannot be added after
+	// loading it. MMDS is empty after loading it.
+	vm.Handlers.FcInit = vm.Handlers.FcInit.
+		Remove(firecracker.AddVsocksHandlerName).
+		Append(firecracker.NewSetMetadataHandler(newRestoreMetadata(machine.metadata, jitConfig, newRestoreEntropy(), time.Now())))
+
+	if err := vm.Start(ctx); err != nil {
+		return fmt.Errorf("firecracker: loading snapshot: %w", err)
+	}
+
+	if !machine.replaceVM(vm) {
+		_ = vm.StopVMM()
+		return errMachineStopping
+	}
+
+	if err := vm.ResumeVM(ctx); err != nil {
+		_ = vm.StopVMM()
+		return fmt.Errorf("firecracker: resuming: %w", err)
+	}
+
+	machine.recordRestore()
+
+	p.cleanupWg.Add(1)
+	go func() {
+		defer p.cleanupWg.Done()
+		p.scrubMetadata(machine, "runner_jit_config", "entropy")
+	}()
+
+	return nil
+}
+
+// removeSnapshot removes the snapshot files and the root drive symlink of
+// the machine.
+func (p *Pool) removeSnapshot(machine *Machine) {
+	memPath, statePath := p.snapshotPaths(machine.Name)
+	for _, path := range []string{memPath, statePath, machine.rootfsLink} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			p.logger.Error().Err(err).Msgf("Failed to remove %s of Firecracker VM %s", path, machine.Name)
+		}
+	}
+}
+
+// snapshotPaths returns the paths of the memory and VM state files of the
+// snapshot of the machine named name.
+func (p *Pool) snapshotPaths(name string) (string, string) {
+	return filepath.Join(p.GetDir(), fmt.Sprintf("%s.mem", name)), filepath.Join(p.GetDir(), fmt.Sprintf("%s.vmstate", name))
+}
+
+// restoreMetadata returns the metadata of a booted VM without the values only
+// needed at boot, for the VMs restored from its snapshot.
+func restoreMetadata(metadata map[string]interface{}) map[string]interface{} {
+	fireactions := maps.Clone(metadata["fireactions"].(map[string]interface{}))
+	delete(fireactions, "secrets")
+
+	metadata = maps.Clone(metadata)
+	metadata["fireactions"] = fireactions
+	return metadata
+}
+
+// newRestoreMetadata returns the MMDS contents of a VM restored at
+// restoredAt, with the runner JIT config and the entropy to reseed its random
+// number generator with.
+func newRestoreMetadata(metadata map[string]interface{}, jitConfig string, entropy []byte, restoredAt time.Time) map[string]interface{} {
+	fireactions := maps.Clone(metadata["fireactions"].(map[string]interface{}))
+	fireactions["runner_jit_config"] = jitConfig
+	fireactions["entropy"] = base64.StdEncoding.EncodeToString(entropy)
+	fireactions["restored_at"] = restoredAt.UTC().Format(time.RFC3339Nano)
+
+	metadata = maps.Clone(metadata)
+	metadata["fireactions"] = fireactions
+	return map[string]interface{}{"latest": map[string]interface{}{"meta-data": metadata}}
+}
+
+// newRestoreEntropy returns random bytes for a restored VM. Every VM restored
+// from a snapshot starts with the state of the random number generator saved
+// in it, so the VMs would generate the same random numbers without reseeding.
+func newRestoreEntropy() []byte {
+	entropy := make([]byte, restoreEntropySize)
+	_, _ = rand.Read(entropy) // Never fails
+	return entropy
+}
+
+// replaceSymlink points the symlink at path to target, replacing it
+// atomically if it exists.
+func replaceSymlink(target, path string) error {
+	tmpPath := path + ".tmp"
+	_ = os.Remove(tmpPath)
+	if err := os.Symlink(target, tmpPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreMetadata(t *testing.T) {
+	boot := map[string]interface{}{
+		"region": "eu",
+		"fireactions": map[string]interface{}{
+			"hostname": "runner-abc123",
+			"restore":  true,
+			"secrets":  []interface{}{map[string]interface{}{"name": "TOKEN", "value": "secret"}},
+		},
+	}
+
+	metadata := restoreMetadata(boot)
+	assert.NotContains(t, metadata["fireactions"], "secrets")
+	assert.Contains(t, boot["fireactions"], "secrets", "the boot metadata is not changed")
+
+	restoredAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	restored := newRestoreMetadata(metadata, "config", []byte("random"), restoredAt)
+	assert.Equal(t, map[string]interface{}{
+		"latest": map[string]interface{}{
+			"meta-data": map[string]interface{}{
+				"region": "eu",
+				"fireactions": map[string]interface{}{
+					"hostname":          "runner-abc123",
+					"restore":           true,
+					"runner_jit_config": "config",
+					"entropy":           "cmFuZG9t",
+					"restored_at":       "2026-10-18T10:00:00Z",
+				},
+			},
+		},
+	}, restored)
+	assert.NotContains(t, metadata["fireactions"], "runner_jit_config", "the metadata is reused for every restore")
+}
+
+func TestNewRestoreEntropy(t *testing.T) {
+	entropy := newRestoreEntropy()
+	assert.Len(t, entropy, restoreEntropySize)
+	assert.NotEqual(t, entropy, newRestoreEntropy(), "every restore gets its own entropy")
+}
+
+func TestReplaceSymlink(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vm1.rootfs")
+
+	require.NoError(t, replaceSymlink(filepath.Join(dir, "a.ext4"), path))
+	require.NoError(t, replaceSymlink(filepath.Join(dir, "b.ext4"), path))
+
+	target, err := os.Readlink(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "b.ext4"), target)
+	assert.NoFileExists(t, path+".tmp")
+}
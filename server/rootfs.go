@@ -0,0 +1,465 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/leases"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/errdefs"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/sys/unix"
+)
+
+// rootfsBuildTimeout bounds how long building the ext4 image of an image
+// digest for the file rootfs backend may take.
+const rootfsBuildTimeout = 30 * time.Minute
+
+// rootfsBackend prepares root drives for Firecracker VMs.
+type rootfsBackend interface {
+	// prepare returns the host path of a writable root drive for the machine
+	// identified by id. The context carries the machine's containerd lease.
+	prepare(ctx context.Context, image containerd.Image, id string) (string, error)
+
+	// release removes any resources created by prepare that are not owned by
+	// the machine's containerd lease.
+	release(ctx context.Context, id string) error
+
+	// commit makes the current contents of the root drive the restore point
+	// of the machine. The drive must not be in use and is gone afterwards.
+	commit(ctx context.Context, id string) error
+
+	// reset creates a root drive for the machine from its restore point and
+	// returns its host path. The previous drive is removed.
+	reset(ctx context.Context, id string) (string, error)
+
+	// imageFiles returns the host paths of the files built for the image
+	// digest outside of containerd. They are removed with the image.
+	imageFiles(digest digest.Digest) []string
+}
+
+// newRootfsBackend creates the rootfs backend selected in the configuration.
+func newRootfsBackend(logger *zerolog.Logger, config *Config, containerdClient *containerd.Client) (rootfsBackend, error) {
+	switch config.Rootfs.Backend {
+	case "snapshotter":
+		return &snapshotterRootfs{containerd: containerdClient, snapshotter: config.Containerd.Snapshotter}, nil
+	case "file":
+		if err := os.MkdirAll(filepath.Join(config.Rootfs.Dir, "images"), 0755); err != nil {
+			return nil, fmt.Errorf("creating rootfs directory: %w", err)
+		}
+
+		fileRootfs := &fileRootfs{
+			containerd:  containerdClient,
+			snapshotter: config.Containerd.Snapshotter,
+			dir:         config.Rootfs.Dir,
+			sizeMib:     config.Rootfs.SizeMib,
+			logger:      logger,
+		}
+
+		return fileRootfs, nil
+	default:
+		return nil, fmt.Errorf("unknown rootfs backend: %s", config.Rootfs.Backend)
+	}
+}
+
+// snapshotterRootfs uses the block device of a containerd snapshot as the root
+// drive. The snapshot is bound to the machine's lease and removed with it.
+type snapshotterRootfs struct {
+	containerd  *containerd.Client
+	snapshotter string
+}
+
+func (r *snapshotterRootfs) prepare(ctx context.Context, image containerd.Image, id string) (string, error) {
+	snapshotService := r.containerd.SnapshotService(r.snapshotter)
+	snapshotExists := true
+	_, err := snapshotService.Stat(ctx, id)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return "", err
+		}
+
+		snapshotExists = false
+	}
+
+	if !snapshotExists {
+		imageContent, err := image.RootFS(ctx)
+		if err != nil {
+			return "", fmt.Errorf("image: rootfs: %w", err)
+		}
+
+		_, err = snapshotService.Prepare(ctx, id, identity.ChainID(imageContent).String())
+		if err != nil {
+			return "", fmt.Errorf("prepare: %w", err)
+		}
+	}
+
+	mounts, err := snapshotService.Mounts(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("mounts: %w", err)
+	}
+
+	if len(mounts) == 0 || mounts[0].Type == "overlay" || mounts[0].Type == "bind" {
+		return "", fmt.Errorf("snapshotter %s does not provide a block device, use the file rootfs backend instead", r.snapshotter)
+	}
+
+	return mounts[0].Source, nil
+}
+
+func (r *snapshotterRootfs) release(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *snapshotterRootfs) commit(ctx context.Context, id string) error {
+	snapshotService := r.containerd.SnapshotService(r.snapshotter)
+	mounts, err := snapshotService.Mounts(ctx, id)
+	if err != nil {
+		return fmt.Errorf("mounts: %w", err)
+	}
+
+	if len(mounts) > 0 {
+		if err := syncPath(mounts[0].Source); err != nil {
+			return fmt.Errorf("sync: %w", err)
+		}
+	}
+
+	if err := snapshotService.Commit(ctx, restorePointKey(id), id); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
+
+func (r *snapshotterRootfs) reset(ctx context.Context, id string) (string, error) {
+	snapshotService := r.containerd.SnapshotService(r.snapshotter)
+	if err := snapshotService.Remove(ctx, id); err != nil && !errdefs.IsNotFound(err) {
+		return "", fmt.Errorf("remove: %w", err)
+	}
+
+	mounts, err := snapshotService.Prepare(ctx, id, restorePointKey(id))
+	if err != nil {
+		return "", fmt.Errorf("prepare: %w", err)
+	}
+
+	if len(mounts) == 0 {
+		return "", fmt.Errorf("snapshotter %s returned no mounts", r.snapshotter)
+	}
+
+	return mounts[0].Source, nil
+}
+
+func (r *snapshotterRootfs) imageFiles(_ digest.Digest) []string {
+	return nil
+}
+
+// restorePointKey returns the key of the committed snapshot the root drive of
+// the machine identified by id is reset to.
+func restorePointKey(id string) string {
+	return fmt.Sprintf("%s-restore-point", id)
+}
+
+// fileRootfs converts the unpacked image into an ext4 image once per image
+// digest and gives every machine a copy-on-write clone of it.
+type fileRootfs struct {
+	containerd  *containerd.Client
+	snapshotter string
+	dir         string
+	sizeMib     int64
+	logger      *zerolog.Logger
+	buildGroup  singleflight.Group
+}
+
+func (r *fileRootfs) prepare(ctx context.Context, image containerd.Image, id string) (string, error) {
+	basePath, err := r.ensureBaseImage(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("building base image: %w", err)
+	}
+
+	path := r.machinePath(id)
+	if err := cloneFile(basePath, path); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("cloning base image: %w", err)
+	}
+
+	return path, nil
+}
+
+func (r *fileRootfs) release(_ context.Context, id string) error {
+	for _, path := range []string{r.machinePath(id), r.restorePointPath(id)} {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *fileRootfs) commit(_ context.Context, id string) error {
+	if err := syncPath(r.machinePath(id)); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	return os.Rename(r.machinePath(id), r.restorePointPath(id))
+}
+
+func (r *fileRootfs) reset(_ context.Context, id string) (string, error) {
+	path := r.machinePath(id)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if err := cloneFile(r.restorePointPath(id), path); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("cloning restore point: %w", err)
+	}
+
+	return path, nil
+}
+
+func (r *fileRootfs) machinePath(id string) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%s.ext4", id))
+}
+
+// restorePointPath returns the path of the root drive the machine identified
+// by id is reset to.
+func (r *fileRootfs) restorePointPath(id string) string {
+	return filepath.Join(r.dir, fmt.Sprintf("%s.restore-point.ext4", id))
+}
+
+// baseImagePath returns the path of the ext4 image built for the image.
+func (r *fileRootfs) baseImagePath(image containerd.Image) string {
+	return r.digestImagePath(image.Target().Digest)
+}
+
+// digestImagePath returns the path of the ext4 image built for the image digest.
+func (r *fileRootfs) digestImagePath(digest digest.Digest) string {
+	return filepath.Join(r.dir, "images", fmt.Sprintf("%s.ext4", digest.Encoded()))
+}
+
+func (r *fileRootfs) imageFiles(digest digest.Digest) []string {
+	return []string{r.digestImagePath(digest)}
+}
+
+// ensureBaseImage builds the ext4 image for the image digest unless it already
+// exists. The build is shared by all machines waiting for the digest, so it
+// outlives the context of the machine that started it; ctx only bounds how
+// long this machine waits for it.
+func (r *fileRootfs) ensureBaseImage(ctx context.Context, image containerd.Image) (string, error) {
+	path := r.baseImagePath(image)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	buildCtx := context.WithoutCancel(ctx)
+	results := r.buildGroup.DoChan(path, func() (interface{}, error) {
+		if _, err := os.Stat(path); err == nil {
+			return nil, nil
+		}
+
+		ctx, cancel := context.WithTimeout(buildCtx, rootfsBuildTimeout)
+		defer cancel()
+
+		return nil, r.buildBaseImage(ctx, image, path)
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return "", result.Err
+		}
+
+		return path, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// buildBaseImage builds the ext4 image for the image at path.
+func (r *fileRootfs) buildBaseImage(ctx context.Context, image containerd.Image, path string) error {
+	start := time.Now()
+	r.logger.Info().Str("image", image.Name()).Str("digest", image.Target().Digest.String()).Msg("Building rootfs image")
+
+	// Keep the temporary view alive only for as long as the build takes.
+	leaseCtx, leaseCancel, err := r.containerd.WithLease(ctx, leases.WithRandomID(), leases.WithExpiration(time.Hour))
+	if err != nil {
+		return fmt.Errorf("containerd: creating lease: %w", err)
+	}
+	defer func() {
+		_ = leaseCancel(context.Background())
+	}()
+
+	imageContent, err := image.RootFS(leaseCtx)
+	if err != nil {
+		return fmt.Errorf("image: rootfs: %w", err)
+	}
+
+	snapshotService := r.containerd.SnapshotService(r.snapshotter)
+	viewKey := fmt.Sprintf("fireactions-rootfs-%s", image.Target().Digest.Encoded())
+	mounts, err := snapshotService.View(leaseCtx, viewKey, identity.ChainID(imageContent).String())
+	if err != nil {
+		return fmt.Errorf("view: %w", err)
+	}
+	defer func() {
+		_ = snapshotService.Remove(context.Background(), viewKey)
+	}()
+
+	tmpPath := path + ".tmp"
+	err = mount.WithTempMount(leaseCtx, mounts, func(root string) error {
+		return makeExt4Image(ctx, root, tmpPath, r.sizeMib)
+	})
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	r.logger.Info().Str("image", image.Name()).Dur("duration", time.Since(start)).Msg("Rootfs image built successfully")
+	return nil
+}
+
+// makeExt4Image creates an ext4 filesystem image at path populated with the
+// contents of dir.
+func makeExt4Image(ctx context.Context, dir, path string, sizeMib int64) error {
+	usedMib, err := dirSizeMib(dir)
+	if err != nil {
+		return fmt.Errorf("calculating rootfs size: %w", err)
+	}
+
+	if usedMib >= sizeMib {
+		return fmt.Errorf("image contents (%d MiB) do not fit into the rootfs size (%d MiB)", usedMib, sizeMib)
+	}
+
+	cmd := exec.CommandContext(ctx, "mkfs.ext4", "-q", "-F", "-L", "rootfs", "-d", dir, path, fmt.Sprintf("%dM", sizeMib))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("mkfs.ext4: %w: %s", err, output)
+	}
+
+	return nil
+}
+
+// dirSizeMib returns the size of the files in dir. Hardlinked files are
+// counted once, as mkfs.ext4 stores their data once.
+func dirSizeMib(dir string) (int64, error) {
+	type inode struct{ dev, ino uint64 }
+	seen := make(map[inode]struct{})
+
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok && !info.IsDir() && stat.Nlink > 1 {
+			key := inode{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}
+			if _, ok := seen[key]; ok {
+				return nil
+			}
+			seen[key] = struct{}{}
+		}
+
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size/(1024*1024) + 1, nil
+}
+
+// syncPath flushes the data of the file or block device at path to disk.
+func syncPath(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
+
+// cloneFile creates dst as a copy-on-write clone of src. It falls back to a
+// sparse copy when the filesystem does not support reflinks.
+func cloneFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	if err := unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd())); err == nil {
+		return nil
+	}
+
+	return sparseCopy(srcFile, dstFile)
+}
+
+// sparseCopy copies the data regions of src into dst, leaving holes unallocated.
+func sparseCopy(src, dst *os.File) error {
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	size := info.Size()
+	if err := dst.Truncate(size); err != nil {
+		return err
+	}
+
+	var offset int64
+	for offset < size {
+		dataStart, err := unix.Seek(int(src.Fd()), offset, unix.SEEK_DATA)
+		if err != nil {
+			if errors.Is(err, unix.ENXIO) {
+				// No more data until the end of the file.
+				return nil
+			}
+
+			return err
+		}
+
+		dataEnd, err := unix.Seek(int(src.Fd()), dataStart, unix.SEEK_HOLE)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(
+			io.NewOffsetWriter(dst, dataStart),
+			io.NewSectionReader(src, dataStart, dataEnd-dataStart))
+		if err != nil {
+			return err
+		}
+
+		offset = dataEnd
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.ext4")
+
+	f, err := os.Create(src)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(8*1024*1024))
+	_, err = f.WriteAt([]byte("header"), 0)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte("trailer"), 6*1024*1024)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	dst := filepath.Join(dir, "dst.ext4")
+	require.NoError(t, cloneFile(src, dst))
+
+	srcData, err := os.ReadFile(src)
+	require.NoError(t, err)
+	dstData, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, srcData, dstData)
+
+	assert.Error(t, cloneFile(src, dst), "clone must not overwrite an existing file")
+}
+
+func TestFileRootfs_Release(t *testing.T) {
+	r := &fileRootfs{dir: t.TempDir()}
+
+	require.NoError(t, os.WriteFile(r.machinePath("vm1"), []byte("rootfs"), 0644))
+	assert.NoError(t, r.release(t.Context(), "vm1"))
+	assert.NoFileExists(t, r.machinePath("vm1"))
+
+	assert.NoError(t, r.release(t.Context(), "vm1"), "releasing a missing rootfs is not an error")
+}
+
+func TestFileRootfs_CommitReset(t *testing.T) {
+	r := &fileRootfs{dir: t.TempDir()}
+
+	require.NoError(t, os.WriteFile(r.machinePath("vm1"), []byte("booted"), 0644))
+	require.NoError(t, r.commit(t.Context(), "vm1"))
+	assert.NoFileExists(t, r.machinePath("vm1"))
+
+	for range 2 {
+		path, err := r.reset(t.Context(), "vm1")
+		require.NoError(t, err)
+		assert.Equal(t, r.machinePath("vm1"), path)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "booted", string(data))
+
+		// The job changes the drive, but not the restore point
+		require.NoError(t, os.WriteFile(path, []byte("job"), 0644))
+	}
+
+	require.NoError(t, r.release(t.Context(), "vm1"))
+	assert.NoFileExists(t, r.machinePath("vm1"))
+	assert.NoFileExists(t, r.restorePointPath("vm1"))
+}
+
+func TestFileRootfs_EnsureBaseImageCancel(t *testing.T) {
+	r := &fileRootfs{dir: t.TempDir()}
+	require.NoError(t, os.MkdirAll(filepath.Join(r.dir, "images"), 0755))
+	image := &fakeImage{name: "runner:latest"}
+	path := r.baseImagePath(image)
+
+	// A build of the digest started by another machine
+	release := make(chan struct{})
+	build := r.buildGroup.DoChan(path, func() (interface{}, error) {
+		<-release
+		return nil, os.WriteFile(path, []byte("rootfs"), 0644)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := r.ensureBaseImage(ctx, image)
+	assert.ErrorIs(t, err, context.Canceled, "a cancelled machine stops waiting for the build")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.ensureBaseImage(t.Context(), image)
+		done <- err
+	}()
+
+	close(release)
+	require.NoError(t, (<-build).Err)
+	assert.NoError(t, <-done, "other machines get the result of the shared build")
+	assert.FileExists(t, path)
+}
+
+func TestDirSizeMib_Hardlinks(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, 2*1024*1024)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), data, 0644))
+	for _, name := range []string{"link1", "link2", "link3"} {
+		require.NoError(t, os.Link(filepath.Join(dir, "file"), filepath.Join(dir, name)))
+	}
+
+	size, err := dirSizeMib(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), size, "hardlinked files are counted once")
+}
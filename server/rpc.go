@@ -2,9 +2,15 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sort"
+	"time"
 
+	"github.com/containerd/errdefs"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/hostinger/fireactions/helper/logger"
 	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
 	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
 	"google.golang.org/grpc/codes"
@@ -135,24 +141,9 @@ func (s *Server) ListMachines(ctx context.Context, req *serverv1.ListMachinesReq
 		return machines[i].Name < machines[j].Name
 	})
 
-	// Convert machines to proto in parallel for better performance
-	protoMachines := make([]*serverv1.Machine, len(machines))
-	type result struct {
-		index int
-		proto *serverv1.Machine
-	}
-	results := make(chan result, len(machines))
-
-	for i, machine := range machines {
-		go func(idx int, m *Machine) {
-			results <- result{index: idx, proto: convertMachineToProto(ctx, m)}
-		}(i, machine)
-	}
-
-	// Collect results
-	for range machines {
-		r := <-results
-		protoMachines[r.index] = r.proto
+	protoMachines := make([]*serverv1.Machine, 0, len(machines))
+	for _, machine := range machines {
+		protoMachines = append(protoMachines, convertMachineToProto(machine))
 	}
 
 	return &serverv1.ListMachinesResponse{Machines: protoMachines}, nil
@@ -165,7 +156,7 @@ func (s *Server) GetMachine(ctx context.Context, req *serverv1.GetMachineRequest
 		return nil, status.Errorf(codes.NotFound, "machine not found: %v", err)
 	}
 
-	return &serverv1.GetMachineResponse{Machine: convertMachineToProto(ctx, machine)}, nil
+	return &serverv1.GetMachineResponse{Machine: convertMachineToProto(machine)}, nil
 }
 
 // GetHealth implements ServerService.GetHealth.
@@ -178,6 +169,39 @@ func (s *Server) GetVersion(ctx context.Context, req *serverv1.GetVersionRequest
 	return &serverv1.GetVersionResponse{Version: s.version, Commit: s.commit, Date: s.date}, nil
 }
 
+// SetLogLevel implements ServerService.SetLogLevel. Without a machine ID the
+// level of the server is changed, otherwise the request is proxied to the
+// agent of the machine.
+func (s *Server) SetLogLevel(ctx context.Context, req *serverv1.SetLogLevelRequest) (*serverv1.SetLogLevelResponse, error) {
+	if req.GetID() == "" {
+		previous, err := logger.SetLevel(req.GetLevel())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid log level: %v", err)
+		}
+
+		s.logger.Info().Msgf("Log level changed from %s to %s", previous, req.GetLevel())
+		return &serverv1.SetLogLevelResponse{PreviousLevel: previous}, nil
+	}
+
+	machine, err := s.findMachine(req.GetID())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	conn, client, err := machine.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+	defer conn.Close()
+
+	agentResp, err := client.SetLogLevel(ctx, &agentv1.SetLogLevelRequest{Level: req.GetLevel()})
+	if err != nil {
+		return nil, status.Errorf(status.Code(err), "agent SetLogLevel: %s", status.Convert(err).Message())
+	}
+
+	return &serverv1.SetLogLevelResponse{PreviousLevel: agentResp.GetPreviousLevel()}, nil
+}
+
 // GetMachineLogs implements ServerService.GetMachineLogs (server-side streaming).
 func (s *Server) GetMachineLogs(req *serverv1.GetMachineLogsRequest, stream serverv1.ServerService_GetMachineLogsServer) error {
 	ctx := stream.Context()
@@ -196,6 +220,7 @@ func (s *Server) GetMachineLogs(req *serverv1.GetMachineLogsRequest, stream serv
 	agentStream, err := client.GetLogs(ctx, &agentv1.GetLogsRequest{
 		Follow:    req.Follow,
 		TailLines: req.TailLines,
+		Source:    req.Source,
 	})
 	if err != nil {
 		return status.Errorf(codes.Internal, "agent GetLogs: %v", err)
@@ -208,7 +233,8 @@ func (s *Server) GetMachineLogs(req *serverv1.GetMachineLogsRequest, stream serv
 			return nil
 		}
 		if err != nil {
-			return status.Errorf(codes.Internal, "receive from agent: %v", err)
+			// Keep the code, e.g. InvalidArgument for an unknown source
+			return status.Errorf(status.Code(err), "receive from agent: %s", status.Convert(err).Message())
 		}
 
 		// Forward to client
@@ -218,6 +244,266 @@ func (s *Server) GetMachineLogs(req *serverv1.GetMachineLogsRequest, stream serv
 	}
 }
 
+// ListMachineLogSources implements ServerService.ListMachineLogSources.
+func (s *Server) ListMachineLogSources(ctx context.Context, req *serverv1.ListMachineLogSourcesRequest) (*serverv1.ListMachineLogSourcesResponse, error) {
+	machine, err := s.findMachine(req.GetID())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	conn, client, err := machine.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+	defer conn.Close()
+
+	agentResp, err := client.ListLogSources(ctx, &agentv1.ListLogSourcesRequest{})
+	if err != nil {
+		return nil, status.Errorf(status.Code(err), "agent ListLogSources: %s", status.Convert(err).Message())
+	}
+
+	resp := &serverv1.ListMachineLogSourcesResponse{}
+	for _, source := range agentResp.GetSources() {
+		resp.Sources = append(resp.Sources, convertLogSourceToProto(source))
+	}
+
+	return resp, nil
+}
+
+// ExecMachine implements ServerService.ExecMachine (bidirectional streaming).
+// It proxies the command to the agent of the machine.
+func (s *Server) ExecMachine(stream serverv1.ServerService_ExecMachineServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	start := req.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "first request must start a command")
+	}
+
+	machine, err := s.findMachine(start.GetID())
+	if err != nil {
+		return status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	user := start.GetUser()
+	if start.GetRunnerUser() {
+		pool, err := s.findPool(machine.Pool)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "pool not found: %v", err)
+		}
+
+		user = pool.runnerUser()
+	}
+
+	conn, client, err := machine.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+	defer conn.Close()
+
+	agentStream, err := client.Exec(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "agent Exec: %v", err)
+	}
+
+	err = agentStream.Send(&agentv1.ExecRequest{Request: &agentv1.ExecRequest_Start{Start: &agentv1.ExecStart{
+		Command:    start.GetCommand(),
+		Env:        start.GetEnv(),
+		WorkingDir: start.GetWorkingDir(),
+		User:       user,
+		Tty:        start.GetTty(),
+		TerminalSize: &agentv1.TerminalSize{
+			Rows: start.GetTerminalSize().GetRows(),
+			Cols: start.GetTerminalSize().GetCols(),
+		},
+	}}})
+	if err != nil {
+		return status.Errorf(codes.Internal, "send to agent: %v", err)
+	}
+
+	s.logger.Info().Strs("command", start.GetCommand()).Str("user", user).Bool("tty", start.GetTty()).Msgf("Executing command in machine %s", machine.Name)
+
+	// Proxy stdin from CLI to agent
+	go func() {
+		defer func() { _ = agentStream.CloseSend() }()
+
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			agentReq := &agentv1.ExecRequest{}
+			switch r := req.Request.(type) {
+			case *serverv1.ExecMachineRequest_Stdin:
+				agentReq.Request = &agentv1.ExecRequest_Stdin{Stdin: r.Stdin}
+			case *serverv1.ExecMachineRequest_CloseStdin:
+				agentReq.Request = &agentv1.ExecRequest_CloseStdin{CloseStdin: r.CloseStdin}
+			case *serverv1.ExecMachineRequest_Resize:
+				agentReq.Request = &agentv1.ExecRequest_Resize{Resize: &agentv1.TerminalSize{
+					Rows: r.Resize.GetRows(),
+					Cols: r.Resize.GetCols(),
+				}}
+			case *serverv1.ExecMachineRequest_Signal:
+				agentReq.Request = &agentv1.ExecRequest_Signal{Signal: r.Signal}
+			default:
+				continue
+			}
+
+			if err := agentStream.Send(agentReq); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Proxy output from agent to CLI
+	for {
+		agentResp, err := agentStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+				return err
+			}
+			return status.Errorf(codes.Internal, "receive from agent: %v", err)
+		}
+
+		resp := &serverv1.ExecMachineResponse{}
+		switch r := agentResp.Response.(type) {
+		case *agentv1.ExecResponse_Stdout:
+			resp.Response = &serverv1.ExecMachineResponse_Stdout{Stdout: r.Stdout}
+		case *agentv1.ExecResponse_Stderr:
+			resp.Response = &serverv1.ExecMachineResponse_Stderr{Stderr: r.Stderr}
+		case *agentv1.ExecResponse_ExitCode:
+			resp.Response = &serverv1.ExecMachineResponse_ExitCode{ExitCode: r.ExitCode}
+		default:
+			continue
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// CopyFromMachine implements ServerService.CopyFromMachine (server-side
+// streaming). It proxies the tar archive from the agent of the machine.
+func (s *Server) CopyFromMachine(req *serverv1.CopyFromMachineRequest, stream serverv1.ServerService_CopyFromMachineServer) error {
+	ctx := stream.Context()
+
+	machine, err := s.findMachine(req.GetID())
+	if err != nil {
+		return status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	conn, client, err := machine.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+	defer conn.Close()
+
+	agentStream, err := client.CopyFrom(ctx, &agentv1.CopyFromRequest{Path: req.GetPath()})
+	if err != nil {
+		return status.Errorf(codes.Internal, "agent CopyFrom: %v", err)
+	}
+
+	s.logger.Info().Str("path", req.GetPath()).Msgf("Copying from machine %s", machine.Name)
+
+	for {
+		agentResp, err := agentStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&serverv1.CopyFromMachineResponse{Data: agentResp.GetData()}); err != nil {
+			return err
+		}
+	}
+}
+
+// CopyToMachine implements ServerService.CopyToMachine (client-side
+// streaming). It proxies the tar archive to the agent of the machine.
+func (s *Server) CopyToMachine(stream serverv1.ServerService_CopyToMachineServer) error {
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	machine, err := s.findMachine(req.GetID())
+	if err != nil {
+		return status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	conn, client, err := machine.ConnectToGuestAgent(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+	defer conn.Close()
+
+	agentStream, err := client.CopyTo(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "agent CopyTo: %v", err)
+	}
+
+	s.logger.Info().Str("path", req.GetPath()).Msgf("Copying to machine %s", machine.Name)
+
+	agentReq := &agentv1.CopyToRequest{Path: req.GetPath(), Data: req.GetData()}
+	for {
+		// A failed send means the agent closed the stream, its error is
+		// returned by CloseAndRecv.
+		if err := agentStream.Send(agentReq); err != nil {
+			break
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		agentReq = &agentv1.CopyToRequest{Data: req.GetData()}
+	}
+
+	if _, err := agentStream.CloseAndRecv(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&serverv1.CopyToMachineResponse{})
+}
+
+// GetMachineStats implements ServerService.GetMachineStats. It returns the
+// resource usage reported by the agent of the machine.
+func (s *Server) GetMachineStats(ctx context.Context, req *serverv1.GetMachineStatsRequest) (*serverv1.GetMachineStatsResponse, error) {
+	machine, err := s.findMachine(req.GetID())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "machine not found: %v", err)
+	}
+
+	stats, err := machine.getStats(ctx, int(req.GetTopProcesses()))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		return nil, status.Errorf(codes.Internal, "connect to agent: %v", err)
+	}
+
+	return convertStatsToProto(stats), nil
+}
+
 // ListImages implements ServerService.ListImages.
 func (s *Server) ListImages(ctx context.Context, req *serverv1.ListImagesRequest) (*serverv1.ListImagesResponse, error) {
 	images, err := s.imageManager.listImages(ctx)
@@ -241,3 +527,149 @@ func (s *Server) RemoveImage(ctx context.Context, req *serverv1.RemoveImageReque
 
 	return &serverv1.RemoveImageResponse{Message: "Image removed successfully"}, nil
 }
+
+// PullImage implements ServerService.PullImage (server-side streaming).
+func (s *Server) PullImage(req *serverv1.PullImageRequest, stream serverv1.ServerService_PullImageServer) error {
+	ctx := stream.Context()
+
+	imageType := req.GetType()
+	switch imageType {
+	case "":
+		imageType = imageTypeRootfs
+	case imageTypeRootfs, imageTypeKernel:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid image type: %s", req.GetType())
+	}
+
+	imageManager := s.imageManager
+	if req.GetPool() != "" {
+		pool, err := s.findPool(req.GetPool())
+		if err != nil {
+			return status.Errorf(codes.NotFound, "pool not found: %v", err)
+		}
+
+		imageManager = pool.imageManager
+	}
+
+	var sendErr error
+	image, err := imageManager.pullImageWithProgress(ctx, req.GetName(), imageType, 500*time.Millisecond, func(layers []layerProgress, resolved bool) {
+		if sendErr != nil {
+			return
+		}
+
+		sendErr = stream.Send(convertPullProgressToProto(layers, resolved))
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "pull image: %v", err)
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if imageType == imageTypeKernel {
+		if _, err := imageManager.ensureKernel(ctx, req.GetName(), "Never"); err != nil {
+			return status.Errorf(codes.Internal, "extract kernel: %v", err)
+		}
+	} else {
+		unpack, err := s.verifyPulledImage(ctx, image.Name(), image.Target().Digest)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "verify image: %v", err)
+		}
+
+		if unpack {
+			if err := imageManager.unpackImage(ctx, image); err != nil {
+				return status.Errorf(codes.Internal, "unpack image: %v", err)
+			}
+		}
+	}
+
+	return stream.Send(&serverv1.PullImageResponse{
+		Status: serverv1.PullImageStatus_PULL_IMAGE_STATUS_DONE,
+		Digest: image.Target().Digest.String(),
+	})
+}
+
+// verifyPulledImage verifies a pulled rootfs image with the verifiers of the
+// pools running it, as images are never unpacked unverified. It reports
+// whether the image may be unpacked: if any pool verifies signatures, images
+// no pool runs are only pulled, they are verified once a pool uses them.
+func (s *Server) verifyPulledImage(ctx context.Context, name string, dgst digest.Digest) (bool, error) {
+	s.l.Lock()
+	pools := make([]*Pool, 0, len(s.pools))
+	for _, pool := range s.pools {
+		pools = append(pools, pool)
+	}
+	s.l.Unlock()
+
+	used, verifying := false, false
+	for _, pool := range pools {
+		verifying = verifying || pool.verifier != nil
+		if pool.config.Runner.Image != name {
+			continue
+		}
+
+		used = true
+		if pool.verifier == nil {
+			continue
+		}
+
+		err := pool.verifier.verify(ctx, name, dgst)
+		metricImageVerifications.WithLabelValues(pool.config.Name, verificationResult(err)).Inc()
+		if err != nil {
+			return false, fmt.Errorf("pool %s: %w", pool.config.Name, err)
+		}
+	}
+
+	return used || !verifying, nil
+}
+
+// InspectImage implements ServerService.InspectImage.
+func (s *Server) InspectImage(ctx context.Context, req *serverv1.InspectImageRequest) (*serverv1.InspectImageResponse, error) {
+	image, err := s.imageManager.getLocalImage(ctx, req.GetName())
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "image not found: %s", req.GetName())
+		}
+
+		return nil, status.Errorf(codes.Internal, "get image: %v", err)
+	}
+
+	details, err := s.imageManager.inspectImage(ctx, image)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "inspect image: %v", err)
+	}
+
+	s.l.Lock()
+	pools := make([]*Pool, 0, len(s.pools))
+	for _, pool := range s.pools {
+		pools = append(pools, pool)
+	}
+	s.l.Unlock()
+
+	resp := convertImageDetailsToProto(ctx, image, details)
+	digest := image.Target().Digest.String()
+	for _, pool := range pools {
+		pinned := pool.getImage()
+		if pool.config.Runner.Image == image.Name() || pool.config.Firecracker.KernelImage == image.Name() ||
+			(pinned != nil && pinned.Target().Digest.String() == digest) {
+			resp.Pools = append(resp.Pools, pool.config.Name)
+		}
+
+		machines, err := pool.ListMachines(ctx)
+		if err != nil {
+			continue
+		}
+
+		for _, machine := range machines {
+			if machine.ImageDigest == digest || machine.KernelDigest == digest {
+				resp.Machines = append(resp.Machines, machine.Name)
+			}
+		}
+	}
+
+	sort.Strings(resp.Pools)
+	sort.Strings(resp.Machines)
+
+	return resp, nil
+}
@@ -1 +1,88 @@
 package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	serverv1 "github.com/hostinger/fireactions/proto/server/v1"
+	"github.com/opencontainers/go-digest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	logger := zerolog.Nop()
+	s := &Server{logger: &logger, l: &sync.Mutex{}}
+
+	resp, err := s.SetLogLevel(context.Background(), &serverv1.SetLogLevelRequest{Level: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, "info", resp.GetPreviousLevel())
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	_, err = s.SetLogLevel(context.Background(), &serverv1.SetLogLevelRequest{Level: "verbose"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.SetLogLevel(context.Background(), &serverv1.SetLogLevelRequest{Level: "debug", ID: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_VerifyPulledImage(t *testing.T) {
+	logger := zerolog.Nop()
+	verifier, err := newImageVerifier(&logger, nil, nil, time.Minute)
+	require.NoError(t, err)
+
+	signed, unsigned := digest.FromString("signed"), digest.FromString("unsigned")
+	verifier.cache[signed] = verification{}
+	verifier.cache[unsigned] = verification{err: errImageUnsigned, expires: time.Now().Add(time.Minute)}
+
+	s := &Server{l: &sync.Mutex{}, pools: map[string]*Pool{
+		"verified": {config: &PoolConfig{Name: "verified", Runner: &RunnerConfig{Image: "ghcr.io/example/runner:latest"}}, verifier: verifier},
+		"plain":    {config: &PoolConfig{Name: "plain", Runner: &RunnerConfig{Image: "ghcr.io/example/plain:latest"}}},
+	}}
+
+	unpack, err := s.verifyPulledImage(t.Context(), "ghcr.io/example/runner:latest", signed)
+	require.NoError(t, err)
+	assert.True(t, unpack)
+
+	_, err = s.verifyPulledImage(t.Context(), "ghcr.io/example/runner:latest", unsigned)
+	assert.ErrorIs(t, err, errImageUnsigned, "unsigned images of verifying pools are rejected")
+
+	unpack, err = s.verifyPulledImage(t.Context(), "ghcr.io/example/plain:latest", unsigned)
+	require.NoError(t, err)
+	assert.True(t, unpack, "images of pools without verification are unpacked")
+
+	unpack, err = s.verifyPulledImage(t.Context(), "ghcr.io/example/other:latest", unsigned)
+	require.NoError(t, err)
+	assert.False(t, unpack, "images no pool runs cannot be verified and are not unpacked")
+
+	delete(s.pools, "verified")
+	unpack, err = s.verifyPulledImage(t.Context(), "ghcr.io/example/other:latest", unsigned)
+	require.NoError(t, err)
+	assert.True(t, unpack, "images are unpacked if no pool verifies signatures")
+}
+
+// pullImageStream is a PullImage stream that only carries a context.
+type pullImageStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *pullImageStream) Context() context.Context { return s.ctx }
+
+func (s *pullImageStream) Send(*serverv1.PullImageResponse) error { return nil }
+
+func TestServer_PullImage_UnknownPool(t *testing.T) {
+	s := &Server{l: &sync.Mutex{}, pools: map[string]*Pool{}}
+
+	err := s.PullImage(&serverv1.PullImageRequest{Name: "ghcr.io/example/runner:latest", Pool: "missing"}, &pullImageStream{ctx: t.Context()})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the registries of an unknown pool cannot be used")
+}
@@ -30,6 +30,7 @@ type Server struct {
 	github        *github.Client
 	containerd    *containerd.Client
 	imageManager  *imageManager
+	rootfs        rootfsBackend
 	l             *sync.Mutex
 	logger        *zerolog.Logger
 	nextCID       atomic.Uint32 // Global VSOCK CID counter (starts at 3)
@@ -96,7 +97,13 @@ func New(config *Config, opts ...Opt) (*Server, error) {
 		opt(s)
 	}
 
-	s.imageManager = newImageManager(s.logger, containerdClient)
+	s.imageManager = newImageManager(s.logger, containerdClient, config.Containerd.Snapshotter, config.Images.KernelDir, config.Registries)
+
+	rootfs, err := newRootfsBackend(s.logger, config, containerdClient)
+	if err != nil {
+		return nil, fmt.Errorf("rootfs: %w", err)
+	}
+	s.rootfs = rootfs
 
 	// Register gRPC service
 	serverv1.RegisterServerServiceServer(grpcServer, s)
@@ -133,15 +140,36 @@ func (s *Server) Run(ctx context.Context) error {
 		_ = listener.Close()
 	}()
 
+	pools := make([]*Pool, 0, len(s.config.Pools))
 	for _, poolConfig := range s.config.Pools {
-		pool, err := NewPool(s.logger, poolConfig, s.github, s.imageManager, s.containerd, &s.nextCID)
+		pool, err := NewPool(s.logger, poolConfig, s.github, s.imageManager, s.rootfs, s.containerd, &s.nextCID, s.config.Heartbeat, s.config.Images.RefreshInterval)
 		if err != nil {
 			return fmt.Errorf("creating pool: %w", err)
 		}
 
 		s.pools[poolConfig.Name] = pool
+		pools = append(pools, pool)
+	}
+
+	// Pre-pull images before the pools start scaling so that the first
+	// machines don't all wait for the same pulls.
+	imageController := newImageController(s.logger, pools, s.config.Images.RefreshInterval)
+	imageController.prePull(ctx)
+	go imageController.Run(ctx)
+
+	if s.config.Images.GC.Enabled {
+		imageGC := newImageGC(s.logger, s.containerd, s.imageManager, s.rootfs, pools, s.config.Images.GC)
+		go imageGC.Run(ctx)
+	}
+
+	if s.config.Metrics.Enabled && s.config.Metrics.StatsInterval > 0 {
+		statsScraper := newStatsScraper(s.logger, pools, s.config.Metrics.StatsInterval)
+		go statsScraper.Run(ctx)
+	}
+
+	for _, pool := range pools {
 		go pool.Run()
-		s.logger.Info().Msgf("Pool %s started", poolConfig.Name)
+		s.logger.Info().Msgf("Pool %s started", pool.config.Name)
 	}
 
 	errGroup := &errgroup.Group{}
@@ -161,12 +189,10 @@ func (s *Server) Run(ctx context.Context) error {
 
 		s.logger.Info().Msg("Shutting down server")
 
-		// Stop pools sequentially to avoid lock contention and race conditions
-		for name, pool := range s.pools {
-			s.logger.Info().Msgf("Stopping pool %s", name)
-			pool.Stop()
-			s.logger.Info().Msgf("Pool %s stopped", name)
-		}
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), s.config.shutdownTimeout())
+		defer stopCancel()
+
+		s.stopPools(stopCtx)
 
 		cancelCtx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 		defer cancel()
@@ -190,6 +216,24 @@ func (s *Server) Run(ctx context.Context) error {
 	return nil
 }
 
+// stopPools stops all pools concurrently, so that the shutdown takes as long
+// as the shutdown grace period of the slowest pool rather than their sum, and
+// at most until ctx is done.
+func (s *Server) stopPools(ctx context.Context) {
+	var wg sync.WaitGroup
+	for name, pool := range s.pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s.logger.Info().Msgf("Stopping pool %s", name)
+			pool.Stop(ctx)
+			s.logger.Info().Msgf("Pool %s stopped", name)
+		}()
+	}
+	wg.Wait()
+}
+
 func (s *Server) findPool(id string) (*Pool, error) {
 	s.l.Lock()
 	defer s.l.Unlock()
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+const (
+	statsScrapeTimeout = 10 * time.Second
+)
+
+// machineStatsGauges are the per-machine gauges set by the statsScraper.
+var machineStatsGauges = []*prometheus.GaugeVec{
+	metricMachineCPUs,
+	metricMachineLoad1,
+	metricMachineLoad5,
+	metricMachineLoad15,
+	metricMachineMemoryTotal,
+	metricMachineMemoryUsed,
+	metricMachineMemoryAvailable,
+	metricMachineSwapUsed,
+	metricMachineDiskTotal,
+	metricMachineDiskUsed,
+}
+
+// machineStatsCounters are the per-machine counters increased by the
+// statsScraper.
+var machineStatsCounters = []*prometheus.CounterVec{
+	metricMachineCPUSeconds,
+	metricMachineNetworkReceive,
+	metricMachineNetworkTransmit,
+}
+
+// statsScraper periodically fetches the resource usage of every machine from
+// its guest agent and exports it as Prometheus gauges, and the cumulative
+// counters of the guest as Prometheus counters. Series of machines that are
+// gone are removed.
+type statsScraper struct {
+	pools    []*Pool
+	interval time.Duration
+	logger   *zerolog.Logger
+
+	// getStats fetches the stats of a machine, replaceable for testing.
+	getStats func(ctx context.Context, machine *Machine) (*agentv1.GetStatsResponse, error)
+
+	// scraped are the machines with exported series, by name.
+	scraped map[string]bool
+
+	// counters turns the guest counters into increases of the Prometheus
+	// counters.
+	counters *guestCounters
+}
+
+// newStatsScraper creates a new statsScraper.
+func newStatsScraper(logger *zerolog.Logger, pools []*Pool, interval time.Duration) *statsScraper {
+	l := logger.With().Str("component", "stats-scraper").Logger()
+
+	s := &statsScraper{
+		pools:    pools,
+		interval: interval,
+		logger:   &l,
+		getStats: func(ctx context.Context, machine *Machine) (*agentv1.GetStatsResponse, error) {
+			// Processes are not exported, only count the resources
+			return machine.getStats(ctx, 1)
+		},
+		scraped:  make(map[string]bool),
+		counters: &guestCounters{last: make(map[string]map[string]float64)},
+	}
+
+	return s
+}
+
+// Run periodically scrapes the stats of all machines until the context is
+// canceled.
+func (s *statsScraper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scrape(ctx)
+		}
+	}
+}
+
+func (s *statsScraper) scrape(ctx context.Context) {
+	timeout := min(s.interval, statsScrapeTimeout)
+
+	var wg sync.WaitGroup
+	live := make(map[string]bool)
+	for _, pool := range s.pools {
+		machines, _ := pool.ListMachines(ctx)
+		for _, machine := range machines {
+			live[machine.Name] = true
+
+			wg.Add(1)
+			go func(pool string, machine *Machine) {
+				defer wg.Done()
+
+				scrapeCtx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+
+				stats, err := s.getStats(scrapeCtx, machine)
+				if err != nil {
+					if ctx.Err() == nil {
+						metricMachineStatsErrors.WithLabelValues(pool).Inc()
+						s.logger.Debug().Err(err).Str("pool", pool).Msgf("Failed to get stats of machine %s", machine.Name)
+					}
+
+					return
+				}
+
+				s.setMachineStatsMetrics(pool, machine.Name, stats)
+			}(pool.config.Name, machine)
+		}
+	}
+	wg.Wait()
+
+	for name := range s.scraped {
+		if !live[name] {
+			s.deleteMachineStatsMetrics(name)
+		}
+	}
+	s.scraped = live
+}
+
+func (s *statsScraper) setMachineStatsMetrics(pool, machine string, stats *agentv1.GetStatsResponse) {
+	metricMachineCPUs.WithLabelValues(pool, machine).Set(float64(stats.GetCpu().GetCount()))
+	metricMachineLoad1.WithLabelValues(pool, machine).Set(stats.GetLoad().GetLoad1())
+	metricMachineLoad5.WithLabelValues(pool, machine).Set(stats.GetLoad().GetLoad5())
+	metricMachineLoad15.WithLabelValues(pool, machine).Set(stats.GetLoad().GetLoad15())
+	metricMachineMemoryTotal.WithLabelValues(pool, machine).Set(float64(stats.GetMemory().GetTotal()))
+	metricMachineMemoryUsed.WithLabelValues(pool, machine).Set(float64(stats.GetMemory().GetUsed()))
+	metricMachineMemoryAvailable.WithLabelValues(pool, machine).Set(float64(stats.GetMemory().GetAvailable()))
+	metricMachineSwapUsed.WithLabelValues(pool, machine).Set(float64(stats.GetMemory().GetSwapUsed()))
+
+	// Volumes may come and go, drop the previous series first
+	for _, gauge := range []*prometheus.GaugeVec{metricMachineDiskTotal, metricMachineDiskUsed} {
+		gauge.DeletePartialMatch(prometheus.Labels{"machine": machine})
+	}
+
+	for _, disk := range stats.GetDisks() {
+		metricMachineDiskTotal.WithLabelValues(pool, machine, disk.GetMountpoint()).Set(float64(disk.GetTotal()))
+		metricMachineDiskUsed.WithLabelValues(pool, machine, disk.GetMountpoint()).Set(float64(disk.GetUsed()))
+	}
+
+	s.counters.add(metricMachineCPUSeconds.WithLabelValues(pool, machine), machine, "cpu", stats.GetCpu().GetBusySeconds())
+	for _, network := range stats.GetNetworks() {
+		s.counters.add(metricMachineNetworkReceive.WithLabelValues(pool, machine, network.GetInterface()), machine, "rx/"+network.GetInterface(), float64(network.GetRxBytes()))
+		s.counters.add(metricMachineNetworkTransmit.WithLabelValues(pool, machine, network.GetInterface()), machine, "tx/"+network.GetInterface(), float64(network.GetTxBytes()))
+	}
+}
+
+func (s *statsScraper) deleteMachineStatsMetrics(machine string) {
+	for _, gauge := range machineStatsGauges {
+		gauge.DeletePartialMatch(prometheus.Labels{"machine": machine})
+	}
+
+	for _, counter := range machineStatsCounters {
+		counter.DeletePartialMatch(prometheus.Labels{"machine": machine})
+	}
+
+	s.counters.delete(machine)
+}
+
+// guestCounters turns the cumulative counters read from the guests of the
+// machines into increases of Prometheus counters.
+type guestCounters struct {
+	mu   sync.Mutex
+	last map[string]map[string]float64 // Last value by machine and series
+}
+
+// add increases counter by the increase of the guest counter series of the
+// machine since it was last seen. A guest counter that decreased was reset,
+// by a reboot or a restore of the VM, and its whole value is added.
+func (g *guestCounters) add(counter prometheus.Counter, machine, series string, value float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	last, ok := g.last[machine]
+	if !ok {
+		last = make(map[string]float64)
+		g.last[machine] = last
+	}
+
+	increase := value - last[series]
+	if increase < 0 {
+		increase = value
+	}
+
+	last[series] = value
+	counter.Add(increase)
+}
+
+// delete forgets the guest counters of the machine.
+func (g *guestCounters) delete(machine string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.last, machine)
+}
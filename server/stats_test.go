@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	agentv1 "github.com/hostinger/fireactions/proto/agent/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsScraper_Scrape(t *testing.T) {
+	logger := zerolog.Nop()
+	p := &Pool{
+		config:     &PoolConfig{Name: "stats"},
+		machinesMu: &sync.Mutex{},
+		machines: map[string]*Machine{
+			"stats-1": {Name: "stats-1"},
+			"stats-2": {Name: "stats-2"},
+		},
+	}
+
+	s := newStatsScraper(&logger, []*Pool{p}, time.Second)
+	busySeconds, txBytes := 12.5, uint64(20)
+	s.getStats = func(ctx context.Context, machine *Machine) (*agentv1.GetStatsResponse, error) {
+		if machine.Name == "stats-2" {
+			return nil, fmt.Errorf("agent unavailable")
+		}
+
+		return &agentv1.GetStatsResponse{
+			Cpu:      &agentv1.CPUStats{Count: 2, BusySeconds: busySeconds},
+			Load:     &agentv1.LoadStats{Load1: 1.5},
+			Memory:   &agentv1.MemoryStats{Total: 2048, Used: 512, Available: 1536},
+			Disks:    []*agentv1.DiskStats{{Mountpoint: "/", Total: 100, Used: 40}},
+			Networks: []*agentv1.NetworkStats{{Interface: "eth0", RxBytes: 10, TxBytes: txBytes}},
+		}, nil
+	}
+
+	s.scrape(context.Background())
+
+	assert.Equal(t, 12.5, testutil.ToFloat64(metricMachineCPUSeconds.WithLabelValues("stats", "stats-1")))
+	assert.Equal(t, 512.0, testutil.ToFloat64(metricMachineMemoryUsed.WithLabelValues("stats", "stats-1")))
+	assert.Equal(t, 40.0, testutil.ToFloat64(metricMachineDiskUsed.WithLabelValues("stats", "stats-1", "/")))
+	assert.Equal(t, 20.0, testutil.ToFloat64(metricMachineNetworkTransmit.WithLabelValues("stats", "stats-1", "eth0")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metricMachineStatsErrors.WithLabelValues("stats")))
+	assert.Equal(t, 1, testutil.CollectAndCount(metricMachineCPUSeconds), "failed machines are not exported")
+
+	// Counters increase by the increase of the guest counters
+	busySeconds, txBytes = 20, 50
+	s.scrape(context.Background())
+
+	assert.Equal(t, 20.0, testutil.ToFloat64(metricMachineCPUSeconds.WithLabelValues("stats", "stats-1")))
+	assert.Equal(t, 50.0, testutil.ToFloat64(metricMachineNetworkTransmit.WithLabelValues("stats", "stats-1", "eth0")))
+
+	// Guest counters reset by a reboot or restore are added whole
+	busySeconds, txBytes = 2, 5
+	s.scrape(context.Background())
+
+	assert.Equal(t, 22.0, testutil.ToFloat64(metricMachineCPUSeconds.WithLabelValues("stats", "stats-1")))
+	assert.Equal(t, 55.0, testutil.ToFloat64(metricMachineNetworkTransmit.WithLabelValues("stats", "stats-1", "eth0")))
+
+	// Series of removed machines are deleted
+	p.machines = map[string]*Machine{}
+	s.scrape(context.Background())
+
+	for _, gauge := range machineStatsGauges {
+		assert.Zero(t, testutil.CollectAndCount(gauge))
+	}
+
+	for _, counter := range machineStatsCounters {
+		assert.Zero(t, testutil.CollectAndCount(counter))
+	}
+	assert.Empty(t, s.counters.last)
+}